@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 
@@ -10,6 +11,9 @@ import (
 )
 
 func main() {
+	noCache := flag.Bool("no-cache", false, "bypass the on-disk LLM response cache")
+	flag.Parse()
+
 	// Check API key
 	apiKey := os.Getenv("OPENROUTER_API_KEY")
 	if apiKey == "" {
@@ -24,6 +28,9 @@ func main() {
 		BaseURL:      "https://openrouter.ai/api/v1",
 		DefaultModel: "anthropic/claude-3.5-sonnet",
 	}
+	if !*noCache {
+		config.CacheDir = llm.DefaultCacheDir
+	}
 
 	client, err := llm.NewClient(config)
 	if err != nil {
@@ -47,6 +54,11 @@ func main() {
 	demo3(client)
 	fmt.Println()
 
+	if !*noCache {
+		stats := client.CacheStats()
+		fmt.Printf("💾 Cache: %d hits, %d misses\n", stats.Hits, stats.Misses)
+	}
+
 	fmt.Println("✨ All demos completed successfully!")
 }
 
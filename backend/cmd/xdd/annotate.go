@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"xdd/internal/core"
+	"xdd/pkg/schema"
+)
+
+// runTag dispatches the tag subcommands.
+func runTag(args []string) error {
+	if len(args) < 3 || (args[0] != "add" && args[0] != "remove") {
+		return fmt.Errorf("usage: xdd tag add|remove <REQ> <TAG>...")
+	}
+	return tagChange(os.Stdout, specDir, args[1], args[0] == "remove", args[2:])
+}
+
+// runField dispatches the field subcommands.
+func runField(args []string) error {
+	switch {
+	case len(args) == 4 && args[0] == "set":
+		return fieldSet(os.Stdout, specDir, args[1], args[2], args[3])
+	case len(args) == 3 && args[0] == "unset":
+		return fieldSet(os.Stdout, specDir, args[1], args[2], "")
+	default:
+		return fmt.Errorf("usage: xdd field set <REQ> <NAME> <VALUE> | unset <REQ> <NAME>")
+	}
+}
+
+// tagChange adds (or removes) tags on a requirement given by ID or alias
+// and records the change in the changelog.
+func tagChange(w io.Writer, dir, ref string, remove bool, tags []string) error {
+	return withSpecLock(dir, func() error {
+		return applyTagChange(w, dir, ref, remove, tags)
+	})
+}
+
+// applyTagChange is tagChange once the lock is held.
+func applyTagChange(w io.Writer, dir, ref string, remove bool, tags []string) error {
+	repo, err := newSpecRepo(dir)
+	if err != nil {
+		return err
+	}
+	spec, err := repo.ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	req := spec.FindRequirement(ref)
+	if req == nil {
+		return fmt.Errorf("requirement %s not found", ref)
+	}
+	label := requirementLabel(*req)
+
+	evtID, err := schema.NewEventID()
+	if err != nil {
+		return err
+	}
+	event := &schema.RequirementTagsChanged{EventID_: evtID, RequirementID: req.ID, Timestamp_: time.Now()}
+	if remove {
+		event.Removed = tags
+	} else {
+		event.Added = tags
+	}
+	if err := spec.ChangeTags(req.ID, event.Added, event.Removed); err != nil {
+		return err
+	}
+
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, []schema.ChangelogEvent{event}); err != nil {
+		return fmt.Errorf("write specification and changelog: %w", err)
+	}
+	fmt.Fprintf(w, "%s tags: %s\n", label, strings.Join(spec.FindRequirement(event.RequirementID).Tags, ", "))
+	return nil
+}
+
+// fieldSet sets (or, with an empty value, clears) a custom field declared
+// in the project config on a requirement given by ID or alias.
+func fieldSet(w io.Writer, dir, ref, name, value string) error {
+	cfg, err := core.LoadProjectConfig(filepath.Join(dir, "config.yml"))
+	if err != nil {
+		return err
+	}
+	def := schema.FindField(cfg.Fields, name)
+	if def == nil && value != "" {
+		return fmt.Errorf("field %s is not declared in config.yml", name)
+	}
+	if value != "" {
+		if err := def.Check(value); err != nil {
+			return err
+		}
+	}
+
+	return withSpecLock(dir, func() error {
+		return applyFieldSet(w, dir, ref, name, value)
+	})
+}
+
+// applyFieldSet is fieldSet once the lock is held.
+func applyFieldSet(w io.Writer, dir, ref, name, value string) error {
+	repo, err := newSpecRepo(dir)
+	if err != nil {
+		return err
+	}
+	spec, err := repo.ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	req := spec.FindRequirement(ref)
+	if req == nil {
+		return fmt.Errorf("requirement %s not found", ref)
+	}
+	label := requirementLabel(*req)
+	old, err := spec.SetField(req.ID, name, value)
+	if err != nil {
+		return err
+	}
+
+	evtID, err := schema.NewEventID()
+	if err != nil {
+		return err
+	}
+	event := &schema.RequirementFieldSet{
+		EventID_:      evtID,
+		RequirementID: req.ID,
+		Name:          name,
+		Value:         value,
+		OldValue:      old,
+		Timestamp_:    time.Now(),
+	}
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, []schema.ChangelogEvent{event}); err != nil {
+		return fmt.Errorf("write specification and changelog: %w", err)
+	}
+	if value == "" {
+		fmt.Fprintf(w, "%s: %s cleared\n", label, name)
+	} else {
+		fmt.Fprintf(w, "%s: %s = %s\n", label, name, value)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestTagAndFieldCommands(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().Add(-time.Minute) // Before any event the commands record
+	config := "fields:\n  - name: compliance\n    type: enum\n    values: [SOC2, HIPAA]\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yml"), []byte(config), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "Users log in", CreatedAt: now}, Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: schema.Requirement{ID: "REQ-AUTH-bbb", Category: "AUTH", Description: "Users log out", CreatedAt: now}, Timestamp_: now.Add(time.Millisecond)},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	if err := repository.NewRepository(dir).WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := tagChange(&out, dir, "AUTH-001", false, []string{"SOC2", "customer-acme"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "AUTH-001 (REQ-AUTH-aaa) tags: SOC2, customer-acme\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+	if err := tagChange(&out, dir, "AUTH-001", true, []string{"HIPAA"}); err == nil {
+		t.Error("expected error removing a missing tag")
+	}
+
+	out.Reset()
+	if err := fieldSet(&out, dir, "AUTH-001", "compliance", "HIPAA"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "AUTH-001 (REQ-AUTH-aaa): compliance = HIPAA\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+	if err := fieldSet(&out, dir, "AUTH-002", "compliance", "PCI"); err == nil || !strings.Contains(err.Error(), "not one of") {
+		t.Errorf("expected enum error, got %v", err)
+	}
+	if err := fieldSet(&out, dir, "AUTH-002", "release", "2.0"); err == nil || !strings.Contains(err.Error(), "not declared") {
+		t.Errorf("expected undeclared field error, got %v", err)
+	}
+
+	out.Reset()
+	filter := schema.RequirementFilter{Tags: []string{"SOC2"}, Fields: map[string]string{"compliance": "HIPAA"}}
+	if err := statusReport(&out, dir, filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report := out.String(); !strings.Contains(report, "AUTH-001") || strings.Contains(report, "AUTH-002") {
+		t.Errorf("expected only the tagged requirement listed, got:\n%s", report)
+	}
+
+	out.Reset()
+	if err := fieldSet(&out, dir, "AUTH-001", "compliance", ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "AUTH-001 (REQ-AUTH-aaa): compliance cleared\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
+
+func TestTagChange_RejectsInvalidFields(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().Add(-time.Minute) // Before any event the commands record
+	config := "fields:\n  - name: compliance\n    type: enum\n    values: [SOC2, HIPAA]\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yml"), []byte(config), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{
+			ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "Users log in",
+			Fields: map[string]string{"compliance": "PCI"}, CreatedAt: now,
+		}, Timestamp_: now},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	if err := repository.NewRepository(dir).WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	err := tagChange(&bytes.Buffer{}, dir, "AUTH-001", false, []string{"SOC2"})
+	if err == nil || !strings.Contains(err.Error(), "validate fields") {
+		t.Fatalf("expected field validation error, got %v", err)
+	}
+	stored, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	if tags := stored.Requirements[0].Tags; len(tags) != 0 {
+		t.Errorf("expected the rejected tag change not to be recorded, got tags %v", tags)
+	}
+}
+
+func TestTagAndField_Locked(t *testing.T) {
+	dir := t.TempDir()
+	session := repository.NewFileLock(filepath.Join(dir, ".lock"), "cli")
+	if err := session.Acquire(); err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	defer session.Release()
+
+	if err := tagChange(&bytes.Buffer{}, dir, "AUTH-001", false, []string{"SOC2"}); err == nil || !strings.Contains(err.Error(), "specification locked by cli") {
+		t.Errorf("expected lock error from tag, got %v", err)
+	}
+	if err := fieldSet(&bytes.Buffer{}, dir, "AUTH-001", "component", ""); err == nil || !strings.Contains(err.Error(), "specification locked by cli") {
+		t.Errorf("expected lock error from field, got %v", err)
+	}
+}
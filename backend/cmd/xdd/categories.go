@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+// runCategories parses flags for the categories command.
+func runCategories(args []string) error {
+	fs := flag.NewFlagSet("categories", flag.ContinueOnError)
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+	return categoriesReport(os.Stdout, specDir, f)
+}
+
+// categoriesReport prints the category tree with requirement counts rolled
+// up from subcategories into their parents. Only requirements matching
+// filter are counted.
+func categoriesReport(w io.Writer, dir string, filter schema.RequirementFilter) error {
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+	spec.Requirements = spec.Filter(filter)
+
+	rollups := core.CategoryRollups(spec)
+	if len(rollups) == 0 {
+		fmt.Fprintln(w, "No categories yet.")
+		return nil
+	}
+
+	var table strings.Builder
+	tw := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CATEGORY\tTOTAL\tDIRECT\tOWNER\tDESCRIPTION")
+	for _, r := range rollups {
+		// Children show only their last segment, indented under the parent
+		name := r.Name[strings.LastIndex(r.Name, schema.CategorySeparator)+1:]
+		fmt.Fprintf(tw, "%s%s\t%d\t%d\t%s\t%s\n",
+			strings.Repeat("  ", r.Depth-1), name, r.Total, r.Direct, r.Owner, r.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// Categories without owner or description would leave padding behind
+	for _, line := range strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n") {
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(w, "\n%d requirement(s) in %d categories.\n", len(spec.Requirements), len(rollups))
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestCategoriesReport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	spec := &schema.Specification{
+		Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "Users log in with email", CreatedAt: now},
+			{ID: "REQ-AUTH.SSO-bbb", Category: "AUTH/SSO", Description: "Users log in with SAML", CreatedAt: now},
+			{ID: "REQ-AUTH.SSO-ccc", Category: "AUTH/SSO", Description: "Users log in with OIDC", Status: schema.StatusApproved, CreatedAt: now},
+			{ID: "REQ-TASK-ddd", Category: "TASK", Description: "Users create tasks", CreatedAt: now},
+		},
+		Categories: []schema.Category{
+			{Name: "TASK"},
+			{Name: "AUTH", Description: "Login and sessions", Owner: "identity"},
+			{Name: "AUTH/SSO", Description: "Single sign-on"},
+		},
+	}
+	if err := repository.NewRepository(dir).WriteSpecification(context.Background(), spec); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := categoriesReport(&out, dir, schema.RequirementFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := `CATEGORY  TOTAL  DIRECT  OWNER     DESCRIPTION
+AUTH      3      1       identity  Login and sessions
+  SSO     2      2                 Single sign-on
+TASK      1      1
+
+4 requirement(s) in 3 categories.
+`
+	if out.String() != want {
+		t.Errorf("unexpected report:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := categoriesReport(&out, dir, schema.RequirementFilter{Statuses: []schema.Status{schema.StatusApproved}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "  SSO     1      1") || !strings.Contains(out.String(), "1 requirement(s) in 3 categories.") {
+		t.Errorf("expected only approved requirements counted, got:\n%s", out.String())
+	}
+}
+
+func TestCategoriesReport_Empty(t *testing.T) {
+	var out bytes.Buffer
+	if err := categoriesReport(&out, t.TempDir(), schema.RequirementFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out.String() != "No categories yet.\n" {
+		t.Errorf("unexpected report: %q", out.String())
+	}
+}
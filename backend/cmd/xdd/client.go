@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"xdd/internal/core"
+	"xdd/internal/llm"
+)
+
+// newLLMClient builds an LLM client from the environment, applying the
+// project configuration in dir and then any overrides.
+func newLLMClient(dir string, overrides ...func(*llm.Config)) (*llm.Client, *core.ProjectConfig, error) {
+	env, err := core.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if env.OpenRouterAPIKey == "" {
+		return nil, nil, fmt.Errorf("OPENROUTER_API_KEY not set")
+	}
+
+	project, err := core.LoadProjectConfig(filepath.Join(dir, "config.yml"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &llm.Config{
+		APIKey:       env.OpenRouterAPIKey,
+		BaseURL:      "https://openrouter.ai/api/v1",
+		DefaultModel: env.DefaultModel,
+	}
+	if !env.NoCache {
+		cfg.CacheDir = filepath.Join(dir, "cache", "llm")
+	}
+	project.ApplyTo(cfg)
+	for _, override := range overrides {
+		override(cfg)
+	}
+
+	client, err := llm.NewClient(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create LLM client: %w", err)
+	}
+	return client, project, nil
+}
+
+// newTaskExecutor returns an LLM-backed executor, or the rule-based one when
+// there is no API key or XDD_OFFLINE=1. The fallback is announced on w.
+func newTaskExecutor(dir string, w io.Writer) (core.TaskExecutor, *core.ProjectConfig, error) {
+	env, err := core.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if env.Offline || env.OpenRouterAPIKey == "" {
+		project, err := core.LoadProjectConfig(filepath.Join(dir, "config.yml"))
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Fprintln(w, "⚠️  No LLM available (OPENROUTER_API_KEY unset or XDD_OFFLINE=1), using offline heuristics")
+		return core.NewHeuristicTaskExecutor(), project, nil
+	}
+
+	client, project, err := newLLMClient(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return core.NewRealTaskExecutor(client), project, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"xdd/internal/core"
+	"xdd/internal/llm"
+)
+
+func TestNewTaskExecutor_Offline(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKey  string
+		offline string
+	}{
+		{name: "no API key", apiKey: ""},
+		{name: "offline flag", apiKey: "sk-test", offline: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENROUTER_API_KEY", tt.apiKey)
+			t.Setenv("XDD_OFFLINE", tt.offline)
+
+			var out bytes.Buffer
+			executor, project, err := newTaskExecutor(t.TempDir(), &out)
+			if err != nil {
+				t.Fatalf("newTaskExecutor: %v", err)
+			}
+			if _, ok := executor.(*core.HeuristicTaskExecutor); !ok {
+				t.Errorf("expected heuristic executor, got %T", executor)
+			}
+			if project == nil {
+				t.Error("expected project config")
+			}
+			if !strings.Contains(out.String(), "offline heuristics") {
+				t.Errorf("expected fallback notice, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestNewLLMClient_NoCache(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "sk-test")
+
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		noCache string
+		want    string
+	}{
+		{noCache: "", want: filepath.Join(dir, "cache", "llm")},
+		{noCache: "1", want: ""},
+	} {
+		t.Setenv("XDD_NO_CACHE", tt.noCache)
+		var cacheDir string
+		_, _, err := newLLMClient(dir, func(cfg *llm.Config) {
+			cacheDir = cfg.CacheDir
+		})
+		if err != nil {
+			t.Fatalf("newLLMClient: %v", err)
+		}
+		if cacheDir != tt.want {
+			t.Errorf("XDD_NO_CACHE=%q: cache dir = %q, want %q", tt.noCache, cacheDir, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+)
+
+// runDedupe parses flags for the dedupe command.
+func runDedupe(args []string) error {
+	fs := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	threshold := fs.Float64("threshold", 0, "similarity at or above which requirements cluster (default: generation.duplicate_threshold)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return dedupeReport(os.Stdout, specDir, *threshold)
+}
+
+// dedupeReport prints clusters of likely duplicate requirements. A zero
+// threshold uses the project's configured duplicate threshold.
+func dedupeReport(w io.Writer, dir string, threshold float64) error {
+	if threshold == 0 {
+		cfg, err := core.LoadProjectConfig(filepath.Join(dir, "config.yml"))
+		if err != nil {
+			return err
+		}
+		opts := cfg.Generation
+		opts.SetDefaults()
+		threshold = opts.DuplicateThreshold
+	}
+	if threshold <= 0 || threshold > 1 {
+		return fmt.Errorf("threshold must be between 0 and 1, got %g", threshold)
+	}
+
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	clusters := core.DuplicateClusters(spec, threshold)
+	if len(clusters) == 0 {
+		fmt.Fprintf(w, "No likely duplicates among %d requirement(s) (threshold %.2f).\n", len(spec.Requirements), threshold)
+		return nil
+	}
+
+	fmt.Fprintf(w, "%d cluster(s) of likely duplicates (threshold %.2f):\n", len(clusters), threshold)
+	for i, cluster := range clusters {
+		fmt.Fprintf(w, "\nCluster %d:\n", i+1)
+		for _, req := range cluster {
+			fmt.Fprintf(w, "  %s [%s]: %s\n", requirementLabel(req), req.Category, req.Description)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestDedupeReport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	spec := &schema.Specification{
+		Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "When a user submits valid credentials, the system shall log the user in", CreatedAt: now},
+			{ID: "REQ-TASK-bbb", Category: "TASK", Description: "The system shall allow users to create tasks with a due date", CreatedAt: now},
+			{ID: "REQ-AUTH-ccc", Category: "AUTH", Description: "When the user submits valid credentials the system shall log them in", CreatedAt: now},
+		},
+		Categories: []schema.Category{{Name: "AUTH"}, {Name: "TASK"}},
+	}
+	if err := repository.NewRepository(dir).WriteSpecification(context.Background(), spec); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := dedupeReport(&out, dir, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	report := out.String()
+	if !strings.Contains(report, "1 cluster(s)") || !strings.Contains(report, "REQ-AUTH-aaa") || !strings.Contains(report, "REQ-AUTH-ccc") {
+		t.Errorf("expected login requirements clustered, got:\n%s", report)
+	}
+	if strings.Contains(report, "REQ-TASK-bbb") {
+		t.Errorf("expected task requirement not clustered, got:\n%s", report)
+	}
+
+	out.Reset()
+	if err := dedupeReport(&out, dir, 0.99); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "No likely duplicates") {
+		t.Errorf("expected no clusters at a strict threshold, got:\n%s", out.String())
+	}
+
+	if err := dedupeReport(&out, dir, 1.5); err == nil {
+		t.Error("expected error for out-of-range threshold")
+	}
+}
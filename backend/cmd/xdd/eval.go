@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"xdd/internal/core"
+	"xdd/internal/eval"
+	"xdd/internal/llm"
+)
+
+// embeddedPrompts selects the built-in templates in --prompts.
+const embeddedPrompts = "embedded"
+
+// runEval parses flags for the eval command and builds one variant per
+// model and prompt set.
+func runEval(args []string) error {
+	fs := flag.NewFlagSet("eval", flag.ContinueOnError)
+	models := fs.String("models", "", "comma-separated models to compare (default: the project's task routes)")
+	prompts := fs.String("prompts", "", `comma-separated prompt override directories to compare, "embedded" for none (default: `+llm.DefaultPromptDir+")")
+	offline := fs.Bool("offline", false, "also run the rule-based executor as a baseline")
+	judge := fs.Bool("judge", false, "grade scenario rubrics with an LLM judge")
+	judgeModel := fs.String("judge-model", "", "model for the judge (default: the eval_judge route or DEFAULT_MODEL)")
+	asJSON := fs.Bool("json", false, "write the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{eval.DefaultScenarioDir}
+	}
+	scenarios, err := eval.LoadScenarios(paths...)
+	if err != nil {
+		return err
+	}
+
+	env, err := core.LoadConfig()
+	if err != nil {
+		return err
+	}
+	project, err := core.LoadProjectConfig(filepath.Join(specDir, "config.yml"))
+	if err != nil {
+		return err
+	}
+	runner := &eval.Runner{Generation: project.Generation, Versioning: project.Versioning}
+
+	var variants []eval.Variant
+	online := !env.Offline && env.OpenRouterAPIKey != ""
+	if *offline || !online {
+		if !online {
+			fmt.Fprintln(os.Stderr, "⚠️  No LLM available (OPENROUTER_API_KEY unset or XDD_OFFLINE=1), evaluating offline heuristics only")
+		}
+		variants = append(variants, eval.Variant{Name: "offline", Executor: core.NewHeuristicTaskExecutor()})
+	}
+
+	if online {
+		llmVariants, err := evalVariants(splitList(*models), splitList(*prompts))
+		if err != nil {
+			return err
+		}
+		variants = append(variants, llmVariants...)
+
+		if *judge {
+			client, _, err := newLLMClient(specDir)
+			if err != nil {
+				return err
+			}
+			runner.Judge = eval.NewLLMJudge(client, *judgeModel)
+		}
+	} else if *judge {
+		return fmt.Errorf("--judge needs an LLM: set OPENROUTER_API_KEY and unset XDD_OFFLINE")
+	}
+
+	return evalReport(context.Background(), os.Stdout, runner, scenarios, variants, *asJSON)
+}
+
+// evalVariants builds an LLM-backed variant for every model and prompt set
+// combination. An empty model list keeps the project's routes; an empty
+// prompt list keeps the project's overrides.
+func evalVariants(models, promptDirs []string) ([]eval.Variant, error) {
+	if len(models) == 0 {
+		models = []string{""}
+	}
+	if len(promptDirs) == 0 {
+		promptDirs = []string{""}
+	}
+
+	var variants []eval.Variant
+	for _, model := range models {
+		for _, dir := range promptDirs {
+			client, _, err := newLLMClient(specDir, func(cfg *llm.Config) {
+				if model != "" {
+					cfg.DefaultModel = model
+					for task := range cfg.Routes {
+						cfg.Routes[task] = llm.ModelRoute{Model: model}
+					}
+				}
+				switch dir {
+				case "":
+				case embeddedPrompts:
+					cfg.PromptDir = ""
+				default:
+					cfg.PromptDir = dir
+				}
+			})
+			if err != nil {
+				return nil, fmt.Errorf("variant %s: %w", variantName(model, dir, len(promptDirs) > 1), err)
+			}
+			variants = append(variants, eval.Variant{
+				Name:     variantName(model, dir, len(promptDirs) > 1),
+				Model:    model,
+				Executor: core.NewRealTaskExecutor(client),
+			})
+		}
+	}
+	return variants, nil
+}
+
+// variantName labels a variant by model, adding the prompt set only when
+// several are compared.
+func variantName(model, promptDir string, withPrompts bool) string {
+	name := model
+	if name == "" {
+		name = "routed"
+	}
+	if withPrompts {
+		if promptDir == "" {
+			promptDir = llm.DefaultPromptDir
+		}
+		name += "@" + promptDir
+	}
+	return name
+}
+
+// evalReport runs the scenarios under every variant and writes the
+// comparison. It fails when any scenario fails, so it can gate CI.
+func evalReport(
+	ctx context.Context,
+	w io.Writer,
+	runner *eval.Runner,
+	scenarios []*eval.Scenario,
+	variants []eval.Variant,
+	asJSON bool,
+) error {
+	if len(variants) == 0 {
+		return errors.New("nothing to evaluate")
+	}
+
+	report := runner.Run(ctx, scenarios, variants)
+	if asJSON {
+		if err := report.WriteJSON(w); err != nil {
+			return err
+		}
+	} else if err := report.WriteText(w); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, res := range report.Results {
+		if !res.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scenario run(s) failed", failed, len(report.Results))
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"xdd/internal/core"
+	"xdd/internal/eval"
+)
+
+func TestEvalReport(t *testing.T) {
+	dir := t.TempDir()
+	scenario := `name: new-project
+prompt: |
+  Build a task manager.
+  Users log in with email and password.
+expect:
+  added:
+    - min: 1
+  mentions: [password]
+  bump: minor
+`
+	if err := os.WriteFile(filepath.Join(dir, "new-project.yaml"), []byte(scenario), 0644); err != nil {
+		t.Fatal(err)
+	}
+	scenarios, err := eval.LoadScenarios(dir)
+	if err != nil {
+		t.Fatalf("load scenarios: %v", err)
+	}
+
+	mock := core.NewMockTaskExecutor() // Adds generic AUTH and TASKS requirements
+	variants := []eval.Variant{
+		{Name: "offline", Executor: core.NewHeuristicTaskExecutor()},
+		{Name: "mock", Executor: mock},
+	}
+
+	var out bytes.Buffer
+	err = evalReport(context.Background(), &out, &eval.Runner{}, scenarios, variants, false)
+	if err == nil || err.Error() != "1 of 2 scenario run(s) failed" {
+		t.Fatalf("expected one failed run, got %v", err)
+	}
+	for _, want := range []string{"offline", "mock", "✓ 3/3", "✗ 2/3", `new-project [mock]: mentions "password"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in report:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := evalReport(context.Background(), &out, &eval.Runner{}, scenarios, variants[:1], true); err != nil {
+		t.Fatalf("offline run: %v", err)
+	}
+	var report eval.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode JSON report: %v", err)
+	}
+	if len(report.Results) != 1 || !report.Results[0].Passed() {
+		t.Errorf("expected one passing result, got %+v", report.Results)
+	}
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		model, dir  string
+		withPrompts bool
+		want        string
+	}{
+		{"", "", false, "routed"},
+		{"openai/gpt-4o", "", false, "openai/gpt-4o"},
+		{"openai/gpt-4o", "", true, "openai/gpt-4o@.xdd/prompts"},
+		{"", "embedded", true, "routed@embedded"},
+	}
+	for _, tt := range tests {
+		if got := variantName(tt.model, tt.dir, tt.withPrompts); got != tt.want {
+			t.Errorf("variantName(%q, %q, %v) = %q, want %q", tt.model, tt.dir, tt.withPrompts, got, tt.want)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	if got := splitList(" a, ,b,"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("splitList = %v", got)
+	}
+	if got := splitList(""); got != nil {
+		t.Errorf("splitList(\"\") = %v, want nil", got)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"xdd/pkg/schema"
+)
+
+// filterFlags registers the --status, --tag and --field flags shared by
+// listings and exports. Call the returned function after parsing.
+func filterFlags(fs *flag.FlagSet) func() (schema.RequirementFilter, error) {
+	statuses := fs.String("status", "", "comma-separated statuses to include (default: all)")
+	tags := fs.String("tag", "", "comma-separated tags requirements must all carry")
+	fields := make(map[string]string)
+	fs.Func("field", "NAME=VALUE a custom field must hold (repeatable)", func(s string) error {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("want NAME=VALUE, got %q", s)
+		}
+		fields[name] = value
+		return nil
+	})
+
+	return func() (schema.RequirementFilter, error) {
+		filter := schema.RequirementFilter{Tags: splitList(*tags)}
+		if len(fields) > 0 {
+			filter.Fields = fields
+		}
+		var err error
+		filter.Statuses, err = parseStatuses(*statuses)
+		return filter, err
+	}
+}
+
+// parseStatuses splits a comma-separated status list. An empty list
+// yields nil, meaning every status.
+func parseStatuses(list string) ([]schema.Status, error) {
+	var statuses []schema.Status
+	for _, name := range splitList(list) {
+		status := schema.Status(strings.ToLower(name))
+		if !status.Valid() {
+			return nil, fmt.Errorf("unknown status %q (want %s)", name, joinStatuses(schema.Statuses))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func joinStatuses(statuses []schema.Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+// runLink dispatches the link subcommands.
+func runLink(args []string) error {
+	types := make([]string, len(schema.LinkTypes))
+	for i, t := range schema.LinkTypes {
+		types[i] = string(t)
+	}
+	usage := fmt.Errorf("usage: xdd link add|remove <REQ> <%s> <REQ>", strings.Join(types, "|"))
+	if len(args) != 4 {
+		return usage
+	}
+
+	switch args[0] {
+	case "add":
+		return linkChange(os.Stdout, specDir, false, args[1], args[2], args[3])
+	case "remove":
+		return linkChange(os.Stdout, specDir, true, args[1], args[2], args[3])
+	default:
+		return usage
+	}
+}
+
+// linkChange adds (or removes) a typed link from source to target, both
+// given by ID or alias, and records it in the changelog.
+func linkChange(w io.Writer, dir string, remove bool, source, linkType, target string) error {
+	return withSpecLock(dir, func() error {
+		return applyLinkChange(w, dir, remove, source, linkType, target)
+	})
+}
+
+// applyLinkChange is linkChange once the lock is held.
+func applyLinkChange(w io.Writer, dir string, remove bool, source, linkType, target string) error {
+	repo, err := newSpecRepo(dir)
+	if err != nil {
+		return err
+	}
+	spec, err := repo.ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	from := spec.FindRequirement(source)
+	if from == nil {
+		return fmt.Errorf("requirement %s not found", source)
+	}
+	to := spec.FindRequirement(target)
+	if to == nil {
+		return fmt.Errorf("requirement %s not found", target)
+	}
+	fromLabel, toLabel := requirementLabel(*from), requirementLabel(*to)
+	link := schema.RequirementLink{Type: schema.LinkType(linkType), Target: to.ID}
+
+	evtID, err := schema.NewEventID()
+	if err != nil {
+		return err
+	}
+	var event schema.ChangelogEvent
+	verb := "Linked"
+	if remove {
+		err = spec.RemoveLink(from.ID, link)
+		event = &schema.RequirementLinkRemoved{EventID_: evtID, RequirementID: from.ID, Link: link, Timestamp_: time.Now()}
+		verb = "Unlinked"
+	} else {
+		err = spec.AddLink(from.ID, link)
+		event = &schema.RequirementLinkAdded{EventID_: evtID, RequirementID: from.ID, Link: link, Timestamp_: time.Now()}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, []schema.ChangelogEvent{event}); err != nil {
+		return fmt.Errorf("write specification and changelog: %w", err)
+	}
+	fmt.Fprintf(w, "%s %s %s %s.\n", verb, fromLabel, linkType, toLabel)
+	return nil
+}
+
+// runGraph parses flags for the graph command.
+func runGraph(args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	format := fs.String("format", core.GraphDOT, "output format: dot or mermaid")
+	only := fs.String("type", "", "only show links of this type")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+	return graphExport(os.Stdout, specDir, *format, schema.LinkType(*only), f)
+}
+
+// graphExport writes the link graph between requirements matching filter
+// in the given format.
+func graphExport(w io.Writer, dir, format string, only schema.LinkType, filter schema.RequirementFilter) error {
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+	spec.Requirements = spec.Filter(filter)
+
+	graph, err := core.LinkGraph(spec, format, only)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, graph)
+	return err
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestLinkChangeAndGraph(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().Add(-time.Minute) // Before any event the commands record
+	repo := repository.NewRepository(dir)
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "Users log in", CreatedAt: now}, Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: schema.Requirement{ID: "REQ-AUTH-bbb", Category: "AUTH", Description: "Users reset passwords", CreatedAt: now}, Timestamp_: now.Add(time.Millisecond)},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := linkChange(&out, dir, false, "auth-002", "depends_on", "AUTH-001"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "Linked AUTH-002 (REQ-AUTH-bbb) depends_on AUTH-001 (REQ-AUTH-aaa).\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+	if err := linkChange(&out, dir, false, "AUTH-001", "depends_on", "AUTH-002"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+	if err := linkChange(&out, dir, false, "AUTH-001", "blocks", "AUTH-002"); err == nil {
+		t.Error("expected error for unknown link type")
+	}
+
+	out.Reset()
+	if err := graphExport(&out, dir, "mermaid", "", schema.RequirementFilter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "n2 -->|depends_on| n1") {
+		t.Errorf("expected dependency edge, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := linkChange(&out, dir, true, "REQ-AUTH-bbb", "depends_on", "REQ-AUTH-aaa"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	reloaded, err := repo.ReadSpecification()
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	if links := reloaded.Links(); len(links) != 0 {
+		t.Errorf("expected no links after removal, got %+v", links)
+	}
+}
+
+func TestLinkChange_Locked(t *testing.T) {
+	dir := t.TempDir()
+	session := repository.NewFileLock(filepath.Join(dir, ".lock"), "cli")
+	if err := session.Acquire(); err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	defer session.Release()
+
+	err := linkChange(&bytes.Buffer{}, dir, false, "AUTH-002", "depends_on", "AUTH-001")
+	if err == nil || !strings.Contains(err.Error(), "specification locked by cli") {
+		t.Errorf("expected lock error, got %v", err)
+	}
+}
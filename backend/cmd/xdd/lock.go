@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+)
+
+// withSpecLock runs fn holding the specification lock in dir, the one
+// interactive sessions take, so no two commands interleave a
+// read-modify-write of the specification.
+func withSpecLock(dir string, fn func() error) error {
+	lock := repository.NewFileLock(filepath.Join(dir, ".lock"), "cli")
+	if err := lock.Acquire(); err != nil {
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Failed to release lock: %v\n", err)
+		}
+	}()
+	return fn()
+}
+
+// newSpecRepo opens the specification in dir for a command that rewrites
+// it, checking custom fields against the definitions in config.yml on
+// every write.
+func newSpecRepo(dir string) (*repository.Repository, error) {
+	cfg, err := core.LoadProjectConfig(filepath.Join(dir, "config.yml"))
+	if err != nil {
+		return nil, err
+	}
+	repo := repository.NewRepository(dir)
+	repo.SetFieldDefinitions(cfg.Fields)
+	return repo, nil
+}
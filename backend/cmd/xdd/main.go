@@ -0,0 +1,109 @@
+// Command xdd manages an xdd specification in the current directory.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"xdd/internal/telemetry"
+)
+
+// specDir is the project's .xdd directory, relative to the working directory.
+const specDir = ".xdd"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	shutdown, err := telemetry.Setup(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "prompts":
+		err = runPrompts(os.Args[2:])
+	case "dedupe":
+		err = runDedupe(os.Args[2:])
+	case "review":
+		err = runReview(os.Args[2:])
+	case "categories":
+		err = runCategories(os.Args[2:])
+	case "status":
+		err = runStatus(os.Args[2:])
+	case "tag":
+		err = runTag(os.Args[2:])
+	case "field":
+		err = runField(os.Args[2:])
+	case "link":
+		err = runLink(os.Args[2:])
+	case "graph":
+		err = runGraph(os.Args[2:])
+	case "verify":
+		err = runVerify(os.Args[2:])
+	case "verification-matrix":
+		err = runVerificationMatrix(os.Args[2:])
+	case "export-tests":
+		err = runExportTests(os.Args[2:])
+	case "eval":
+		err = runEval(os.Args[2:])
+	case "help", "-h", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	// Flush spans before exiting, even on failure
+	if shutdownErr := shutdown(context.Background()); shutdownErr != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Failed to export traces: %v\n", shutdownErr)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Usage: xdd <command> [arguments]
+
+Commands:
+  prompts list                      List prompt templates with origin and hash
+  prompts render <task> [flags]     Render a task's prompt against the current spec
+  dedupe [--threshold N]            Report clusters of likely duplicate requirements
+  review [REQ-ID...]                Review requirement quality (all if no IDs given)
+  categories [filters]              List the category tree with requirement counts
+  status set REQ STATUS [flags]     Move a requirement through draft, approved, implemented, verified, deprecated
+  status report [filters]           Count and list requirements by status
+  tag add|remove REQ TAG...         Tag requirements (e.g. SOC2, HIPAA)
+  field set REQ NAME VALUE          Set a custom field declared under fields: in .xdd/config.yml
+  field unset REQ NAME              Clear a custom field
+  link add|remove REQ TYPE REQ      Link requirements (depends_on, refines, conflicts_with, supersedes)
+  graph [--format dot|mermaid] [filters]
+                                    Export the requirement link graph
+  verify REQ AC --method METHOD [--ref ARTEFACT]
+                                    Record how an acceptance criterion is verified
+                                    (--clear forgets it)
+  verification-matrix [--all] [filters]
+                                    List criteria lacking a verification method
+                                    (test, inspection, analysis, demonstration)
+                                    or artefact, most urgent first
+  export-tests [--format gherkin|json] [filters]
+                                    Export a test case per acceptance criterion,
+                                    one per example row for scenario outlines
+  eval [flags] [SCENARIO...]        Score the pipeline on golden scenarios (default: .xdd/eval)
+
+Filters: --status LIST, --tag LIST (all required), --field NAME=VALUE (repeatable).
+
+Without OPENROUTER_API_KEY, or with XDD_OFFLINE=1, LLM tasks fall back to
+rule-based heuristics. Set XDD_NO_CACHE=1 to bypass the LLM response cache.
+
+Set OTEL_TRACES_EXPORTER=console (or otlp, with OTEL_EXPORTER_OTLP_ENDPOINT)
+to trace LLM calls and repository writes.`)
+}
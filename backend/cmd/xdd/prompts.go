@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
+	"xdd/internal/repository"
+	"xdd/internal/versioning"
+	"xdd/pkg/schema"
+)
+
+// runPrompts dispatches the prompts subcommands.
+func runPrompts(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: xdd prompts list | render <task> [--request TEXT]")
+	}
+
+	switch args[0] {
+	case "list":
+		return promptsList(os.Stdout, specDir)
+	case "render":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: xdd prompts render <task> [--request TEXT]")
+		}
+		fs := flag.NewFlagSet("prompts render", flag.ContinueOnError)
+		request := fs.String("request", "Example change request", "update request to render into the prompt")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		return promptsRender(os.Stdout, os.Stderr, specDir, args[1], *request)
+	default:
+		return fmt.Errorf("unknown prompts subcommand %q", args[0])
+	}
+}
+
+// promptsList prints every prompt template with its origin and hash.
+func promptsList(w io.Writer, dir string) error {
+	prompts, err := llm.LoadPromptSet(filepath.Join(dir, "prompts"))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range llm.PromptNames() {
+		fmt.Fprintf(w, "%-16s %s  %s\n", name, prompts.Hash(name), prompts.Origin(name))
+	}
+	return nil
+}
+
+// promptsRender renders a task's prompt against the current specification.
+// The prompt goes to w and a provenance header to info, so w can be piped.
+func promptsRender(w, info io.Writer, dir, task, request string) error {
+	prompts, err := llm.LoadPromptSet(filepath.Join(dir, "prompts"))
+	if err != nil {
+		return err
+	}
+
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	data, err := samplePromptData(task, spec, request)
+	if err != nil {
+		return err
+	}
+
+	out, err := prompts.Render(task, data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(info, "# prompt %s (hash %s, %s)\n", task, prompts.Hash(task), prompts.Origin(task))
+	fmt.Fprintln(w, out)
+	return nil
+}
+
+// samplePromptData builds a task's template data from the specification,
+// using request where the pipeline would use the user's prompt.
+func samplePromptData(task string, spec *schema.Specification, request string) (any, error) {
+	briefs := make([]string, 0, len(spec.Requirements))
+	for _, req := range spec.Requirements {
+		briefs = append(briefs, req.Description)
+	}
+
+	switch task {
+	case llm.PromptMetadata:
+		var existing *schema.ProjectMetadata
+		if spec.Metadata.Name != "" {
+			existing = &spec.Metadata
+		}
+		return llm.MetadataPromptData{Existing: existing, UpdateRequest: request}, nil
+
+	case llm.PromptRequirementsDelta:
+		return llm.RequirementsDeltaPromptData{
+			ExistingRequirements: spec.Requirements,
+			ExistingCategories:   spec.Categories,
+			UpdateRequest:        request,
+		}, nil
+
+	case llm.PromptCategorization:
+		return llm.CategorizationPromptData{
+			ProjectName:        spec.Metadata.Name,
+			ProjectDescription: spec.Metadata.Description,
+			RequirementBriefs:  append(briefs, request),
+			ExistingCategories: spec.Categories,
+		}, nil
+
+	case llm.PromptRequirementGen:
+		category := "GENERAL"
+		if len(spec.Categories) > 0 {
+			category = spec.Categories[0].Name
+		}
+		return llm.RequirementGenPromptData{
+			Category:             category,
+			EARSType:             string(schema.EARSEvent),
+			BriefDescription:     request,
+			EstimatedPriority:    string(schema.PriorityMedium),
+			ProjectName:          spec.Metadata.Name,
+			ProjectDescription:   spec.Metadata.Description,
+			ExistingRequirements: spec.Requirements,
+			UpdateRequest:        request,
+		}, nil
+
+	case llm.PromptReview:
+		if len(spec.Requirements) > 0 {
+			req := spec.Requirements[0]
+			return llm.ReviewPromptData{
+				ID:          req.ID,
+				EARSType:    string(req.Type),
+				Category:    req.Category,
+				Description: req.Description,
+				Rationale:   req.Rationale,
+				Attributes:  tasks.QualityAttributes(),
+			}, nil
+		}
+		return llm.ReviewPromptData{
+			ID:          "REQ-GENERAL-example",
+			EARSType:    string(schema.EARSEvent),
+			Category:    "GENERAL",
+			Description: request,
+			Attributes:  tasks.QualityAttributes(),
+		}, nil
+
+	case llm.PromptConflicts:
+		return llm.ConflictsPromptData{
+			NewRequirements: []schema.Requirement{{
+				ID:          "REQ-GENERAL-example",
+				Type:        schema.EARSEvent,
+				Category:    "GENERAL",
+				Description: request,
+			}},
+			ExistingRequirements: spec.Requirements,
+		}, nil
+
+	case llm.PromptVersionBump:
+		bump, err := versioning.Compute(versioning.DefaultPolicy(), spec.Metadata.Version,
+			[]schema.ChangelogEvent{&schema.RequirementAdded{}})
+		if err != nil {
+			return nil, err
+		}
+		return llm.VersionBumpPromptData{
+			CurrentVersion:     spec.Metadata.Version,
+			NewVersion:         bump.NewVersion,
+			BumpType:           string(bump.Type),
+			Rules:              bump.Rules,
+			RequirementsAdded:  1,
+			ChangeDescriptions: []string{"Added: " + request},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown task %q, must be one of %v", task, llm.PromptNames())
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"xdd/internal/llm"
+)
+
+func TestPromptsRender(t *testing.T) {
+	dir := t.TempDir()
+
+	var out, info bytes.Buffer
+	if err := promptsRender(&out, &info, dir, llm.PromptRequirementsDelta, "Add OAuth login"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(out.String(), `based on this request: "Add OAuth login"`) {
+		t.Errorf("expected request in rendered prompt, got:\n%s", out.String())
+	}
+	if !strings.Contains(info.String(), llm.PromptOriginEmbedded) {
+		t.Errorf("expected provenance header, got %q", info.String())
+	}
+}
+
+func TestPromptsRender_Override(t *testing.T) {
+	dir := t.TempDir()
+	promptDir := filepath.Join(dir, "prompts")
+	if err := os.MkdirAll(promptDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptDir, "metadata.tmpl"), []byte("Custom: {{.UpdateRequest}}"), 0644); err != nil {
+		t.Fatalf("write override: %v", err)
+	}
+
+	var out, info bytes.Buffer
+	if err := promptsRender(&out, &info, dir, llm.PromptMetadata, "chat"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out.String() != "Custom: chat\n" {
+		t.Errorf("expected override output, got %q", out.String())
+	}
+
+	var list bytes.Buffer
+	if err := promptsList(&list, dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(list.String(), filepath.Join(promptDir, "metadata.tmpl")) {
+		t.Errorf("expected override listed, got:\n%s", list.String())
+	}
+}
+
+func TestPromptsRender_UnknownTask(t *testing.T) {
+	var out, info bytes.Buffer
+	if err := promptsRender(&out, &info, t.TempDir(), "summarize", "x"); err == nil {
+		t.Fatal("expected error for unknown task")
+	}
+}
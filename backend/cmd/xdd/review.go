@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+// runReview reviews the named requirements, or all of them, with the LLM
+// (or rule-based checks when offline).
+func runReview(args []string) error {
+	executor, project, err := newTaskExecutor(specDir, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	orch := core.NewOrchestrator(executor, repository.NewRepository(specDir))
+	orch.SetGenerationOptions(project.Generation)
+
+	return reviewReport(context.Background(), os.Stdout, orch, specDir, args)
+}
+
+// reviewReport prints quality findings for the requirements with the given
+// IDs, or for every requirement when ids is empty.
+func reviewReport(ctx context.Context, w io.Writer, orch *core.Orchestrator, dir string, ids []string) error {
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	selected, err := selectRequirements(spec, ids)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(w, "No requirements to review.")
+		return nil
+	}
+
+	reviews := orch.ReviewRequirements(ctx, selected)
+	failed := 0
+	for i, review := range reviews {
+		req := selected[i]
+		fmt.Fprintf(w, "%s: %s\n", requirementLabel(req), req.Description)
+
+		if review.Err != nil {
+			failed++
+			fmt.Fprintf(w, "  ❌ Review failed: %v\n\n", review.Err)
+			continue
+		}
+
+		out := review.Output
+		fmt.Fprintf(w, "  Score: %d/100  %s\n", out.Score, out.Summary)
+		for _, f := range out.Findings {
+			fmt.Fprintf(w, "  [%s] %s (%d/5): %s\n", f.Severity, f.Attribute, f.Score, f.Issue)
+			if f.Excerpt != "" {
+				fmt.Fprintf(w, "      Excerpt:   %q\n", f.Excerpt)
+			}
+			fmt.Fprintf(w, "      Suggested: %s\n", f.SuggestedRewrite)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d review(s) failed", failed, len(reviews))
+	}
+	return nil
+}
+
+// selectRequirements returns the requirements with the given IDs or
+// aliases in the order given (case-insensitive), or all requirements when
+// ids is empty.
+func selectRequirements(spec *schema.Specification, ids []string) ([]schema.Requirement, error) {
+	if len(ids) == 0 {
+		return spec.Requirements, nil
+	}
+
+	selected := make([]schema.Requirement, 0, len(ids))
+	for _, id := range ids {
+		req := spec.FindRequirement(id)
+		if req == nil {
+			return nil, fmt.Errorf("requirement %s not found", id)
+		}
+		selected = append(selected, *req)
+	}
+	return selected, nil
+}
+
+// requirementLabel shows a requirement's alias next to its ID when it has one.
+func requirementLabel(req schema.Requirement) string {
+	if req.Alias == "" {
+		return req.ID
+	}
+	return fmt.Sprintf("%s (%s)", req.Alias, req.ID)
+}
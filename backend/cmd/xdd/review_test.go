@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/core"
+	"xdd/internal/llm/tasks"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestReviewReport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	repo := repository.NewRepository(dir)
+	spec := &schema.Specification{
+		Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-PERF-aaa", Category: "PERF", Description: "The system shall be fast and user-friendly", CreatedAt: now},
+			{ID: "REQ-AUTH-bbb", Category: "AUTH", Description: "When a user logs in, the system shall verify the password", CreatedAt: now},
+		},
+		Categories: []schema.Category{{Name: "AUTH"}, {Name: "PERF"}},
+	}
+	if err := repo.WriteSpecification(context.Background(), spec); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	mock := core.NewMockTaskExecutor()
+	mock.ReviewOutput = &tasks.ReviewOutput{
+		Score:   35,
+		Summary: "Vague and compound",
+		Findings: []tasks.ReviewFinding{{
+			Attribute:        tasks.QualitySingular,
+			Severity:         "medium",
+			Score:            2,
+			Issue:            "Two requirements joined by 'and'",
+			Excerpt:          "fast and user-friendly",
+			SuggestedRewrite: "The system shall render each page within 1 second",
+		}},
+	}
+	orch := core.NewOrchestrator(mock, repo)
+
+	var out bytes.Buffer
+	if err := reviewReport(context.Background(), &out, orch, dir, []string{"req-perf-aaa"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	report := out.String()
+	for _, want := range []string{"REQ-PERF-aaa", "Score: 35/100", "[medium] singular (2/5)", "Suggested: The system shall render"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "REQ-AUTH-bbb") {
+		t.Errorf("expected only the requested requirement, got:\n%s", report)
+	}
+	if mock.ReviewCalls != 1 {
+		t.Errorf("expected 1 review call, got %d", mock.ReviewCalls)
+	}
+
+	// No IDs reviews everything
+	out.Reset()
+	if err := reviewReport(context.Background(), &out, orch, dir, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if mock.ReviewCalls != 3 {
+		t.Errorf("expected all requirements reviewed, got %d calls", mock.ReviewCalls)
+	}
+
+	if err := reviewReport(context.Background(), &out, orch, dir, []string{"REQ-NOPE-zzz"}); err == nil {
+		t.Error("expected error for unknown requirement")
+	}
+}
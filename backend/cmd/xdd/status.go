@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+// runStatus dispatches the status subcommands.
+func runStatus(args []string) error {
+	usage := fmt.Errorf("usage: xdd status set <REQ> <%s> [--comment TEXT] [--actor NAME] | report [--status LIST] [--tag LIST] [--field NAME=VALUE]", joinStatuses(schema.Statuses))
+	if len(args) == 0 {
+		return usage
+	}
+
+	switch args[0] {
+	case "set":
+		if len(args) < 3 {
+			return usage
+		}
+		fs := flag.NewFlagSet("status set", flag.ContinueOnError)
+		comment := fs.String("comment", "", "why the status changed")
+		actor := fs.String("actor", defaultActor(), "who changed the status (default: $XDD_ACTOR or $USER)")
+		if err := fs.Parse(args[3:]); err != nil {
+			return err
+		}
+		return statusSet(os.Stdout, specDir, args[1], schema.Status(args[2]), *actor, *comment)
+	case "report":
+		fs := flag.NewFlagSet("status report", flag.ContinueOnError)
+		filter := filterFlags(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		f, err := filter()
+		if err != nil {
+			return err
+		}
+		return statusReport(os.Stdout, specDir, f)
+	default:
+		return usage
+	}
+}
+
+// defaultActor names the person running the command.
+func defaultActor() string {
+	for _, env := range []string{"XDD_ACTOR", "USER", "USERNAME"} {
+		if actor := os.Getenv(env); actor != "" {
+			return actor
+		}
+	}
+	return "unknown"
+}
+
+// statusSet moves a requirement, given by ID or alias, to status and
+// records who did it and why in the changelog.
+func statusSet(w io.Writer, dir, ref string, status schema.Status, actor, comment string) error {
+	return withSpecLock(dir, func() error {
+		return applyStatusSet(w, dir, ref, status, actor, comment)
+	})
+}
+
+// applyStatusSet is statusSet once the lock is held.
+func applyStatusSet(w io.Writer, dir, ref string, status schema.Status, actor, comment string) error {
+	repo, err := newSpecRepo(dir)
+	if err != nil {
+		return err
+	}
+	spec, err := repo.ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	req := spec.FindRequirement(ref)
+	if req == nil {
+		return fmt.Errorf("requirement %s not found", ref)
+	}
+	label := requirementLabel(*req)
+	from, err := spec.ChangeStatus(req.ID, status)
+	if err != nil {
+		return err
+	}
+
+	evtID, err := schema.NewEventID()
+	if err != nil {
+		return err
+	}
+	event := &schema.RequirementStatusChanged{
+		EventID_:      evtID,
+		RequirementID: req.ID,
+		From:          from,
+		To:            status,
+		Actor:         actor,
+		Comment:       comment,
+		Timestamp_:    time.Now(),
+	}
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, []schema.ChangelogEvent{event}); err != nil {
+		return fmt.Errorf("write specification and changelog: %w", err)
+	}
+	fmt.Fprintf(w, "%s: %s → %s\n", label, from, status)
+	return nil
+}
+
+// statusReport prints requirement counts per status, how many active
+// requirements are verified, and the requirements grouped by status. The
+// filter's tags and fields narrow every figure; its statuses only narrow
+// the listing, so the counts still show the whole lifecycle.
+func statusReport(w io.Writer, dir string, filter schema.RequirementFilter) error {
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+	statuses := filter.Statuses
+	filter.Statuses = nil
+	spec.Requirements = spec.Filter(filter)
+	if len(spec.Requirements) == 0 {
+		fmt.Fprintln(w, "No matching requirements.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tCOUNT")
+	for _, status := range schema.Statuses {
+		fmt.Fprintf(tw, "%s\t%d\n", status, len(spec.RequirementsWithStatus(status)))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	active := len(spec.Requirements) - len(spec.RequirementsWithStatus(schema.StatusDeprecated))
+	verified := len(spec.RequirementsWithStatus(schema.StatusVerified))
+	if active > 0 {
+		fmt.Fprintf(w, "\n%d of %d active requirement(s) verified (%.0f%%).\n", verified, active, float64(verified)*100/float64(active))
+	}
+
+	if len(statuses) == 0 {
+		statuses = schema.Statuses
+	}
+	for _, status := range statuses {
+		reqs := spec.RequirementsWithStatus(status)
+		if len(reqs) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s (%d):\n", status, len(reqs))
+		for _, req := range reqs {
+			fmt.Fprintf(w, "  %s [%s]: %s\n", requirementLabel(req), req.Category, req.Description)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestStatusSetAndReport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().Add(-time.Minute) // Before any event the commands record
+	repo := repository.NewRepository(dir)
+	var events []schema.ChangelogEvent
+	for i, desc := range []string{"Users log in", "Users log out", "Users reset passwords"} {
+		events = append(events, &schema.RequirementAdded{
+			EventID_:    "EVT-" + desc,
+			Requirement: schema.Requirement{ID: "REQ-AUTH-" + string(rune('a'+i)), Category: "AUTH", Description: desc, CreatedAt: now},
+			Timestamp_:  now.Add(time.Duration(i) * time.Millisecond),
+		})
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := statusSet(&out, dir, "AUTH-001", schema.StatusApproved, "alex", "Signed off in review"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "AUTH-001 (REQ-AUTH-a): draft → approved\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+	if err := statusSet(&out, dir, "AUTH-002", schema.StatusVerified, "alex", ""); err == nil || !strings.Contains(err.Error(), "cannot move from draft to verified") {
+		t.Errorf("expected transition error, got %v", err)
+	}
+	if err := statusSet(&out, dir, "AUTH-003", schema.StatusDeprecated, "alex", ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reloaded, err := repo.ReadSpecification()
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	if got := reloaded.FindRequirement("AUTH-001").Status; got != schema.StatusApproved {
+		t.Errorf("AUTH-001 status = %s, want approved after replay", got)
+	}
+
+	out.Reset()
+	if err := statusReport(&out, dir, schema.RequirementFilter{Statuses: []schema.Status{schema.StatusApproved}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	report := out.String()
+	for _, want := range []string{"draft        1", "approved     1", "deprecated   1", "0 of 2 active requirement(s) verified (0%).", "approved (1):\n  AUTH-001 (REQ-AUTH-a) [AUTH]: Users log in"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "draft (1):") {
+		t.Errorf("expected draft requirements filtered out, got:\n%s", report)
+	}
+
+	if _, err := parseStatuses("approved, shipped"); err == nil {
+		t.Error("expected error for unknown status")
+	}
+}
+
+func TestStatusSet_Locked(t *testing.T) {
+	dir := t.TempDir()
+	session := repository.NewFileLock(filepath.Join(dir, ".lock"), "cli")
+	if err := session.Acquire(); err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	defer session.Release()
+
+	err := statusSet(&bytes.Buffer{}, dir, "AUTH-001", schema.StatusApproved, "alice", "")
+	if err == nil || !strings.Contains(err.Error(), "specification locked by cli") {
+		t.Errorf("expected lock error, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+// runExportTests parses flags for the export-tests command.
+func runExportTests(args []string) error {
+	fs := flag.NewFlagSet("export-tests", flag.ContinueOnError)
+	format := fs.String("format", core.TestsGherkin, "output format: gherkin or json")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+	return testsExport(os.Stdout, specDir, *format, f)
+}
+
+// testsExport writes a test case per acceptance criterion of the
+// requirements matching filter, expanding scenario outlines per example.
+func testsExport(w io.Writer, dir, format string, filter schema.RequirementFilter) error {
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+	spec.Requirements = spec.Filter(filter)
+
+	tests, err := core.ExportTests(spec, format)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, tests)
+	return err
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestTestsExport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	outline := &schema.ScenarioOutlineCriterion{
+		ID: "AC-out", Type: "scenario_outline", CreatedAt: now,
+		Given: "a user with role <role>", When: "they open /admin", Then: "the response is <status>",
+		Examples: schema.ExamplesTable{Columns: []string{"role", "status"}, Rows: [][]string{{"admin", "200"}, {"guest", "403"}}},
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{
+			ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "Admins manage users", Tags: []string{"SOC2"},
+			AcceptanceCriteria: []schema.AcceptanceCriterion{outline}, CreatedAt: now,
+		}, Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: schema.Requirement{
+			ID: "REQ-AUTH-bbb", Category: "AUTH", Description: "Users log out",
+			AcceptanceCriteria: []schema.AcceptanceCriterion{&schema.AssertionCriterion{ID: "AC-a", Type: "assertion", Statement: "Sessions end", CreatedAt: now}},
+			CreatedAt:          now,
+		}, Timestamp_: now.Add(time.Millisecond)},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	if err := repository.NewRepository(dir).WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := testsExport(&out, dir, "gherkin", schema.RequirementFilter{Tags: []string{"SOC2"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"Scenario: AC-out.1", "Given a user with role admin", "Then the response is 403"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "REQ-AUTH-bbb") {
+		t.Errorf("expected untagged requirement to be filtered out:\n%s", got)
+	}
+
+	if err := testsExport(&out, dir, "xml", schema.RequirementFilter{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
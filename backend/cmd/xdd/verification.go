@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+// runVerify parses flags for the verify command.
+func runVerify(args []string) error {
+	usage := fmt.Errorf("usage: xdd verify <REQ> <AC> --method test|inspection|analysis|demonstration [--ref ARTEFACT] | --clear")
+	if len(args) < 2 {
+		return usage
+	}
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	method := fs.String("method", "", "how the criterion is verified: test, inspection, analysis or demonstration")
+	ref := fs.String("ref", "", "the artefact showing it, e.g. a test file or report")
+	forget := fs.Bool("clear", false, "forget how the criterion is verified")
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+	if *forget && (*method != "" || *ref != "") || !*forget && *method == "" {
+		return usage
+	}
+	v := schema.Verification{Method: schema.VerificationMethod(*method), Ref: *ref}
+	return verifySet(os.Stdout, specDir, args[0], args[1], v)
+}
+
+// verifySet records how a criterion of a requirement, given by ID or
+// alias, is verified, or clears it when v is empty.
+func verifySet(w io.Writer, dir, ref, criterionID string, v schema.Verification) error {
+	return withSpecLock(dir, func() error {
+		return applyVerifySet(w, dir, ref, criterionID, v)
+	})
+}
+
+// applyVerifySet is verifySet once the lock is held.
+func applyVerifySet(w io.Writer, dir, ref, criterionID string, v schema.Verification) error {
+	repo, err := newSpecRepo(dir)
+	if err != nil {
+		return err
+	}
+	spec, err := repo.ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+
+	req := spec.FindRequirement(ref)
+	if req == nil {
+		return fmt.Errorf("requirement %s not found", ref)
+	}
+	label := requirementLabel(*req)
+	old, err := spec.SetVerification(req.ID, criterionID, v)
+	if err != nil {
+		return err
+	}
+
+	evtID, err := schema.NewEventID()
+	if err != nil {
+		return err
+	}
+	event := &schema.AcceptanceCriterionVerificationSet{
+		EventID_:        evtID,
+		RequirementID:   req.ID,
+		CriterionID:     criterionID,
+		Verification:    v,
+		OldVerification: old,
+		Timestamp_:      time.Now(),
+	}
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, []schema.ChangelogEvent{event}); err != nil {
+		return fmt.Errorf("write specification and changelog: %w", err)
+	}
+	if v == (schema.Verification{}) {
+		fmt.Fprintf(w, "%s %s: verification cleared\n", label, criterionID)
+	} else {
+		fmt.Fprintf(w, "%s %s: verified by %s\n", label, criterionID, v)
+	}
+	return nil
+}
+
+// runVerificationMatrix parses flags for the verification-matrix command.
+func runVerificationMatrix(args []string) error {
+	fs := flag.NewFlagSet("verification-matrix", flag.ContinueOnError)
+	all := fs.Bool("all", false, "list verified criteria too")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	f, err := filter()
+	if err != nil {
+		return err
+	}
+	return verificationMatrix(os.Stdout, specDir, f, *all)
+}
+
+// verificationMatrix lists, most urgent first, the acceptance criteria of
+// requirements matching filter that lack a verification method or
+// artefact, or every criterion with all. Deprecated requirements are left
+// out unless the filter asks for them.
+func verificationMatrix(w io.Writer, dir string, filter schema.RequirementFilter, all bool) error {
+	spec, err := repository.NewRepository(dir).ReadSpecification()
+	if err != nil {
+		return fmt.Errorf("load specification: %w", err)
+	}
+	if len(filter.Statuses) == 0 {
+		for _, status := range schema.Statuses {
+			if status != schema.StatusDeprecated {
+				filter.Statuses = append(filter.Statuses, status)
+			}
+		}
+	}
+	spec.Requirements = spec.Filter(filter)
+
+	entries := core.VerificationMatrix(spec)
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No matching criteria.")
+		return nil
+	}
+
+	verified := 0
+	var rows []core.VerificationEntry
+	for _, entry := range entries {
+		if entry.Verified() {
+			verified++
+			if !all {
+				continue
+			}
+		}
+		rows = append(rows, entry)
+	}
+
+	if len(rows) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "PRIORITY\tREQUIREMENT\tCRITERION\tMETHOD\tREF\tOUTCOME")
+		for _, row := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				row.Requirement.Priority, requirementLabel(row.Requirement), row.CriterionID,
+				orDash(string(row.Method)), orDash(row.Ref), row.Outcome)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%d of %d criteria have a verification method and artefact (%.0f%%).\n", verified, len(entries), float64(verified)*100/float64(len(entries)))
+	return nil
+}
+
+// orDash shows a missing value as "-".
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
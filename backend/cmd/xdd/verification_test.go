@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+)
+
+func TestVerificationMatrix(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	requirement := func(id string, priority schema.Priority, status schema.Status, criteria ...schema.AcceptanceCriterion) schema.ChangelogEvent {
+		return &schema.RequirementAdded{EventID_: "EVT-" + id, Requirement: schema.Requirement{
+			ID: id, Category: "AUTH", Description: "Requirement " + id, Priority: priority, Status: status,
+			AcceptanceCriteria: criteria, CreatedAt: now,
+		}, Timestamp_: now}
+	}
+	assertion := func(id string, v schema.Verification) *schema.AssertionCriterion {
+		return &schema.AssertionCriterion{ID: id, Type: "assertion", Statement: "Statement " + id, Verification: v, CreatedAt: now}
+	}
+	events := []schema.ChangelogEvent{
+		requirement("REQ-AUTH-low", schema.PriorityLow, schema.StatusDraft,
+			assertion("AC-low", schema.Verification{})),
+		requirement("REQ-AUTH-crit", schema.PriorityCritical, schema.StatusApproved,
+			assertion("AC-crit", schema.Verification{Method: schema.VerificationTest}),
+			assertion("AC-done", schema.Verification{Method: schema.VerificationTest, Ref: "auth_test.go"})),
+		requirement("REQ-AUTH-old", schema.PriorityHigh, schema.StatusDeprecated,
+			assertion("AC-old", schema.Verification{})),
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	if err := repository.NewRepository(dir).WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := verificationMatrix(&out, dir, schema.RequirementFilter{}, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got := out.String()
+	crit, low := strings.Index(got, "AC-crit"), strings.Index(got, "AC-low")
+	if crit < 0 || low < 0 || crit > low {
+		t.Errorf("expected critical criterion listed before low one:\n%s", got)
+	}
+	if strings.Contains(got, "AC-done") || strings.Contains(got, "AC-old") {
+		t.Errorf("expected verified and deprecated criteria left out:\n%s", got)
+	}
+	if !strings.Contains(got, "1 of 3 criteria have a verification method and artefact (33%).") {
+		t.Errorf("unexpected summary:\n%s", got)
+	}
+
+	out.Reset()
+	if err := verificationMatrix(&out, dir, schema.RequirementFilter{Statuses: []schema.Status{schema.StatusDeprecated}}, true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "AC-old") {
+		t.Errorf("expected deprecated criterion when filtered by status:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := verificationMatrix(&out, dir, schema.RequirementFilter{Tags: []string{"SOC2"}}, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out.String() != "No matching criteria.\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestVerifySet(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().Add(-time.Minute) // Before any event the commands record
+	criterion := &schema.BehavioralCriterion{ID: "AC-login", Type: "behavioral", Given: "a user", When: "they log in", Then: "they see the dashboard", CreatedAt: now}
+	events := []schema.ChangelogEvent{&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{
+		ID: "REQ-AUTH-aaa", Category: "AUTH", Description: "Users log in",
+		AcceptanceCriteria: []schema.AcceptanceCriterion{criterion}, CreatedAt: now,
+	}, Timestamp_: now}}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: now, UpdatedAt: now}}
+	repo := repository.NewRepository(dir)
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	var out bytes.Buffer
+	v := schema.Verification{Method: schema.VerificationTest, Ref: "auth/login_test.go"}
+	if err := verifySet(&out, dir, "AUTH-001", "AC-login", v); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "AUTH-001 (REQ-AUTH-aaa) AC-login: verified by test: auth/login_test.go\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+	reloaded, err := repo.ReadSpecification()
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	if got := reloaded.Requirements[0].AcceptanceCriteria[0].GetVerification(); got != v {
+		t.Errorf("verification after replay = %+v, want %+v", got, v)
+	}
+
+	if err := verifySet(&out, dir, "AUTH-001", "AC-missing", v); err == nil || !strings.Contains(err.Error(), "no acceptance criterion AC-missing") {
+		t.Errorf("expected missing criterion error, got %v", err)
+	}
+	if err := verifySet(&out, dir, "AUTH-001", "AC-login", schema.Verification{Method: "vibes"}); err == nil {
+		t.Error("expected error for unknown method")
+	}
+
+	out.Reset()
+	if err := verifySet(&out, dir, "REQ-AUTH-aaa", "AC-login", schema.Verification{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := out.String(); got != "AUTH-001 (REQ-AUTH-aaa) AC-login: verification cleared\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+	reloaded, err = repo.ReadSpecification()
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	if got := reloaded.Requirements[0].AcceptanceCriteria[0].GetVerification(); got != (schema.Verification{}) {
+		t.Errorf("verification after clearing = %+v, want none", got)
+	}
+}
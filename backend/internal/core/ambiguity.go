@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+)
+
+// ambiguityDescriptionLength caps candidate descriptions in clarification choices.
+const ambiguityDescriptionLength = 60
+
+// Ambiguity is a modification the delta task could not pin to one requirement.
+type Ambiguity struct {
+	Clarification string
+	Candidates    []AmbiguityCandidate
+}
+
+// AmbiguityCandidate is one requirement the user may have meant.
+type AmbiguityCandidate struct {
+	ID          string
+	Alias       string
+	Description string // Truncated for display
+}
+
+// label shows the alias next to the ID when there is one.
+func (c AmbiguityCandidate) label() string {
+	if c.Alias == "" {
+		return c.ID
+	}
+	return fmt.Sprintf("%s (%s)", c.Alias, c.ID)
+}
+
+// newAmbiguities pairs each ambiguous modification with its candidate
+// requirements, looked up in spec by ID or alias.
+func newAmbiguities(delta *tasks.RequirementsDeltaOutput, spec *schema.Specification) []Ambiguity {
+	ambiguities := make([]Ambiguity, 0, len(delta.AmbiguousModifications))
+	for _, mod := range delta.AmbiguousModifications {
+		amb := Ambiguity{Clarification: mod.Clarification}
+		for _, ref := range mod.PossibleTargets {
+			candidate := AmbiguityCandidate{ID: ref, Description: "(unknown requirement)"}
+			if req := spec.FindRequirement(ref); req != nil {
+				candidate = AmbiguityCandidate{ID: req.ID, Alias: req.Alias, Description: req.Description}
+			}
+			candidate.Description = truncate(candidate.Description, ambiguityDescriptionLength)
+			amb.Candidates = append(amb.Candidates, candidate)
+		}
+		ambiguities = append(ambiguities, amb)
+	}
+	return ambiguities
+}
+
+// Format renders the question followed by its numbered choices.
+func (a Ambiguity) Format() string {
+	var b strings.Builder
+	b.WriteString(a.Clarification)
+	for i, c := range a.Candidates {
+		fmt.Fprintf(&b, "\n  %d) %s: %s", i+1, c.label(), c.Description)
+	}
+	if len(a.Candidates) > 1 {
+		b.WriteString("\n  (answer with a number, several numbers like 1,2, \"all\", or free text)")
+	}
+	return b.String()
+}
+
+// formatAmbiguities renders every ambiguity as one numbered question list.
+func formatAmbiguities(ambiguities []Ambiguity) string {
+	if len(ambiguities) == 1 {
+		return ambiguities[0].Format()
+	}
+	parts := make([]string, 0, len(ambiguities))
+	for i, amb := range ambiguities {
+		parts = append(parts, fmt.Sprintf("Q%d. %s", i+1, amb.Format()))
+	}
+	return "I need to clarify a few things:\n" + strings.Join(parts, "\n")
+}
+
+// Bind interprets answer against the candidates. Choice numbers ("2",
+// "1,3"), "all" and candidate IDs or aliases select targets; anything else
+// is kept as a free-text answer for the model to interpret.
+func (a Ambiguity) Bind(answer string) llm.ResolvedAmbiguity {
+	answer = strings.TrimSpace(answer)
+	resolved := llm.ResolvedAmbiguity{Question: a.Clarification}
+
+	if strings.EqualFold(answer, "all") {
+		for _, c := range a.Candidates {
+			resolved.TargetIDs = append(resolved.TargetIDs, c.ID)
+		}
+		return resolved
+	}
+
+	fields := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	var targets []string
+	for _, f := range fields {
+		if id, ok := a.candidateFor(f); ok {
+			targets = append(targets, id)
+			continue
+		}
+		targets = nil
+		break
+	}
+
+	if len(targets) > 0 {
+		resolved.TargetIDs = targets
+	} else {
+		resolved.Answer = answer
+	}
+	return resolved
+}
+
+// candidateFor resolves a choice number, ID or alias to a candidate ID.
+func (a Ambiguity) candidateFor(choice string) (string, bool) {
+	if n, err := strconv.Atoi(choice); err == nil {
+		if n >= 1 && n <= len(a.Candidates) {
+			return a.Candidates[n-1].ID, true
+		}
+		return "", false
+	}
+	for _, c := range a.Candidates {
+		if strings.EqualFold(c.ID, choice) || (c.Alias != "" && strings.EqualFold(c.Alias, choice)) {
+			return c.ID, true
+		}
+	}
+	return "", false
+}
+
+// describeResolutions summarizes answers for the conversation history.
+func describeResolutions(resolutions []llm.ResolvedAmbiguity) string {
+	lines := []string{"Clarifications:"}
+	for _, res := range resolutions {
+		answer := res.Answer
+		if len(res.TargetIDs) > 0 {
+			answer = strings.Join(res.TargetIDs, ", ")
+		}
+		lines = append(lines, fmt.Sprintf("- %s -> %s", res.Question, answer))
+	}
+	return strings.Join(lines, "\n")
+}
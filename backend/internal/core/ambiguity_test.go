@@ -0,0 +1,158 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmbiguity_Bind(t *testing.T) {
+	amb := Ambiguity{
+		Clarification: "Which login requirement?",
+		Candidates: []AmbiguityCandidate{
+			{ID: "REQ-AUTH-abc123", Description: "Password login"},
+			{ID: "REQ-AUTH-def456", Alias: "AUTH-002", Description: "SSO login"},
+			{ID: "REQ-AUTH-ghi789", Description: "Login rate limiting"},
+		},
+	}
+
+	tests := []struct {
+		answer  string
+		targets []string
+		text    string
+	}{
+		{answer: "2", targets: []string{"REQ-AUTH-def456"}},
+		{answer: " 1, 3 ", targets: []string{"REQ-AUTH-abc123", "REQ-AUTH-ghi789"}},
+		{answer: "ALL", targets: []string{"REQ-AUTH-abc123", "REQ-AUTH-def456", "REQ-AUTH-ghi789"}},
+		{answer: "req-auth-abc123", targets: []string{"REQ-AUTH-abc123"}},
+		{answer: "auth-002", targets: []string{"REQ-AUTH-def456"}},
+		{answer: "4", text: "4"},
+		{answer: "the SSO one", text: "the SSO one"},
+		{answer: "1 and also a new one", text: "1 and also a new one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			res := amb.Bind(tt.answer)
+			assert.Equal(t, "Which login requirement?", res.Question)
+			assert.Equal(t, tt.targets, res.TargetIDs)
+			assert.Equal(t, tt.text, res.Answer)
+		})
+	}
+}
+
+func TestNewAmbiguities(t *testing.T) {
+	spec := &schema.Specification{
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-abc123", Alias: "AUTH-001", Description: strings.Repeat("When user logs in ", 10)},
+		},
+	}
+	delta := &tasks.RequirementsDeltaOutput{}
+	delta.AmbiguousModifications = append(delta.AmbiguousModifications, struct {
+		PossibleTargets []string `json:"possible_targets"`
+		Clarification   string   `json:"clarification"`
+	}{
+		PossibleTargets: []string{"AUTH-001", "REQ-AUTH-zzz999"},
+		Clarification:   "Which one?",
+	})
+
+	ambiguities := newAmbiguities(delta, spec)
+	require.Len(t, ambiguities, 1)
+	require.Len(t, ambiguities[0].Candidates, 2)
+	assert.Len(t, ambiguities[0].Candidates[0].Description, ambiguityDescriptionLength)
+	assert.True(t, strings.HasSuffix(ambiguities[0].Candidates[0].Description, "..."))
+	assert.Equal(t, "(unknown requirement)", ambiguities[0].Candidates[1].Description)
+
+	formatted := ambiguities[0].Format()
+	assert.Equal(t, "REQ-AUTH-abc123", ambiguities[0].Candidates[0].ID)
+	assert.Contains(t, formatted, "1) AUTH-001 (REQ-AUTH-abc123): When user logs in")
+	assert.Contains(t, formatted, "2) REQ-AUTH-zzz999: (unknown requirement)")
+}
+
+// deltaRecorder captures every delta input and reports ambiguity until resolved.
+type deltaRecorder struct {
+	*MockTaskExecutor
+	ambiguous *tasks.RequirementsDeltaOutput
+	inputs    []*tasks.RequirementsDeltaInput
+}
+
+func (r *deltaRecorder) ExecuteRequirementsDelta(ctx context.Context, input *tasks.RequirementsDeltaInput) (*tasks.RequirementsDeltaOutput, error) {
+	r.inputs = append(r.inputs, input)
+	if len(input.Resolutions) == 0 {
+		return r.ambiguous, nil
+	}
+	return r.MockTaskExecutor.ExecuteRequirementsDelta(ctx, input)
+}
+
+func TestOrchestrator_ResolveAmbiguities(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	require.NoError(t, repo.WriteSpecification(context.Background(), &schema.Specification{
+		Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-abc123", Category: "AUTH", Description: "Password login", CreatedAt: time.Now()},
+			{ID: "REQ-AUTH-def456", Category: "AUTH", Description: "SSO login", CreatedAt: time.Now()},
+			{ID: "REQ-TASKS-aaa111", Category: "TASKS", Description: "Task list", CreatedAt: time.Now()},
+		},
+		Categories: []schema.Category{{Name: "AUTH"}, {Name: "TASKS"}},
+	}))
+
+	ambiguous := &tasks.RequirementsDeltaOutput{}
+	ambiguous.AmbiguousModifications = []struct {
+		PossibleTargets []string `json:"possible_targets"`
+		Clarification   string   `json:"clarification"`
+	}{
+		{PossibleTargets: []string{"REQ-AUTH-abc123", "REQ-AUTH-def456"}, Clarification: "Which login requirement?"},
+		{PossibleTargets: []string{"REQ-TASKS-aaa111"}, Clarification: "Should the task list change too?"},
+	}
+	recorder := &deltaRecorder{MockTaskExecutor: NewMockTaskExecutor(), ambiguous: ambiguous}
+	orch := NewOrchestrator(recorder, repo)
+	ctx := context.Background()
+
+	state, err := orch.ProcessPrompt(ctx, NewSessionState(), "Update login and tasks")
+	require.NoError(t, err)
+
+	// Every question is asked, with numbered candidates
+	assert.True(t, state.AwaitingFeedback)
+	require.Len(t, state.PendingAmbiguities, 2)
+	assert.Equal(t, "Update login and tasks", state.PendingRequest)
+	question := state.Messages[len(state.Messages)-1].Content
+	assert.Contains(t, question, "Q1. Which login requirement?")
+	assert.Contains(t, question, "2) REQ-AUTH-def456: SSO login")
+	assert.Contains(t, question, "Q2. Should the task list change too?")
+
+	_, err = orch.ResolveAmbiguities(ctx, state, []string{"2"})
+	assert.Error(t, err, "one answer per ambiguity is required")
+
+	resolved, err := orch.ResolveAmbiguities(ctx, state, []string{"2", "no"})
+	require.NoError(t, err)
+
+	// The original request is re-run with the bound answers attached
+	require.Len(t, recorder.inputs, 2)
+	rerun := recorder.inputs[1]
+	assert.Equal(t, "Update login and tasks", rerun.UpdateRequest)
+	assert.Equal(t, []llm.ResolvedAmbiguity{
+		{Question: "Which login requirement?", TargetIDs: []string{"REQ-AUTH-def456"}},
+		{Question: "Should the task list change too?", Answer: "no"},
+	}, rerun.Resolutions)
+
+	assert.False(t, resolved.AwaitingFeedback)
+	assert.Empty(t, resolved.PendingAmbiguities)
+	assert.Empty(t, resolved.PendingRequest)
+	assert.NotEmpty(t, resolved.PendingChangelog)
+	assert.Len(t, resolved.Resolutions, 2)
+
+	// Original state is untouched
+	assert.Len(t, state.PendingAmbiguities, 2)
+	assert.Empty(t, state.Resolutions)
+
+	_, err = orch.ResolveAmbiguities(ctx, resolved, []string{"1"})
+	assert.Error(t, err, "nothing left to resolve")
+}
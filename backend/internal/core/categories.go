@@ -0,0 +1,321 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+)
+
+// categoryMove relocates a whole existing category. Merge is set when the
+// target already exists (or is claimed by an earlier rename) and the
+// source disappears into it.
+type categoryMove struct {
+	From  string
+	To    string
+	Merge bool
+}
+
+// categoryPlan is the category restructuring proposed in one turn, derived
+// by diffing the categorization output against the current spec.
+type categoryPlan struct {
+	Moves   []categoryMove
+	Added   []string // Set by settle
+	Deleted []string // Set by settle
+
+	spec     *schema.Specification
+	removed  map[string]bool   // Requirement IDs removed this turn
+	members  map[string]int    // Surviving requirements per category
+	existing []string          // Declared categories plus any in use
+	proposed map[string]string // Categories the categorization kept, with descriptions
+	renamed  map[string]string // Old category to new, for every move
+}
+
+// Resolve maps a category through the plan's renames and merges.
+func (p *categoryPlan) Resolve(category string) string {
+	if to, ok := p.renamed[category]; ok {
+		return to
+	}
+	return category
+}
+
+// planCategories diffs the proposed categorization against spec. A
+// declared category whose remaining requirements are all mapped to one
+// other category is renamed to it, or merged into it if that category
+// already exists. Categories with subcategories are never moved. Call
+// settle once the new requirements are known.
+func planCategories(
+	spec *schema.Specification,
+	delta *tasks.RequirementsDeltaOutput,
+	categorization *tasks.CategorizationOutput,
+) *categoryPlan {
+	plan := &categoryPlan{
+		spec:     spec,
+		removed:  make(map[string]bool, len(delta.ToRemove)),
+		members:  make(map[string]int),
+		existing: spec.CategoryNames(),
+		proposed: make(map[string]string, len(categorization.Categories)),
+		renamed:  make(map[string]string),
+	}
+	for _, rem := range delta.ToRemove {
+		plan.removed[rem.ID] = true
+	}
+	for _, c := range categorization.Categories {
+		plan.proposed[c.Name] = c.Description
+	}
+
+	surviving := make(map[string][]schema.Requirement)
+	for _, req := range spec.Requirements {
+		if !slices.Contains(plan.existing, req.Category) {
+			plan.existing = append(plan.existing, req.Category) // Undeclared but in use
+		}
+		if !plan.removed[req.ID] {
+			surviving[req.Category] = append(surviving[req.Category], req)
+			plan.members[req.Category]++
+		}
+	}
+
+	for _, cat := range spec.CategoryNames() {
+		if len(spec.Subcategories(cat)) > 0 {
+			continue
+		}
+		if to, ok := movedTo(surviving[cat], categorization.RequirementMapping); ok && !schema.CategoryWithin(to, cat) {
+			plan.renamed[cat] = to
+		}
+	}
+
+	// A category whose target (or its parent) is itself moving stays put
+	// rather than chaining
+	claimed := make(map[string]bool)
+	for _, cat := range spec.CategoryNames() {
+		to, ok := plan.renamed[cat]
+		if !ok {
+			continue
+		}
+		if slices.ContainsFunc(append(schema.CategoryAncestors(to), to), func(c string) bool {
+			_, moving := plan.renamed[c]
+			return moving
+		}) {
+			delete(plan.renamed, cat)
+			continue
+		}
+		merge := slices.Contains(plan.existing, to) || claimed[to]
+		claimed[to] = true
+		plan.Moves = append(plan.Moves, categoryMove{From: cat, To: to, Merge: merge})
+	}
+	return plan
+}
+
+// settle decides which categories to add and delete given the categories
+// of the requirements added this turn (already resolved). Categories in use
+// are added, parents before children; existing categories left without
+// requirements are deleted, children before parents, when this turn
+// empties them or the categorization no longer proposes them.
+func (p *categoryPlan) settle(newCategories []string) {
+	after := make([]string, 0, len(p.existing))
+	for _, cat := range p.existing {
+		if _, moved := p.renamed[cat]; !moved {
+			after = append(after, cat)
+		}
+	}
+	for _, m := range p.Moves {
+		if !m.Merge {
+			// Renaming declares any missing parents of the new name
+			for _, cat := range append(schema.CategoryAncestors(m.To), m.To) {
+				if !slices.Contains(after, cat) {
+					after = append(after, cat)
+				}
+			}
+		}
+	}
+
+	inUse := make(map[string]bool)
+	use := func(cat string) {
+		inUse[cat] = true
+		for _, ancestor := range schema.CategoryAncestors(cat) {
+			inUse[ancestor] = true
+		}
+	}
+	for cat, n := range p.members {
+		if n > 0 {
+			use(p.Resolve(cat))
+		}
+	}
+	p.Added = nil
+	for _, cat := range newCategories {
+		use(cat)
+		for _, c := range append(schema.CategoryAncestors(cat), cat) {
+			if !slices.Contains(after, c) && !slices.Contains(p.Added, c) {
+				p.Added = append(p.Added, c)
+			}
+		}
+	}
+
+	deleted := make(map[string]bool)
+	for _, cat := range after {
+		if inUse[cat] {
+			continue
+		}
+		emptied := false
+		for _, req := range p.spec.Requirements {
+			if req.Category == cat && p.removed[req.ID] {
+				emptied = true
+				break
+			}
+		}
+		if _, kept := p.proposed[cat]; emptied || !kept {
+			deleted[cat] = true
+		}
+	}
+	// A category keeps its parents
+	for _, cat := range after {
+		if !deleted[cat] {
+			for _, ancestor := range schema.CategoryAncestors(cat) {
+				delete(deleted, ancestor)
+			}
+		}
+	}
+
+	p.Deleted = nil
+	for _, cat := range after {
+		if deleted[cat] {
+			p.Deleted = append(p.Deleted, cat)
+		}
+	}
+	slices.SortStableFunc(p.Deleted, func(a, b string) int {
+		return schema.CategoryDepth(b) - schema.CategoryDepth(a)
+	})
+}
+
+// Description returns the categorization's description of category.
+func (p *categoryPlan) Description(category string) string {
+	return p.proposed[category]
+}
+
+// movedTo returns the single category every requirement is mapped to.
+// Unmapped requirements keep the category where it is.
+func movedTo(requirements []schema.Requirement, mapping map[string]string) (string, bool) {
+	if len(requirements) == 0 {
+		return "", false
+	}
+	var to string
+	for _, req := range requirements {
+		mapped, ok := mapping[req.Description]
+		if !ok || mapped == "" || (to != "" && mapped != to) {
+			return "", false
+		}
+		to = mapped
+	}
+	return to, true
+}
+
+// moveEvents builds the rename and merge events, re-identifying surviving
+// requirements whose ID carries the old category. Requirements removed this
+// turn keep their ID so their deletion still finds them.
+func (p *categoryPlan) moveEvents() []schema.ChangelogEvent {
+	events := make([]schema.ChangelogEvent, 0, len(p.Moves))
+	for _, m := range p.Moves {
+		ids := make(map[string]string)
+		for _, req := range p.spec.Requirements {
+			if req.Category != m.From || p.removed[req.ID] {
+				continue
+			}
+			if newID, ok := schema.RecategorizeRequirementID(req.ID, m.From, m.To); ok {
+				ids[req.ID] = newID
+			}
+		}
+		if len(ids) == 0 {
+			ids = nil
+		}
+
+		evtID, _ := schema.NewEventID()
+		if m.Merge {
+			events = append(events, &schema.CategoryMerged{
+				EventID_:       evtID,
+				Source:         m.From,
+				Target:         m.To,
+				RequirementIDs: ids,
+				Timestamp_:     time.Now(),
+			})
+			continue
+		}
+		events = append(events, &schema.CategoryRenamed{
+			EventID_:       evtID,
+			OldName:        m.From,
+			NewName:        m.To,
+			RequirementIDs: ids,
+			Timestamp_:     time.Now(),
+		})
+	}
+	return events
+}
+
+// describe summarizes the moves and deletions for the version bump task.
+func (p *categoryPlan) describe() []string {
+	var lines []string
+	for _, m := range p.Moves {
+		if m.Merge {
+			lines = append(lines, fmt.Sprintf("Category merged: %s into %s", m.From, m.To))
+		} else {
+			lines = append(lines, fmt.Sprintf("Category renamed: %s to %s", m.From, m.To))
+		}
+	}
+	for _, cat := range p.Deleted {
+		lines = append(lines, "Category removed: "+cat)
+	}
+	return lines
+}
+
+// CategoryRollup is one node of the category tree with its requirement
+// counts. Total includes every subcategory.
+type CategoryRollup struct {
+	Name        string
+	Description string
+	Owner       string
+	Depth       int // 1 for top-level categories
+	Direct      int // Requirements in exactly this category
+	Total       int
+}
+
+// CategoryRollups returns the category tree of spec depth-first, parents
+// before children and siblings by name. Categories used by requirements
+// but never declared are included, along with their parents.
+func CategoryRollups(spec *schema.Specification) []CategoryRollup {
+	index := make(map[string]int)
+	var rollups []CategoryRollup
+	add := func(name string) {
+		for _, cat := range append(schema.CategoryAncestors(name), name) {
+			if _, ok := index[cat]; ok {
+				continue
+			}
+			index[cat] = len(rollups)
+			rollup := CategoryRollup{Name: cat, Depth: schema.CategoryDepth(cat)}
+			if c := spec.FindCategory(cat); c != nil {
+				rollup.Description = c.Description
+				rollup.Owner = c.Owner
+			}
+			rollups = append(rollups, rollup)
+		}
+	}
+	for _, c := range spec.Categories {
+		add(c.Name)
+	}
+	for _, req := range spec.Requirements {
+		add(req.Category)
+		rollups[index[req.Category]].Direct++
+		for _, cat := range append(schema.CategoryAncestors(req.Category), req.Category) {
+			rollups[index[cat]].Total++
+		}
+	}
+
+	slices.SortFunc(rollups, func(a, b CategoryRollup) int {
+		return slices.Compare(
+			strings.Split(a.Name, schema.CategorySeparator),
+			strings.Split(b.Name, schema.CategorySeparator),
+		)
+	})
+	return rollups
+}
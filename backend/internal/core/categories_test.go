@@ -0,0 +1,290 @@
+package core
+
+import (
+	"testing"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// categorizationOf builds a categorization proposing names with mapping.
+func categorizationOf(mapping map[string]string, names ...string) *tasks.CategorizationOutput {
+	out := &tasks.CategorizationOutput{RequirementMapping: mapping}
+	for _, name := range names {
+		out.Categories = append(out.Categories, struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Count       int    `json:"count"`
+		}{Name: name})
+	}
+	return out
+}
+
+// deltaRemoving builds a delta that removes ids.
+func deltaRemoving(ids ...string) *tasks.RequirementsDeltaOutput {
+	delta := &tasks.RequirementsDeltaOutput{}
+	for _, id := range ids {
+		delta.ToRemove = append(delta.ToRemove, struct {
+			ID        string `json:"id"`
+			Reasoning string `json:"reasoning"`
+		}{ID: id, Reasoning: "Obsolete"})
+	}
+	return delta
+}
+
+func categorySpec() *schema.Specification {
+	return &schema.Specification{
+		Categories: []schema.Category{{Name: "LOGIN"}, {Name: "API"}},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-LOGIN-aaa111", Category: "LOGIN", Description: "Users log in with email"},
+			{ID: "REQ-LOGIN-bbb222", Category: "LOGIN", Description: "Users log out"},
+			{ID: "REQ-API-ccc333", Category: "API", Description: "Expose a REST API"},
+		},
+	}
+}
+
+func TestPlanCategories_Rename(t *testing.T) {
+	spec := categorySpec()
+	categorization := categorizationOf(map[string]string{
+		"Users log in with email": "AUTH",
+		"Users log out":           "AUTH",
+		"Expose a REST API":       "API",
+	}, "AUTH", "API")
+
+	plan := planCategories(spec, deltaRemoving(), categorization)
+	plan.settle(nil)
+
+	assert.Equal(t, []categoryMove{{From: "LOGIN", To: "AUTH"}}, plan.Moves)
+	assert.Equal(t, "AUTH", plan.Resolve("LOGIN"))
+	assert.Equal(t, "API", plan.Resolve("API"))
+	assert.Empty(t, plan.Added)
+	assert.Empty(t, plan.Deleted)
+
+	events := plan.moveEvents()
+	require.Len(t, events, 1)
+	renamed, ok := events[0].(*schema.CategoryRenamed)
+	require.True(t, ok, "expected CategoryRenamed, got %T", events[0])
+	assert.Equal(t, "LOGIN", renamed.OldName)
+	assert.Equal(t, "AUTH", renamed.NewName)
+	assert.Equal(t, map[string]string{
+		"REQ-LOGIN-aaa111": "REQ-AUTH-aaa111",
+		"REQ-LOGIN-bbb222": "REQ-AUTH-bbb222",
+	}, renamed.RequirementIDs)
+	assert.Equal(t, []string{"Category renamed: LOGIN to AUTH"}, plan.describe())
+}
+
+func TestPlanCategories_Merge(t *testing.T) {
+	spec := categorySpec()
+	categorization := categorizationOf(map[string]string{
+		"Users log in with email": "API",
+		"Users log out":           "API",
+		"Expose a REST API":       "API",
+	}, "API")
+
+	plan := planCategories(spec, deltaRemoving(), categorization)
+	plan.settle(nil)
+
+	assert.Equal(t, []categoryMove{{From: "LOGIN", To: "API", Merge: true}}, plan.Moves)
+	assert.Empty(t, plan.Deleted, "the merge removes the source category itself")
+
+	events := plan.moveEvents()
+	require.Len(t, events, 1)
+	merged, ok := events[0].(*schema.CategoryMerged)
+	require.True(t, ok, "expected CategoryMerged, got %T", events[0])
+	assert.Equal(t, "LOGIN", merged.Source)
+	assert.Equal(t, "API", merged.Target)
+
+	// The events replay cleanly onto the spec
+	require.NoError(t, spec.MergeCategory(merged.Source, merged.Target, merged.RequirementIDs))
+	assert.Equal(t, []string{"API"}, spec.CategoryNames())
+}
+
+func TestPlanCategories_RemovedRequirementsKeepTheirID(t *testing.T) {
+	spec := categorySpec()
+	categorization := categorizationOf(map[string]string{
+		"Users log in with email": "AUTH",
+		"Expose a REST API":       "API",
+	}, "AUTH", "API")
+
+	plan := planCategories(spec, deltaRemoving("REQ-LOGIN-bbb222"), categorization)
+	require.Len(t, plan.Moves, 1)
+
+	renamed := plan.moveEvents()[0].(*schema.CategoryRenamed)
+	assert.Equal(t, map[string]string{"REQ-LOGIN-aaa111": "REQ-AUTH-aaa111"}, renamed.RequirementIDs)
+}
+
+func TestPlanCategories_NoMoveWhenSplit(t *testing.T) {
+	spec := categorySpec()
+	categorization := categorizationOf(map[string]string{
+		"Users log in with email": "AUTH",
+		"Users log out":           "SESSION",
+		"Expose a REST API":       "API",
+	}, "AUTH", "SESSION", "API")
+
+	plan := planCategories(spec, deltaRemoving(), categorization)
+	assert.Empty(t, plan.Moves)
+	assert.Equal(t, "LOGIN", plan.Resolve("LOGIN"))
+}
+
+func TestPlanCategories_ChainSkipped(t *testing.T) {
+	spec := categorySpec()
+	categorization := categorizationOf(map[string]string{
+		"Users log in with email": "API",
+		"Users log out":           "API",
+		"Expose a REST API":       "INTEGRATION",
+	}, "API", "INTEGRATION")
+
+	plan := planCategories(spec, deltaRemoving(), categorization)
+
+	// API moves on, so LOGIN stays rather than following it
+	assert.Equal(t, []categoryMove{{From: "API", To: "INTEGRATION"}}, plan.Moves)
+	assert.Equal(t, "LOGIN", plan.Resolve("LOGIN"))
+}
+
+func TestPlanCategories_Delete(t *testing.T) {
+	t.Run("emptied this turn", func(t *testing.T) {
+		spec := categorySpec()
+		plan := planCategories(spec, deltaRemoving("REQ-API-ccc333"), categorizationOf(nil, "LOGIN", "API"))
+		plan.settle(nil)
+		assert.Equal(t, []string{"API"}, plan.Deleted)
+		assert.Equal(t, []string{"Category removed: API"}, plan.describe())
+	})
+
+	t.Run("empty and no longer proposed", func(t *testing.T) {
+		spec := categorySpec()
+		spec.Categories = append(spec.Categories, schema.Category{Name: "LEGACY"})
+		plan := planCategories(spec, deltaRemoving(), categorizationOf(nil, "LOGIN", "API"))
+		plan.settle(nil)
+		assert.Equal(t, []string{"LEGACY"}, plan.Deleted)
+	})
+
+	t.Run("empty but still proposed", func(t *testing.T) {
+		spec := categorySpec()
+		spec.Categories = append(spec.Categories, schema.Category{Name: "LEGACY"})
+		plan := planCategories(spec, deltaRemoving(), categorizationOf(nil, "LOGIN", "API", "LEGACY"))
+		plan.settle(nil)
+		assert.Empty(t, plan.Deleted)
+	})
+
+	t.Run("refilled by a new requirement", func(t *testing.T) {
+		spec := categorySpec()
+		plan := planCategories(spec, deltaRemoving("REQ-API-ccc333"), categorizationOf(nil, "LOGIN", "API"))
+		plan.settle([]string{"API", "BILLING"})
+		assert.Empty(t, plan.Deleted)
+		assert.Equal(t, []string{"BILLING"}, plan.Added)
+	})
+}
+
+func TestBuildChangelog_CategoryRename(t *testing.T) {
+	spec := categorySpec()
+	spec.Metadata = schema.ProjectMetadata{Name: "App", Version: "0.1.0"}
+	categorization := categorizationOf(map[string]string{
+		"Users log in with email": "AUTH",
+		"Users log out":           "AUTH",
+		"Expose a REST API":       "API",
+	}, "AUTH", "API")
+	delta := deltaRemoving()
+	plan := planCategories(spec, delta, categorization)
+
+	events := buildChangelog(spec, &tasks.MetadataOutput{}, delta, plan, nil)
+	require.Len(t, events, 1)
+	_, ok := events[0].(*schema.CategoryRenamed)
+	assert.True(t, ok, "expected CategoryRenamed, got %T", events[0])
+
+	summary := summarizeProposal(events, nil, nil)
+	assert.Contains(t, summary, "Rename category LOGIN -> AUTH")
+}
+
+func TestBuildChangelog_CategoryDescription(t *testing.T) {
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0"}}
+	categorization := categorizationOf(map[string]string{"Users log in with email": "AUTH"}, "AUTH")
+	categorization.Categories[0].Description = "Login, sessions and access control"
+	delta := deltaRemoving()
+
+	newRequirements := []schema.Requirement{{ID: "REQ-AUTH-aaa111", Category: "AUTH", Description: "Users log in with email"}}
+	events := buildChangelog(spec, &tasks.MetadataOutput{}, delta, planCategories(spec, delta, categorization), newRequirements)
+
+	var added *schema.CategoryAdded
+	for _, event := range events {
+		if e, ok := event.(*schema.CategoryAdded); ok {
+			added = e
+		}
+	}
+	require.NotNil(t, added, "expected a CategoryAdded event")
+	assert.Equal(t, "AUTH", added.Name)
+	assert.Equal(t, "Login, sessions and access control", added.Description)
+}
+
+func TestPlanCategories_Nested(t *testing.T) {
+	t.Run("new subcategory declares its parent first", func(t *testing.T) {
+		spec := categorySpec()
+		plan := planCategories(spec, deltaRemoving(), categorizationOf(nil, "LOGIN", "API", "AUTH/SSO"))
+		plan.settle([]string{"AUTH/SSO"})
+		assert.Equal(t, []string{"AUTH", "AUTH/SSO"}, plan.Added)
+	})
+
+	t.Run("move into a subcategory", func(t *testing.T) {
+		spec := categorySpec()
+		categorization := categorizationOf(map[string]string{
+			"Users log in with email": "AUTH/LOGIN",
+			"Users log out":           "AUTH/LOGIN",
+			"Expose a REST API":       "API",
+		}, "AUTH/LOGIN", "API")
+
+		plan := planCategories(spec, deltaRemoving(), categorization)
+		plan.settle([]string{"AUTH"})
+		assert.Equal(t, []categoryMove{{From: "LOGIN", To: "AUTH/LOGIN"}}, plan.Moves)
+		assert.Empty(t, plan.Added, "the rename already declares AUTH")
+
+		renamed := plan.moveEvents()[0].(*schema.CategoryRenamed)
+		assert.Equal(t, "REQ-AUTH.LOGIN-aaa111", renamed.RequirementIDs["REQ-LOGIN-aaa111"])
+	})
+
+	t.Run("parents are never moved or moved into themselves", func(t *testing.T) {
+		spec := categorySpec()
+		spec.Categories = append(spec.Categories, schema.Category{Name: "API/REST"})
+		categorization := categorizationOf(map[string]string{
+			"Users log in with email": "LOGIN/EMAIL",
+			"Users log out":           "LOGIN/EMAIL",
+			"Expose a REST API":       "INTEGRATION",
+		}, "LOGIN/EMAIL", "INTEGRATION")
+
+		plan := planCategories(spec, deltaRemoving(), categorization)
+		assert.Empty(t, plan.Moves)
+	})
+
+	t.Run("children are deleted before parents, and parents of kept children stay", func(t *testing.T) {
+		spec := &schema.Specification{
+			Categories: []schema.Category{{Name: "AUTH"}, {Name: "AUTH/SSO"}, {Name: "AUTH/MFA"}, {Name: "API"}, {Name: "API/REST"}},
+			Requirements: []schema.Requirement{
+				{ID: "REQ-AUTH.SSO-aaa111", Category: "AUTH/SSO", Description: "Users log in with SAML"},
+				{ID: "REQ-API.REST-bbb222", Category: "API/REST", Description: "Expose a REST API"},
+			},
+		}
+		plan := planCategories(spec, deltaRemoving("REQ-AUTH.SSO-aaa111", "REQ-API.REST-bbb222"), categorizationOf(nil, "AUTH/MFA"))
+		plan.settle(nil)
+		assert.Equal(t, []string{"AUTH/SSO", "API/REST", "API"}, plan.Deleted)
+	})
+}
+
+func TestCategoryRollups(t *testing.T) {
+	spec := &schema.Specification{
+		Categories: []schema.Category{{Name: "TASK"}, {Name: "AUTH", Description: "Login"}, {Name: "AUTH/SSO"}},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-a", Category: "AUTH"},
+			{ID: "REQ-AUTH.SSO-b", Category: "AUTH/SSO"},
+			{ID: "REQ-AUTH.SSO.SAML-c", Category: "AUTH/SSO/SAML"}, // Undeclared
+		},
+	}
+
+	rollups := CategoryRollups(spec)
+	assert.Equal(t, []CategoryRollup{
+		{Name: "AUTH", Description: "Login", Depth: 1, Direct: 1, Total: 3},
+		{Name: "AUTH/SSO", Depth: 2, Direct: 1, Total: 2},
+		{Name: "AUTH/SSO/SAML", Depth: 3, Direct: 1, Total: 1},
+		{Name: "TASK", Depth: 1},
+	}, rollups)
+}
@@ -9,6 +9,8 @@ type Config struct {
 	LogLevel         string // DEBUG, INFO, WARN, ERROR
 	OpenRouterAPIKey string // Required for LLM operations
 	DefaultModel     string // Default LLM model to use
+	Offline          bool   // Use rule-based heuristics instead of the LLM (XDD_OFFLINE=1)
+	NoCache          bool   // Bypass the on-disk LLM response cache (XDD_NO_CACHE=1)
 }
 
 // LoadConfig loads configuration from environment variables.
@@ -24,6 +26,8 @@ func LoadConfig() (*Config, error) {
 		LogLevel:         logLevel,
 		OpenRouterAPIKey: os.Getenv("OPENROUTER_API_KEY"),
 		DefaultModel:     getEnvOrDefault("DEFAULT_MODEL", "openrouter/anthropic/claude-3.5-sonnet"),
+		Offline:          os.Getenv("XDD_OFFLINE") == "1",
+		NoCache:          os.Getenv("XDD_NO_CACHE") == "1",
 	}
 
 	// Don't require API key for basic operations
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+)
+
+// ErrCommitBlocked is returned when committing with unresolved critical conflicts.
+var ErrCommitBlocked = errors.New("critical conflicts must be resolved or overridden before commit")
+
+// detectConflicts checks new requirements against the existing ones that
+// survive this change.
+func (o *Orchestrator) detectConflicts(
+	ctx context.Context,
+	spec *schema.Specification,
+	delta *tasks.RequirementsDeltaOutput,
+	newRequirements []schema.Requirement,
+) ([]tasks.Conflict, error) {
+	if len(newRequirements) == 0 {
+		return nil, nil
+	}
+
+	removed := make(map[string]bool, len(delta.ToRemove))
+	for _, rem := range delta.ToRemove {
+		removed[rem.ID] = true
+	}
+	existing := make([]schema.Requirement, 0, len(spec.Requirements))
+	for _, req := range spec.Requirements {
+		if !removed[req.ID] {
+			existing = append(existing, req)
+		}
+	}
+
+	output, err := o.executor.ExecuteConflictAnalysis(ctx, &tasks.ConflictInput{
+		NewRequirements:      newRequirements,
+		ExistingRequirements: existing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Conflicts, nil
+}
+
+// CriticalConflicts returns the pending critical conflicts.
+func (s *SessionState) CriticalConflicts() []tasks.Conflict {
+	var critical []tasks.Conflict
+	for _, c := range s.Conflicts {
+		if c.Severity == tasks.ConflictCritical {
+			critical = append(critical, c)
+		}
+	}
+	return critical
+}
+
+// CommitBlocked reports whether critical conflicts prevent committing the
+// pending changelog.
+func (s *SessionState) CommitBlocked() bool {
+	return len(s.CriticalConflicts()) > 0 && !s.conflictsOverridden()
+}
+
+// OverrideConflicts records the user's decision to commit despite critical
+// conflicts by appending a ConflictOverridden event to the pending changelog.
+func (s *SessionState) OverrideConflicts(reason string) error {
+	critical := s.CriticalConflicts()
+	if len(critical) == 0 {
+		return fmt.Errorf("no critical conflicts to override")
+	}
+	if reason == "" {
+		return fmt.Errorf("a reason is required to override conflicts")
+	}
+	if s.conflictsOverridden() {
+		return nil
+	}
+
+	records := make([]schema.ConflictRecord, 0, len(critical))
+	for _, c := range critical {
+		records = append(records, schema.ConflictRecord{
+			RequirementA: c.RequirementA,
+			RequirementB: c.RequirementB,
+			Severity:     c.Severity,
+			Explanation:  c.Explanation,
+		})
+	}
+
+	evtID, _ := schema.NewEventID()
+	s.PendingChangelog = append(s.PendingChangelog, &schema.ConflictOverridden{
+		EventID_:   evtID,
+		Conflicts:  records,
+		Reason:     reason,
+		Timestamp_: time.Now(),
+	})
+	return nil
+}
+
+// conflictsOverridden reports whether the pending changelog already records an override.
+func (s *SessionState) conflictsOverridden() bool {
+	return slices.ContainsFunc(s.PendingChangelog, func(e schema.ChangelogEvent) bool {
+		_, ok := e.(*schema.ConflictOverridden)
+		return ok
+	})
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conflictRecorder captures the conflict analysis input.
+type conflictRecorder struct {
+	*MockTaskExecutor
+	input *tasks.ConflictInput
+}
+
+func (r *conflictRecorder) ExecuteConflictAnalysis(ctx context.Context, input *tasks.ConflictInput) (*tasks.ConflictOutput, error) {
+	r.input = input
+	return r.MockTaskExecutor.ExecuteConflictAnalysis(ctx, input)
+}
+
+func criticalConflict() *tasks.ConflictOutput {
+	return &tasks.ConflictOutput{Conflicts: []tasks.Conflict{
+		{RequirementA: "REQ-AUTH-new", RequirementB: "REQ-AUTH-abc123", Severity: tasks.ConflictCritical, Explanation: "15 minute expiry vs 24 hour sessions"},
+		{RequirementA: "REQ-AUTH-new", RequirementB: "REQ-AUTH-def456", Severity: tasks.ConflictMinor, Explanation: "Inconsistent wording"},
+	}}
+}
+
+func TestOrchestrator_ProcessPrompt_DetectsConflicts(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	require.NoError(t, repo.WriteSpecification(context.Background(), &schema.Specification{
+		Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-abc123", Category: "AUTH", Description: "Sessions shall persist for 24 hours", CreatedAt: time.Now()},
+			{ID: "REQ-AUTH-def456", Category: "AUTH", Description: "Legacy login", CreatedAt: time.Now()},
+		},
+		Categories: []schema.Category{{Name: "AUTH"}},
+	}))
+
+	mockExecutor := NewMockTaskExecutor()
+	mockExecutor.ConflictOutput = criticalConflict()
+	mockExecutor.RequirementsDeltaOutput.ToRemove = append(mockExecutor.RequirementsDeltaOutput.ToRemove, struct {
+		ID        string `json:"id"`
+		Reasoning string `json:"reasoning"`
+	}{ID: "REQ-AUTH-def456", Reasoning: "Replaced"})
+	recorder := &conflictRecorder{MockTaskExecutor: mockExecutor}
+	orch := NewOrchestrator(recorder, repo)
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Sessions expire after 15 minutes")
+	require.NoError(t, err)
+
+	// New requirements are compared with the existing ones that survive the change
+	require.NotNil(t, recorder.input)
+	assert.Len(t, recorder.input.NewRequirements, 2)
+	require.Len(t, recorder.input.ExistingRequirements, 1)
+	assert.Equal(t, "REQ-AUTH-abc123", recorder.input.ExistingRequirements[0].ID)
+
+	assert.Len(t, state.Conflicts, 2)
+	assert.Len(t, state.CriticalConflicts(), 1)
+	assert.True(t, state.CommitBlocked())
+	assert.Contains(t, state.Messages[len(state.Messages)-1].Content, "Conflict (critical) REQ-AUTH-new vs REQ-AUTH-abc123")
+}
+
+func TestOrchestrator_ProcessPrompt_ConflictOptions(t *testing.T) {
+	repo, _ := createTestRepository(t)
+
+	mockExecutor := NewMockTaskExecutor()
+	orch := NewOrchestrator(mockExecutor, repo)
+	orch.SetGenerationOptions(GenerationOptions{SkipConflicts: true})
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+	assert.Zero(t, mockExecutor.ConflictCalls)
+	assert.False(t, state.CommitBlocked())
+
+	mockExecutor.ConflictError = errors.New("provider down")
+	orch.SetGenerationOptions(GenerationOptions{})
+	_, err = orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	assert.ErrorContains(t, err, "conflict analysis task")
+}
+
+func TestSessionState_OverrideConflicts(t *testing.T) {
+	state := NewSessionState()
+	assert.Error(t, state.OverrideConflicts("because"), "nothing to override")
+
+	state.Conflicts = criticalConflict().Conflicts
+	require.True(t, state.CommitBlocked())
+	assert.Error(t, state.OverrideConflicts(""), "reason is required")
+
+	require.NoError(t, state.OverrideConflicts("Old requirement goes next sprint"))
+	assert.False(t, state.CommitBlocked())
+
+	require.Len(t, state.PendingChangelog, 1)
+	override, ok := state.PendingChangelog[0].(*schema.ConflictOverridden)
+	require.True(t, ok)
+	assert.Equal(t, "Old requirement goes next sprint", override.Reason)
+	require.Len(t, override.Conflicts, 1, "only critical conflicts are recorded")
+	assert.Equal(t, "REQ-AUTH-abc123", override.Conflicts[0].RequirementB)
+
+	// Overriding twice records one event
+	require.NoError(t, state.OverrideConflicts("again"))
+	assert.Len(t, state.PendingChangelog, 1)
+}
+
+func runWithInput(t *testing.T, session *CLISession, input string) error {
+	t.Helper()
+
+	r, w, _ := os.Pipe()
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+	go func() {
+		defer w.Close()
+		w.WriteString(input)
+	}()
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	return session.Run("Sessions expire after 15 minutes")
+}
+
+func TestCLISession_Run_CriticalConflicts(t *testing.T) {
+	newSession := func(t *testing.T) (*CLISession, string) {
+		repo, tempDir := createTestRepository(t)
+		lockDir := filepath.Join(tempDir, ".xdd")
+		require.NoError(t, os.MkdirAll(lockDir, 0755))
+
+		mockExecutor := NewMockTaskExecutor()
+		mockExecutor.ConflictOutput = criticalConflict()
+		session := NewCLISessionWithExecutor(mockExecutor, repo)
+		session.Lock = repository.NewFileLock(filepath.Join(lockDir, ".lock"), "cli")
+		return session, tempDir
+	}
+
+	t.Run("yes is refused", func(t *testing.T) {
+		session, _ := newSession(t)
+		require.NoError(t, runWithInput(t, session, "yes\nno\n"))
+		assert.False(t, session.State.Committed)
+		assert.ErrorIs(t, session.commit(context.Background()), ErrCommitBlocked)
+	})
+
+	t.Run("override is recorded", func(t *testing.T) {
+		session, tempDir := newSession(t)
+		require.NoError(t, runWithInput(t, session, "override\nAccepted risk for the beta\nyes\n"))
+		assert.True(t, session.State.Committed)
+
+		data, err := os.ReadFile(filepath.Join(tempDir, "01-specs", "changelog.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "event_type: ConflictOverridden")
+		assert.Contains(t, string(data), "reason: Accepted risk for the beta")
+	})
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/similarity"
+	"xdd/internal/versioning"
+	"xdd/pkg/schema"
+)
+
+// PossibleDuplicate flags a proposed requirement that closely matches an
+// existing one.
+type PossibleDuplicate struct {
+	RequirementID       string // Generated requirement; set once generation succeeds
+	BriefDescription    string
+	ExistingID          string
+	ExistingDescription string
+	Score               float64
+}
+
+// requirementIndex builds a similarity index over requirement descriptions.
+func requirementIndex(requirements []schema.Requirement) *similarity.Index {
+	docs := make([]similarity.Document, 0, len(requirements))
+	for _, req := range requirements {
+		docs = append(docs, similarity.Document{ID: req.ID, Text: req.Description})
+	}
+	return similarity.NewIndex(docs)
+}
+
+// findDuplicates checks every ToAdd brief against the existing requirements.
+// The result is indexed like delta.ToAdd; nil means no likely duplicate.
+func findDuplicates(
+	requirements []schema.Requirement,
+	delta *tasks.RequirementsDeltaOutput,
+	threshold float64,
+) []*PossibleDuplicate {
+	duplicates := make([]*PossibleDuplicate, len(delta.ToAdd))
+	if len(requirements) == 0 {
+		return duplicates
+	}
+
+	descriptions := make(map[string]string, len(requirements))
+	for _, req := range requirements {
+		descriptions[req.ID] = req.Description
+	}
+
+	idx := requirementIndex(requirements)
+	for i, add := range delta.ToAdd {
+		matches := idx.Query(add.BriefDescription, threshold)
+		if len(matches) == 0 {
+			continue
+		}
+		duplicates[i] = &PossibleDuplicate{
+			BriefDescription:    add.BriefDescription,
+			ExistingID:          matches[0].ID,
+			ExistingDescription: descriptions[matches[0].ID],
+			Score:               matches[0].Score,
+		}
+	}
+	return duplicates
+}
+
+// DuplicateClusters groups requirements in spec that are likely duplicates
+// of each other.
+func DuplicateClusters(spec *schema.Specification, threshold float64) [][]schema.Requirement {
+	byID := make(map[string]schema.Requirement, len(spec.Requirements))
+	for _, req := range spec.Requirements {
+		byID[req.ID] = req
+	}
+
+	var clusters [][]schema.Requirement
+	for _, group := range requirementIndex(spec.Requirements).Clusters(threshold) {
+		cluster := make([]schema.Requirement, 0, len(group))
+		for _, doc := range group {
+			cluster = append(cluster, byID[doc.ID])
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// SkipDuplicates drops the proposed requirements flagged as possible
+// duplicates from the pending changelog, along with categories only they
+// needed and the reviews and conflicts raised against them, and recomputes
+// the version bump for what is left. It returns how many requirements were
+// dropped.
+func (o *Orchestrator) SkipDuplicates(s *SessionState) (int, error) {
+	skip := make(map[string]bool, len(s.PossibleDuplicates))
+	for _, d := range s.PossibleDuplicates {
+		skip[d.RequirementID] = true
+	}
+
+	inUse := make(map[string]bool)
+	for _, event := range s.PendingChangelog {
+		if added, ok := event.(*schema.RequirementAdded); ok && !skip[added.Requirement.ID] {
+			inUse[added.Requirement.Category] = true
+			for _, ancestor := range schema.CategoryAncestors(added.Requirement.Category) {
+				inUse[ancestor] = true
+			}
+		}
+	}
+
+	kept := make([]schema.ChangelogEvent, 0, len(s.PendingChangelog))
+	var previous *schema.VersionBumped
+	skipped := 0
+	for _, event := range s.PendingChangelog {
+		switch e := event.(type) {
+		case *schema.RequirementAdded:
+			if skip[e.Requirement.ID] {
+				skipped++
+				continue
+			}
+		case *schema.CategoryAdded:
+			if !inUse[e.Name] {
+				continue
+			}
+		case *schema.ConflictOverridden:
+			records := slices.DeleteFunc(slices.Clone(e.Conflicts), func(c schema.ConflictRecord) bool {
+				return skip[c.RequirementA] || skip[c.RequirementB]
+			})
+			if len(records) == 0 {
+				continue
+			}
+			overridden := *e
+			overridden.Conflicts = records
+			event = &overridden
+		case *schema.VersionBumped:
+			previous = e
+			continue
+		}
+		kept = append(kept, event)
+	}
+
+	if previous != nil {
+		bump, err := versioning.Compute(o.versioning, previous.OldVersion, kept)
+		if err != nil {
+			return 0, fmt.Errorf("version rules: %w", err)
+		}
+		version := &tasks.VersionBumpOutput{
+			NewVersion: bump.NewVersion,
+			BumpType:   string(bump.Type),
+			Reasoning:  previous.Reasoning,
+		}
+		if version.NewVersion != previous.NewVersion {
+			version.Reasoning = "After skipping possible duplicates: " + strings.Join(bump.Rules, "; ")
+		}
+		kept = appendVersionBump(kept, previous.OldVersion, version)
+	}
+
+	s.PendingChangelog = kept
+	s.PossibleDuplicates = nil
+	s.Reviews = slices.DeleteFunc(s.Reviews, func(r RequirementReview) bool {
+		return skip[r.RequirementID]
+	})
+	s.Conflicts = slices.DeleteFunc(s.Conflicts, func(c tasks.Conflict) bool {
+		return skip[c.RequirementA] || skip[c.RequirementB]
+	})
+	return skipped, nil
+}
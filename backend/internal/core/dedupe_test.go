@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrchestrator_ProcessPrompt_FlagsDuplicates(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	require.NoError(t, repo.WriteSpecification(context.Background(), &schema.Specification{
+		Metadata: schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-abc123", Category: "AUTH", Description: "Authentication requirement for every user", CreatedAt: time.Now()},
+			{ID: "REQ-REPORTS-def456", Category: "REPORTS", Description: "Export monthly reports as PDF", CreatedAt: time.Now()},
+		},
+		Categories: []schema.Category{{Name: "AUTH"}, {Name: "REPORTS"}},
+	}))
+
+	orch := NewOrchestrator(NewMockTaskExecutor(), repo)
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Add login and tasks")
+	require.NoError(t, err)
+
+	// Only the authentication brief restates an existing requirement
+	require.Len(t, state.PossibleDuplicates, 1)
+	dup := state.PossibleDuplicates[0]
+	assert.Equal(t, "User authentication requirement", dup.BriefDescription)
+	assert.Equal(t, "REQ-AUTH-abc123", dup.ExistingID)
+	assert.Contains(t, dup.RequirementID, "REQ-AUTH-")
+	assert.GreaterOrEqual(t, dup.Score, DefaultGenerationOptions().DuplicateThreshold)
+
+	notes := state.requirementNotes()
+	require.NotEmpty(t, notes[dup.RequirementID])
+	assert.Contains(t, notes[dup.RequirementID][0], "Possible duplicate of REQ-AUTH-abc123")
+
+	// Skipping drops just the flagged addition
+	before := countEvents(state.PendingChangelog, "RequirementAdded")
+	skipped, err := orch.SkipDuplicates(state)
+	require.NoError(t, err)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, before-1, countEvents(state.PendingChangelog, "RequirementAdded"))
+	for _, event := range state.PendingChangelog {
+		if added, ok := event.(*schema.RequirementAdded); ok {
+			assert.NotEqual(t, dup.RequirementID, added.Requirement.ID)
+		}
+	}
+	assert.Empty(t, state.PossibleDuplicates)
+}
+
+func TestOrchestrator_ProcessPrompt_NoDuplicatesInEmptySpec(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	orch := NewOrchestrator(NewMockTaskExecutor(), repo)
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+	assert.Empty(t, state.PossibleDuplicates)
+}
+
+func TestOrchestrator_SkipDuplicates_RecomputesChangelog(t *testing.T) {
+	now := time.Now()
+	state := NewSessionState()
+	state.PendingChangelog = []schema.ChangelogEvent{
+		&schema.CategoryAdded{EventID_: "EVT-1", Name: "BILLING", Timestamp_: now},
+		&schema.CategoryAdded{EventID_: "EVT-2", Name: "AUTH", Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-3", Requirement: schema.Requirement{ID: "REQ-BILLING-aaa111", Category: "BILLING"}, Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-4", Requirement: schema.Requirement{ID: "REQ-AUTH-bbb222", Category: "AUTH"}, Timestamp_: now},
+		&schema.VersionBumped{EventID_: "EVT-5", OldVersion: "1.2.3", NewVersion: "1.3.0", BumpType: "minor", Reasoning: "New features", Timestamp_: now},
+	}
+	state.PossibleDuplicates = []PossibleDuplicate{{RequirementID: "REQ-AUTH-bbb222"}}
+
+	orch := NewOrchestrator(NewMockTaskExecutor(), nil)
+	skipped, err := orch.SkipDuplicates(state)
+	require.NoError(t, err)
+	assert.Equal(t, 1, skipped)
+
+	// AUTH was only needed by the skipped requirement
+	require.Len(t, state.PendingChangelog, 3)
+	assert.Equal(t, "BILLING", state.PendingChangelog[0].(*schema.CategoryAdded).Name)
+	assert.Equal(t, "REQ-BILLING-aaa111", state.PendingChangelog[1].(*schema.RequirementAdded).Requirement.ID)
+	bump := state.PendingChangelog[2].(*schema.VersionBumped)
+	assert.Equal(t, "1.3.0", bump.NewVersion)
+	assert.Equal(t, "New features", bump.Reasoning)
+
+	// Skipping the last addition leaves nothing but a patch
+	state.PossibleDuplicates = []PossibleDuplicate{{RequirementID: "REQ-BILLING-aaa111"}}
+	skipped, err = orch.SkipDuplicates(state)
+	require.NoError(t, err)
+	assert.Equal(t, 1, skipped)
+	require.Len(t, state.PendingChangelog, 1)
+	bump = state.PendingChangelog[0].(*schema.VersionBumped)
+	assert.Equal(t, "1.2.3", bump.OldVersion)
+	assert.Equal(t, "1.2.4", bump.NewVersion)
+	assert.Equal(t, "patch", bump.BumpType)
+}
+
+func TestOrchestrator_SkipDuplicates_UnblocksCommit(t *testing.T) {
+	now := time.Now()
+	state := NewSessionState()
+	state.PendingChangelog = []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{ID: "REQ-AUTH-aaa111", Category: "AUTH"}, Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: schema.Requirement{ID: "REQ-AUTH-bbb222", Category: "AUTH"}, Timestamp_: now},
+	}
+	state.PossibleDuplicates = []PossibleDuplicate{{RequirementID: "REQ-AUTH-bbb222"}}
+	state.Reviews = []RequirementReview{{RequirementID: "REQ-AUTH-aaa111"}, {RequirementID: "REQ-AUTH-bbb222"}}
+	state.Conflicts = []tasks.Conflict{
+		{RequirementA: "REQ-AUTH-bbb222", RequirementB: "REQ-AUTH-old000", Severity: tasks.ConflictCritical},
+		{RequirementA: "REQ-AUTH-aaa111", RequirementB: "REQ-AUTH-old000", Severity: "minor"},
+	}
+	require.True(t, state.CommitBlocked())
+	require.NoError(t, state.OverrideConflicts("accepted"))
+
+	_, err := NewOrchestrator(NewMockTaskExecutor(), nil).SkipDuplicates(state)
+	require.NoError(t, err)
+
+	assert.False(t, state.CommitBlocked(), "the only critical conflict was raised against the skipped requirement")
+	require.Len(t, state.Conflicts, 1)
+	assert.Equal(t, "REQ-AUTH-aaa111", state.Conflicts[0].RequirementA)
+	require.Len(t, state.Reviews, 1)
+	assert.Equal(t, "REQ-AUTH-aaa111", state.Reviews[0].RequirementID)
+	assert.Zero(t, countEvents(state.PendingChangelog, "ConflictOverridden"), "the override only covered the skipped requirement")
+}
+
+func TestDuplicateClusters(t *testing.T) {
+	spec := &schema.Specification{
+		Requirements: []schema.Requirement{
+			{ID: "REQ-A", Description: "The system shall send a password reset email"},
+			{ID: "REQ-B", Description: "The system shall export reports"},
+			{ID: "REQ-C", Description: "The system shall email a password reset link"},
+		},
+	}
+
+	clusters := DuplicateClusters(spec, 0.5)
+	require.Len(t, clusters, 1)
+	require.Len(t, clusters[0], 2)
+	assert.Equal(t, "REQ-A", clusters[0][0].ID)
+	assert.Equal(t, "REQ-C", clusters[0][1].ID)
+}
+
+func countEvents(events []schema.ChangelogEvent, eventType string) int {
+	n := 0
+	for _, event := range events {
+		if event.EventType() == eventType {
+			n++
+		}
+	}
+	return n
+}
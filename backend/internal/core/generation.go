@@ -0,0 +1,175 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/similarity"
+)
+
+// FailurePolicy controls what happens when some requirements fail to generate.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail aborts the whole prompt on the first failure.
+	FailurePolicyFail FailurePolicy = "fail"
+	// FailurePolicyKeep keeps the successful requirements and reports the failures.
+	FailurePolicyKeep FailurePolicy = "keep"
+)
+
+// DefaultGenerationConcurrency is the default number of parallel requirement generations.
+const DefaultGenerationConcurrency = 4
+
+// GenerationOptions configures the requirement generation fan-out.
+type GenerationOptions struct {
+	Concurrency        int           `yaml:"concurrency,omitempty"`         // Max parallel LLM calls (default: 4)
+	OnFailure          FailurePolicy `yaml:"on_failure,omitempty"`          // fail|keep (default: fail)
+	DuplicateThreshold float64       `yaml:"duplicate_threshold,omitempty"` // Similarity flagging a brief as a duplicate (default: 0.6)
+	SkipReview         bool          `yaml:"skip_review,omitempty"`         // Don't quality-review generated requirements
+	SkipConflicts      bool          `yaml:"skip_conflicts,omitempty"`      // Don't check generated requirements for conflicts
+}
+
+// DefaultGenerationOptions returns the default generation options.
+func DefaultGenerationOptions() GenerationOptions {
+	return GenerationOptions{
+		Concurrency:        DefaultGenerationConcurrency,
+		OnFailure:          FailurePolicyFail,
+		DuplicateThreshold: similarity.DefaultThreshold,
+	}
+}
+
+// SetDefaults fills in default values for unset fields.
+func (o *GenerationOptions) SetDefaults() {
+	if o.Concurrency == 0 {
+		o.Concurrency = DefaultGenerationConcurrency
+	}
+	if o.OnFailure == "" {
+		o.OnFailure = FailurePolicyFail
+	}
+	if o.DuplicateThreshold == 0 {
+		o.DuplicateThreshold = similarity.DefaultThreshold
+	}
+}
+
+// Validate checks the options are usable.
+func (o GenerationOptions) Validate() error {
+	if o.Concurrency < 0 {
+		return fmt.Errorf("concurrency must be positive, got %d", o.Concurrency)
+	}
+	if o.DuplicateThreshold < 0 || o.DuplicateThreshold > 1 {
+		return fmt.Errorf("duplicate_threshold must be between 0 and 1, got %g", o.DuplicateThreshold)
+	}
+	switch o.OnFailure {
+	case "", FailurePolicyFail, FailurePolicyKeep:
+		return nil
+	default:
+		return fmt.Errorf("on_failure must be %s|%s, got %q", FailurePolicyFail, FailurePolicyKeep, o.OnFailure)
+	}
+}
+
+// GenerationFailure records a requirement that could not be generated.
+type GenerationFailure struct {
+	Index            int // Position in the delta's ToAdd list
+	Category         string
+	BriefDescription string
+	Err              error
+}
+
+// GenerationError is returned when requirement generation fails under FailurePolicyFail.
+type GenerationError struct {
+	Failures []GenerationFailure
+}
+
+func (e *GenerationError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%q: %v", f.BriefDescription, f.Err))
+	}
+	return fmt.Sprintf("%d requirement(s) failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+func (e *GenerationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// generateAll runs ExecuteRequirementGen for every input over a bounded worker
+// pool. Outputs are returned in input order; a nil output marks a failed item.
+// Under FailurePolicyFail the first failure cancels the remaining work.
+func (o *Orchestrator) generateAll(
+	ctx context.Context,
+	inputs []*tasks.RequirementGenInput,
+) ([]*tasks.RequirementGenOutput, []GenerationFailure, error) {
+	opts := o.generation
+	opts.SetDefaults()
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outputs := make([]*tasks.RequirementGenOutput, len(inputs))
+	errs := runBounded(ctx, len(inputs), opts.Concurrency, func(i int) error {
+		output, err := o.executor.ExecuteRequirementGen(ctx, inputs[i])
+		if err == nil {
+			err = checkCriteria(output.AcceptanceCriteria)
+		}
+		if err != nil {
+			if opts.OnFailure == FailurePolicyFail {
+				cancel()
+			}
+			return err
+		}
+		outputs[i] = output
+		return nil
+	})
+
+	// The caller's cancellation always wins over the failure policy
+	if err := parent.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var failures []GenerationFailure
+	for i, err := range errs {
+		// Items cancelled after another item failed are not failures in their own right
+		if err == nil || errors.Is(err, context.Canceled) {
+			continue
+		}
+		failures = append(failures, GenerationFailure{
+			Index:            i,
+			Category:         inputs[i].Category,
+			BriefDescription: inputs[i].BriefDescription,
+			Err:              err,
+		})
+	}
+
+	if len(failures) > 0 && opts.OnFailure == FailurePolicyFail {
+		return nil, nil, &GenerationError{Failures: failures}
+	}
+
+	return outputs, failures, nil
+}
+
+// checkCriteria rejects generated criteria that could not be stored as
+// produced, whichever executor returned them, so a requirement never loses
+// criteria silently.
+func checkCriteria(criteria []tasks.AcceptanceCriterionJSON) error {
+	for i, ac := range criteria {
+		switch ac.Type {
+		case "behavioral", "assertion", "metric":
+			// Convertible as is
+		case "scenario_outline":
+			if ac.Examples == nil {
+				return fmt.Errorf("acceptance_criteria[%d]: scenario_outline requires examples", i)
+			}
+		default:
+			return fmt.Errorf("acceptance_criteria[%d]: unknown type %q", i, ac.Type)
+		}
+	}
+	return nil
+}
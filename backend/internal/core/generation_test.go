@@ -0,0 +1,224 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowGenExecutor echoes each brief back as the description after a delay,
+// tracking peak concurrency.
+type slowGenExecutor struct {
+	*MockTaskExecutor
+	delay   func(brief string) time.Duration
+	failOn  map[string]error
+	active  atomic.Int32
+	peak    atomic.Int32
+	started sync.Map
+}
+
+func (e *slowGenExecutor) ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error) {
+	e.started.Store(input.BriefDescription, true)
+	n := e.active.Add(1)
+	defer e.active.Add(-1)
+	for {
+		peak := e.peak.Load()
+		if n <= peak || e.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(e.delay(input.BriefDescription)):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if err := e.failOn[input.BriefDescription]; err != nil {
+		return nil, err
+	}
+	return &tasks.RequirementGenOutput{Description: input.BriefDescription, Priority: "medium"}, nil
+}
+
+func genInputs(n int) []*tasks.RequirementGenInput {
+	inputs := make([]*tasks.RequirementGenInput, n)
+	for i := range inputs {
+		inputs[i] = &tasks.RequirementGenInput{Category: "CAT", BriefDescription: fmt.Sprintf("req-%02d", i)}
+	}
+	return inputs
+}
+
+func TestGenerateAll_BoundedAndOrdered(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	exec := &slowGenExecutor{
+		MockTaskExecutor: NewMockTaskExecutor(),
+		// Later items finish first to prove ordering does not follow completion
+		delay: func(brief string) time.Duration {
+			var i int
+			fmt.Sscanf(brief, "req-%d", &i)
+			return time.Duration(20-i) * time.Millisecond
+		},
+	}
+	orch := NewOrchestrator(exec, repo)
+	orch.SetGenerationOptions(GenerationOptions{Concurrency: 3})
+
+	outputs, failures, err := orch.generateAll(context.Background(), genInputs(12))
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+
+	require.Len(t, outputs, 12)
+	for i, out := range outputs {
+		assert.Equal(t, fmt.Sprintf("req-%02d", i), out.Description)
+	}
+	assert.LessOrEqual(t, exec.peak.Load(), int32(3))
+	assert.Greater(t, exec.peak.Load(), int32(1), "expected generation to run in parallel")
+}
+
+func TestGenerateAll_FailPolicy(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	boom := errors.New("provider down")
+	exec := &slowGenExecutor{
+		MockTaskExecutor: NewMockTaskExecutor(),
+		delay: func(brief string) time.Duration {
+			if brief == "req-00" {
+				return 0
+			}
+			return time.Second
+		},
+		failOn: map[string]error{"req-00": boom},
+	}
+	orch := NewOrchestrator(exec, repo)
+	orch.SetGenerationOptions(GenerationOptions{Concurrency: 2, OnFailure: FailurePolicyFail})
+
+	start := time.Now()
+	_, _, err := orch.generateAll(context.Background(), genInputs(6))
+	require.Error(t, err)
+
+	var genErr *GenerationError
+	require.ErrorAs(t, err, &genErr)
+	require.Len(t, genErr.Failures, 1, "cancelled siblings are not reported as failures")
+	assert.Equal(t, "req-00", genErr.Failures[0].BriefDescription)
+	assert.ErrorIs(t, err, boom)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "expected remaining work to be cancelled")
+
+	_, queuedStarted := exec.started.Load("req-05")
+	assert.False(t, queuedStarted, "expected queued items not to start after failure")
+}
+
+func TestGenerateAll_KeepPolicy(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	exec := &slowGenExecutor{
+		MockTaskExecutor: NewMockTaskExecutor(),
+		delay:            func(string) time.Duration { return time.Millisecond },
+		failOn: map[string]error{
+			"req-01": errors.New("bad output"),
+			"req-03": errors.New("timeout"),
+		},
+	}
+	orch := NewOrchestrator(exec, repo)
+	orch.SetGenerationOptions(GenerationOptions{Concurrency: 4, OnFailure: FailurePolicyKeep})
+
+	outputs, failures, err := orch.generateAll(context.Background(), genInputs(5))
+	require.NoError(t, err)
+
+	require.Len(t, failures, 2)
+	assert.Equal(t, 1, failures[0].Index)
+	assert.Equal(t, 3, failures[1].Index)
+
+	assert.Nil(t, outputs[1])
+	assert.Nil(t, outputs[3])
+	assert.Equal(t, "req-04", outputs[4].Description)
+}
+
+// outlineGenExecutor returns a scenario outline criterion, with an examples
+// table only when withExamples is set.
+type outlineGenExecutor struct {
+	*MockTaskExecutor
+	withExamples bool
+}
+
+func (e *outlineGenExecutor) ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error) {
+	outline := tasks.AcceptanceCriterionJSON{Type: "scenario_outline", Given: "a user with role <role>", When: "they open the admin page", Then: "the status is <status>"}
+	if e.withExamples {
+		outline.Examples = &schema.ExamplesTable{Columns: []string{"role", "status"}, Rows: [][]string{{"admin", "200"}}}
+	}
+	return &tasks.RequirementGenOutput{Description: input.BriefDescription, Priority: "medium", AcceptanceCriteria: []tasks.AcceptanceCriterionJSON{outline}}, nil
+}
+
+func TestGenerateAll_RejectsOutlineWithoutExamples(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	exec := &outlineGenExecutor{MockTaskExecutor: NewMockTaskExecutor()}
+	orch := NewOrchestrator(exec, repo)
+	orch.SetGenerationOptions(GenerationOptions{OnFailure: FailurePolicyKeep})
+
+	outputs, failures, err := orch.generateAll(context.Background(), genInputs(1))
+	require.NoError(t, err)
+	require.Len(t, failures, 1, "the outline must be reported, not dropped")
+	assert.ErrorContains(t, failures[0].Err, "scenario_outline requires examples")
+	assert.Nil(t, outputs[0])
+
+	exec.withExamples = true
+	outputs, failures, err = orch.generateAll(context.Background(), genInputs(1))
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Len(t, outputs[0].AcceptanceCriteria, 1)
+}
+
+func TestGenerateAll_ContextCancelled(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	exec := &slowGenExecutor{
+		MockTaskExecutor: NewMockTaskExecutor(),
+		delay:            func(string) time.Duration { return time.Second },
+	}
+	orch := NewOrchestrator(exec, repo)
+	orch.SetGenerationOptions(GenerationOptions{Concurrency: 2, OnFailure: FailurePolicyKeep})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := orch.generateAll(ctx, genInputs(4))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestOrchestrator_ProcessPrompt_KeepsPartialResults(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	mockExecutor := NewMockTaskExecutor()
+	mockExecutor.RequirementGenErrors = map[string]error{
+		"Task management requirement": errors.New("provider down"),
+	}
+
+	orch := NewOrchestrator(mockExecutor, repo)
+	orch.SetGenerationOptions(GenerationOptions{OnFailure: FailurePolicyKeep})
+
+	newState, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+
+	require.Len(t, newState.GenerationFailures, 1)
+	assert.Equal(t, "TASKS", newState.GenerationFailures[0].Category)
+
+	added := 0
+	for _, evt := range newState.PendingChangelog {
+		if evt.EventType() == "RequirementAdded" {
+			added++
+		}
+	}
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 2, mockExecutor.RequirementGenCalls)
+}
+
+func TestGenerationOptions_Validate(t *testing.T) {
+	assert.NoError(t, GenerationOptions{}.Validate())
+	assert.NoError(t, DefaultGenerationOptions().Validate())
+	assert.Error(t, GenerationOptions{Concurrency: -1}.Validate())
+	assert.Error(t, GenerationOptions{OnFailure: "ignore"}.Validate())
+}
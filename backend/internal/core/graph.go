@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"xdd/pkg/schema"
+)
+
+// Link graph export formats.
+const (
+	GraphDOT     = "dot"
+	GraphMermaid = "mermaid"
+)
+
+// graphLabelLength bounds the description shown on each graph node.
+const graphLabelLength = 40
+
+// LinkGraph renders the requirements that take part in a link, and the
+// links between them, as a Graphviz DOT or Mermaid flowchart. A non-empty
+// only keeps links of that type.
+func LinkGraph(spec *schema.Specification, format string, only schema.LinkType) (string, error) {
+	if only != "" && !only.Valid() {
+		return "", fmt.Errorf("invalid link type: %s", only)
+	}
+
+	present := make(map[string]bool, len(spec.Requirements))
+	for _, req := range spec.Requirements {
+		present[req.ID] = true
+	}
+
+	var edges []schema.LinkEdge
+	linked := make(map[string]bool)
+	for _, edge := range spec.Links() {
+		// Targets may have been filtered out of spec
+		if (only != "" && edge.Type != only) || !present[edge.Target] {
+			continue
+		}
+		edges = append(edges, edge)
+		linked[edge.Source] = true
+		linked[edge.Target] = true
+	}
+
+	// Nodes keep specification order so output is stable
+	var nodes []schema.Requirement
+	for _, req := range spec.Requirements {
+		if linked[req.ID] {
+			nodes = append(nodes, req)
+		}
+	}
+
+	switch format {
+	case GraphDOT:
+		return dotGraph(nodes, edges), nil
+	case GraphMermaid:
+		return mermaidGraph(nodes, edges), nil
+	default:
+		return "", fmt.Errorf("unknown graph format %q (want %s or %s)", format, GraphDOT, GraphMermaid)
+	}
+}
+
+// graphLabel names a node by alias (or ID) and a short description.
+func graphLabel(req schema.Requirement) string {
+	name := req.Alias
+	if name == "" {
+		name = req.ID
+	}
+	return name + ": " + truncate(req.Description, graphLabelLength)
+}
+
+// dotEdgeStyles draws the link types that are not plain dependencies apart.
+var dotEdgeStyles = map[schema.LinkType]string{
+	schema.LinkRefines:       ", style=dashed",
+	schema.LinkConflictsWith: ", style=bold, color=red, dir=both",
+	schema.LinkSupersedes:    ", style=dotted",
+}
+
+func dotGraph(nodes []schema.Requirement, edges []schema.LinkEdge) string {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	var b strings.Builder
+	b.WriteString("digraph requirements {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, req := range nodes {
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", quote.Replace(req.ID), quote.Replace(graphLabel(req)))
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(&b, "  \"%s\" -> \"%s\" [label=\"%s\"%s];\n",
+			quote.Replace(edge.Source), quote.Replace(edge.Target), edge.Type, dotEdgeStyles[edge.Type])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaidArrows mirrors dotEdgeStyles.
+var mermaidArrows = map[schema.LinkType]string{
+	schema.LinkDependsOn:     "-->",
+	schema.LinkRefines:       "-.->",
+	schema.LinkConflictsWith: "<==>",
+	schema.LinkSupersedes:    "-.->",
+}
+
+func mermaidGraph(nodes []schema.Requirement, edges []schema.LinkEdge) string {
+	// Requirement IDs may contain dots, so nodes get positional names
+	names := make(map[string]string, len(nodes))
+	quote := strings.NewReplacer(`"`, "#quot;")
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, req := range nodes {
+		names[req.ID] = fmt.Sprintf("n%d", i+1)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", names[req.ID], quote.Replace(graphLabel(req)))
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", names[edge.Source], mermaidArrows[edge.Type], edge.Type, names[edge.Target])
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"testing"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/repository"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// linkedSpec has REQ-B depend on REQ-A and REQ-C supersede REQ-B; REQ-D is unlinked.
+func linkedSpec() *schema.Specification {
+	return &schema.Specification{
+		Metadata:   schema.ProjectMetadata{Name: "App", Version: "0.1.0"},
+		Categories: []schema.Category{{Name: "AUTH"}},
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-aaa", Alias: "AUTH-001", Category: "AUTH", Description: `Users log in with "email"`},
+			{ID: "REQ-AUTH-bbb", Alias: "AUTH-002", Category: "AUTH", Description: "Users reset passwords",
+				Links: []schema.RequirementLink{{Type: schema.LinkDependsOn, Target: "REQ-AUTH-aaa"}}},
+			{ID: "REQ-AUTH-ccc", Category: "AUTH", Description: "Users reset passwords by SMS",
+				Links: []schema.RequirementLink{{Type: schema.LinkSupersedes, Target: "REQ-AUTH-bbb"}}},
+			{ID: "REQ-AUTH-ddd", Alias: "AUTH-004", Category: "AUTH", Description: "Users log out"},
+		},
+	}
+}
+
+func TestLinkGraph_DOT(t *testing.T) {
+	graph, err := LinkGraph(linkedSpec(), GraphDOT, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, `digraph requirements {
+  rankdir=LR;
+  node [shape=box];
+  "REQ-AUTH-aaa" [label="AUTH-001: Users log in with \"email\""];
+  "REQ-AUTH-bbb" [label="AUTH-002: Users reset passwords"];
+  "REQ-AUTH-ccc" [label="REQ-AUTH-ccc: Users reset passwords by SMS"];
+  "REQ-AUTH-bbb" -> "REQ-AUTH-aaa" [label="depends_on"];
+  "REQ-AUTH-ccc" -> "REQ-AUTH-bbb" [label="supersedes", style=dotted];
+}
+`, graph)
+}
+
+func TestLinkGraph_Mermaid(t *testing.T) {
+	graph, err := LinkGraph(linkedSpec(), GraphMermaid, schema.LinkDependsOn)
+	require.NoError(t, err)
+
+	assert.Equal(t, `flowchart LR
+  n1["AUTH-001: Users log in with #quot;email#quot;"]
+  n2["AUTH-002: Users reset passwords"]
+  n2 -->|depends_on| n1
+`, graph)
+}
+
+func TestLinkGraph_Errors(t *testing.T) {
+	_, err := LinkGraph(linkedSpec(), "svg", "")
+	assert.ErrorContains(t, err, "unknown graph format")
+
+	_, err = LinkGraph(linkedSpec(), GraphDOT, "blocks")
+	assert.ErrorContains(t, err, "invalid link type")
+}
+
+func TestBuildChangelog_UnlinksRemovedRequirements(t *testing.T) {
+	spec := linkedSpec()
+	delta := deltaRemoving("REQ-AUTH-aaa", "REQ-AUTH-bbb")
+	categorization := categorizationOf(map[string]string{
+		"Users reset passwords by SMS": "AUTH",
+		"Users log out":                "AUTH",
+	}, "AUTH")
+
+	events := buildChangelog(spec, &tasks.MetadataOutput{}, delta, planCategories(spec, delta, categorization), nil)
+	require.Len(t, events, 4)
+	assert.Equal(t, &schema.RequirementLinkRemoved{
+		EventID_:      events[0].EventID(),
+		RequirementID: "REQ-AUTH-bbb",
+		Link:          schema.RequirementLink{Type: schema.LinkDependsOn, Target: "REQ-AUTH-aaa"},
+		Timestamp_:    events[0].Timestamp(),
+	}, events[0])
+	unlink, ok := events[1].(*schema.RequirementLinkRemoved)
+	require.True(t, ok, "expected RequirementLinkRemoved, got %T", events[1])
+	assert.Equal(t, "REQ-AUTH-ccc", unlink.RequirementID)
+
+	// Replay accepts the deletions once the links are gone
+	replayed, err := repository.ReplayEvents(linkedSpec(), events)
+	require.NoError(t, err)
+	assert.Len(t, replayed.Requirements, 2)
+	assert.Empty(t, replayed.Links())
+
+	state := &SessionState{PendingChangelog: events}
+	notes := state.requirementNotes()
+	assert.Equal(t, []string{"⚠️  REQ-AUTH-bbb depends_on it; that link is removed too"}, notes["REQ-AUTH-aaa"])
+	assert.Contains(t, summarizeProposal(events, nil, nil), "- Unlink REQ-AUTH-ccc supersedes REQ-AUTH-bbb")
+}
+
+func TestBuildChangelog_UnlinksBeforeCategoryRename(t *testing.T) {
+	spec := linkedSpec()
+	delta := deltaRemoving("REQ-AUTH-aaa")
+	categorization := categorizationOf(map[string]string{
+		"Users reset passwords":        "IDENTITY",
+		"Users reset passwords by SMS": "IDENTITY",
+		"Users log out":                "IDENTITY",
+	}, "IDENTITY")
+
+	events := buildChangelog(spec, &tasks.MetadataOutput{}, delta, planCategories(spec, delta, categorization), nil)
+	require.Len(t, events, 3)
+	unlink, ok := events[0].(*schema.RequirementLinkRemoved)
+	require.True(t, ok, "expected RequirementLinkRemoved, got %T", events[0])
+	assert.Equal(t, "REQ-AUTH-bbb", unlink.RequirementID, "unlinked under the ID it has before the rename")
+	rename, ok := events[1].(*schema.CategoryRenamed)
+	require.True(t, ok, "expected CategoryRenamed, got %T", events[1])
+	assert.Equal(t, "REQ-IDENTITY-bbb", rename.RequirementIDs["REQ-AUTH-bbb"])
+
+	replayed, err := repository.ReplayEvents(linkedSpec(), events)
+	require.NoError(t, err)
+	require.Len(t, replayed.Requirements, 3)
+	assert.NotNil(t, replayed.FindRequirement("REQ-IDENTITY-bbb"))
+	assert.Equal(t, []schema.LinkEdge{{Source: "REQ-IDENTITY-ccc", Type: schema.LinkSupersedes, Target: "REQ-IDENTITY-bbb"}}, replayed.Links())
+}
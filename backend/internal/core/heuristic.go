@@ -0,0 +1,665 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/similarity"
+	"xdd/pkg/schema"
+)
+
+// defaultHeuristicCategory is used when a candidate has no usable noun phrase.
+const defaultHeuristicCategory = "GENERAL"
+
+// HeuristicTaskExecutor implements TaskExecutor with rules only, for use
+// without an API key or network. Prompts are split into one requirement per
+// sentence or bullet, EARS types come from leading keywords, categories from
+// noun phrases, and acceptance criteria from templates. The result is a
+// valid, editable spec to refine later with the LLM.
+type HeuristicTaskExecutor struct {
+	mu sync.Mutex
+	// Categories chosen by the last delta call, keyed by brief. Categorization
+	// reuses them because rules cannot recover a category from text alone.
+	assigned map[string]string
+}
+
+// NewHeuristicTaskExecutor creates a TaskExecutor that makes no LLM calls.
+func NewHeuristicTaskExecutor() TaskExecutor {
+	return &HeuristicTaskExecutor{assigned: make(map[string]string)}
+}
+
+func (e *HeuristicTaskExecutor) ExecuteMetadata(ctx context.Context, input *tasks.MetadataInput) (*tasks.MetadataOutput, error) {
+	// Rules can't tell whether a request renames the project; keep what exists
+	if input.Existing != nil && input.Existing.Name != "" {
+		return &tasks.MetadataOutput{
+			Name:        input.Existing.Name,
+			Description: input.Existing.Description,
+			Reasoning:   "Heuristic executor keeps existing metadata",
+		}, nil
+	}
+
+	output := &tasks.MetadataOutput{
+		Name:        "Untitled Project",
+		Description: truncate(strings.TrimSpace(input.UpdateRequest), schema.MetadataDescriptionMax),
+		Reasoning:   "Name taken from the first noun phrase of the request",
+	}
+	if phrases := nounPhrases(input.UpdateRequest); len(phrases) > 0 {
+		output.Name = truncate(titleCase(phrases[0]), schema.MetadataNameMax)
+	}
+	if len(output.Description) < schema.MetadataDescriptionMin {
+		output.Description = "Project specified as: " + output.Description
+	}
+	output.Changed.Name = true
+	output.Changed.Description = true
+	return output, nil
+}
+
+func (e *HeuristicTaskExecutor) ExecuteRequirementsDelta(ctx context.Context, input *tasks.RequirementsDeltaInput) (*tasks.RequirementsDeltaOutput, error) {
+	assigned := make(map[string]string)
+	for _, req := range input.ExistingRequirements {
+		assigned[req.Description] = req.Category
+	}
+	known := make([]string, 0, len(input.ExistingCategories))
+	for _, c := range input.ExistingCategories {
+		known = append(known, c.Name)
+	}
+
+	output := &tasks.RequirementsDeltaOutput{}
+	for i, candidate := range splitCandidates(input.UpdateRequest) {
+		// "Build a task manager" describes the project, which metadata covers
+		if i == 0 && len(input.ExistingRequirements) == 0 && pitchPattern.MatchString(candidate) {
+			continue
+		}
+		if removed := removalTargets(candidate, input.ExistingRequirements); len(removed) > 0 {
+			for _, id := range removed {
+				output.ToRemove = append(output.ToRemove, struct {
+					ID        string `json:"id"`
+					Reasoning string `json:"reasoning"`
+				}{ID: id, Reasoning: fmt.Sprintf("Request asks to remove it: %q", candidate)})
+			}
+			continue
+		}
+
+		category := categoryFor(candidate, known)
+		if !containsFold(known, category) {
+			known = append(known, category)
+		}
+		assigned[candidate] = category
+
+		output.ToAdd = append(output.ToAdd, struct {
+			Category          string `json:"category"`
+			BriefDescription  string `json:"brief_description"`
+			EARSType          string `json:"ears_type"`
+			EstimatedPriority string `json:"estimated_priority"`
+			Reasoning         string `json:"reasoning"`
+		}{
+			Category:          category,
+			BriefDescription:  candidate,
+			EARSType:          string(classifyEARS(candidate)),
+			EstimatedPriority: string(estimatePriority(candidate)),
+			Reasoning:         fmt.Sprintf("Sentence %d of the request", i+1),
+		})
+	}
+
+	e.mu.Lock()
+	e.assigned = assigned
+	e.mu.Unlock()
+
+	return output, nil
+}
+
+func (e *HeuristicTaskExecutor) ExecuteCategorization(ctx context.Context, input *tasks.CategorizationInput) (*tasks.CategorizationOutput, error) {
+	e.mu.Lock()
+	assigned := e.assigned
+	e.mu.Unlock()
+
+	output := &tasks.CategorizationOutput{
+		RequirementMapping: make(map[string]string, len(input.AllRequirementBriefs)),
+		Reasoning:          "Categories derived from noun phrases in each requirement",
+	}
+
+	var known []string
+	counts := make(map[string]int)
+	for _, brief := range input.AllRequirementBriefs {
+		category, ok := assigned[brief]
+		if !ok {
+			category = categoryFor(brief, known)
+		}
+		if counts[category] == 0 {
+			known = append(known, category)
+		}
+		counts[category]++
+		output.RequirementMapping[brief] = category
+	}
+
+	for _, name := range known {
+		output.Categories = append(output.Categories, struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Count       int    `json:"count"`
+		}{
+			Name:        name,
+			Description: fmt.Sprintf("Requirements about %s", strings.ToLower(name)),
+			Count:       counts[name],
+		})
+	}
+	return output, nil
+}
+
+func (e *HeuristicTaskExecutor) ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error) {
+	earsType := schema.EARSType(input.EARSType)
+	if earsType == "" {
+		earsType = classifyEARS(input.BriefDescription)
+	}
+	keyword, condition, response := splitCondition(input.BriefDescription)
+	clause := shallClause(response)
+
+	var description string
+	switch {
+	case condition == "":
+		description = clause
+	case keyword == "if":
+		description = fmt.Sprintf("If %s, then %s", condition, clause)
+	default:
+		description = fmt.Sprintf("%s %s, %s", capitalize(keyword), condition, clause)
+	}
+	description = truncate(capitalize(description)+".", schema.RequirementDescriptionMax)
+
+	given, when := "the system is running", "the described behavior is exercised"
+	switch earsType {
+	case schema.EARSEvent:
+		if condition != "" {
+			when = condition
+		}
+	case schema.EARSState, schema.EARSOptional:
+		if condition != "" {
+			given = condition
+		}
+	}
+
+	priority := schema.Priority(input.EstimatedPriority)
+	switch priority {
+	case schema.PriorityCritical, schema.PriorityHigh, schema.PriorityMedium, schema.PriorityLow:
+	default:
+		priority = schema.PriorityMedium
+	}
+
+	return &tasks.RequirementGenOutput{
+		Description: description,
+		Rationale: truncate(fmt.Sprintf("Derived by rules from the request %q; review and refine.",
+			input.BriefDescription), schema.RequirementRationaleMax),
+		AcceptanceCriteria: []tasks.AcceptanceCriterionJSON{{
+			Type:  "behavioral",
+			Given: truncate(given, schema.GivenWhenThenMax),
+			When:  truncate(when, schema.GivenWhenThenMax),
+			Then:  truncate(clause, schema.GivenWhenThenMax),
+
+			VerificationMethod: string(schema.VerificationTest),
+		}},
+		Priority: string(priority),
+	}, nil
+}
+
+// vagueTerms are words a reader cannot verify, mapped to a suggested fix.
+var vagueTerms = map[string]string{
+	"fast":          "a time limit, e.g. within 2 seconds",
+	"quickly":       "a time limit, e.g. within 2 seconds",
+	"slow":          "a time limit",
+	"easy":          "a measurable usability target",
+	"user-friendly": "a measurable usability target",
+	"intuitive":     "a measurable usability target",
+	"efficient":     "a resource or throughput threshold",
+	"robust":        "the failures it must tolerate",
+	"flexible":      "the specific variations it must support",
+	"adequate":      "a concrete threshold",
+	"appropriate":   "the specific condition",
+	"several":       "an exact number",
+	"some":          "an exact number or list",
+	"etc":           "the complete list",
+}
+
+func (e *HeuristicTaskExecutor) ExecuteReview(ctx context.Context, input *tasks.ReviewInput) (*tasks.ReviewOutput, error) {
+	req := input.Requirement
+	lower := strings.ToLower(req.Description)
+	var findings []tasks.ReviewFinding
+
+	for _, word := range strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	}) {
+		if fix, ok := vagueTerms[word]; ok {
+			findings = append(findings, tasks.ReviewFinding{
+				Attribute:        tasks.QualityUnambiguous,
+				Severity:         "medium",
+				Score:            2,
+				Issue:            fmt.Sprintf("%q is not measurable", word),
+				Excerpt:          word,
+				SuggestedRewrite: fmt.Sprintf("Replace %q with %s", word, fix),
+			})
+		}
+	}
+	if strings.Count(lower, "shall") > 1 || strings.Contains(lower, "and/or") {
+		findings = append(findings, tasks.ReviewFinding{
+			Attribute:        tasks.QualitySingular,
+			Severity:         "medium",
+			Score:            2,
+			Issue:            "States more than one requirement",
+			SuggestedRewrite: "Split into one requirement per 'shall'",
+		})
+	}
+	if !strings.Contains(lower, "shall") {
+		findings = append(findings, tasks.ReviewFinding{
+			Attribute:        tasks.QualityConforming,
+			Severity:         "low",
+			Score:            3,
+			Issue:            "Does not use 'shall'",
+			SuggestedRewrite: "Rephrase as '... the system shall ...'",
+		})
+	}
+	if len(req.AcceptanceCriteria) == 0 {
+		findings = append(findings, tasks.ReviewFinding{
+			Attribute:        tasks.QualityVerifiable,
+			Severity:         "high",
+			Score:            1,
+			Issue:            "Has no acceptance criteria",
+			SuggestedRewrite: "Add at least one Given/When/Then criterion",
+		})
+	}
+
+	penalty := map[string]int{"high": 25, "medium": 15, "low": 5}
+	output := &tasks.ReviewOutput{Score: 100, Findings: findings, Summary: "No rule-based issues found"}
+	for _, f := range findings {
+		output.Score -= penalty[f.Severity]
+	}
+	output.Score = max(output.Score, 0)
+	if len(findings) > 0 {
+		output.Summary = fmt.Sprintf("%d rule-based finding(s)", len(findings))
+	}
+	return output, nil
+}
+
+// negationPattern marks a requirement that forbids rather than requires.
+var negationPattern = regexp.MustCompile(`\b(shall not|must not|should not|cannot|never|prohibit\w*)\b`)
+
+func (e *HeuristicTaskExecutor) ExecuteConflictAnalysis(ctx context.Context, input *tasks.ConflictInput) (*tasks.ConflictOutput, error) {
+	// Rules only spot near-identical requirements where one negates the other.
+	// They are never critical: a heuristic should not block a commit.
+	output := &tasks.ConflictOutput{}
+	candidates := append(append([]schema.Requirement(nil), input.NewRequirements...), input.ExistingRequirements...)
+	idx := requirementIndex(candidates)
+
+	seen := make(map[[2]string]bool)
+	for _, req := range input.NewRequirements {
+		negated := negationPattern.MatchString(strings.ToLower(req.Description))
+		for _, match := range idx.Query(req.Description, similarity.DefaultThreshold) {
+			pair := [2]string{min(req.ID, match.ID), max(req.ID, match.ID)}
+			if match.ID == req.ID || seen[pair] {
+				continue
+			}
+			other := requirementByID(candidates, match.ID)
+			if negationPattern.MatchString(strings.ToLower(other.Description)) == negated {
+				continue
+			}
+			seen[pair] = true
+			output.Conflicts = append(output.Conflicts, tasks.Conflict{
+				RequirementA: req.ID,
+				RequirementB: other.ID,
+				Severity:     tasks.ConflictMajor,
+				Explanation:  fmt.Sprintf("Nearly identical (%.0f%% similar) but one forbids what the other requires", match.Score*100),
+			})
+		}
+	}
+	return output, nil
+}
+
+func (e *HeuristicTaskExecutor) ExecuteVersionBump(ctx context.Context, input *tasks.VersionBumpInput) (*tasks.VersionBumpOutput, error) {
+	reasoning := "No requirement changes"
+	if len(input.Rules) > 0 {
+		reasoning = "Versioning policy: " + strings.Join(input.Rules, "; ")
+	}
+	return &tasks.VersionBumpOutput{
+		NewVersion: input.NewVersion,
+		BumpType:   input.BumpType,
+		Reasoning:  reasoning,
+	}, nil
+}
+
+// bulletPattern matches list markers such as "-", "*", "•", "1." and "2)".
+var bulletPattern = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)])\s+`)
+
+// abbreviations end with a period but do not end a sentence.
+var abbreviations = []string{"e.g.", "i.e.", "etc.", "vs.", "approx."}
+
+// splitCandidates splits a request into one candidate requirement per bullet
+// or sentence. Headings ending in a colon are dropped.
+func splitCandidates(text string) []string {
+	var candidates []string
+	var paragraph []string
+
+	flush := func() {
+		candidates = append(candidates, splitSentences(strings.Join(paragraph, " "))...)
+		paragraph = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case bulletPattern.MatchString(line):
+			flush()
+			candidates = append(candidates, splitSentences(bulletPattern.ReplaceAllString(line, ""))...)
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	kept := candidates[:0]
+	for _, c := range candidates {
+		if c != "" && !strings.HasSuffix(c, ":") {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// splitSentences splits text at '.', '!', '?' and ';' followed by a space.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i, r := range text {
+		if !strings.ContainsRune(".!?;", r) || (i+1 < len(text) && text[i+1] != ' ') {
+			continue
+		}
+		if r == '.' && endsWithAbbreviation(text[:i+1]) {
+			continue
+		}
+		sentences = append(sentences, strings.TrimSpace(strings.TrimRight(text[start:i+1], ".!?; ")))
+		start = i + 1
+	}
+	sentences = append(sentences, strings.TrimSpace(strings.TrimRight(text[start:], ".!?; ")))
+	return sentences
+}
+
+func endsWithAbbreviation(text string) bool {
+	lower := strings.ToLower(text)
+	for _, abbr := range abbreviations {
+		if strings.HasSuffix(lower, abbr) {
+			return true
+		}
+	}
+	return false
+}
+
+// pitchPattern matches an opening sentence that names the project rather
+// than a requirement.
+var pitchPattern = regexp.MustCompile(`(?i)^(build|create|make|develop|design|write)\s+(a|an)\s`)
+
+// removalVerbs mark a candidate that removes the requirements it names.
+var removalVerbs = regexp.MustCompile(`(?i)\b(remove|delete|drop|deprecate|retire)\b`)
+
+// removalTargets returns the existing requirement IDs a removal candidate names.
+func removalTargets(candidate string, existing []schema.Requirement) []string {
+	if !removalVerbs.MatchString(candidate) {
+		return nil
+	}
+	var ids []string
+	for _, req := range existing {
+		if req.MentionedIn(candidate) {
+			ids = append(ids, req.ID)
+		}
+	}
+	return ids
+}
+
+// earsKeywords maps leading keywords to EARS types. "If" introduces unwanted
+// behavior, which this schema records as an event.
+var earsKeywords = map[string]schema.EARSType{
+	"when":   schema.EARSEvent,
+	"if":     schema.EARSEvent,
+	"once":   schema.EARSEvent,
+	"after":  schema.EARSEvent,
+	"while":  schema.EARSState,
+	"during": schema.EARSState,
+	"where":  schema.EARSOptional,
+}
+
+// classifyEARS picks an EARS type from the candidate's condition keyword.
+func classifyEARS(candidate string) schema.EARSType {
+	if keyword, _, _ := splitCondition(candidate); keyword != "" {
+		return earsKeywords[keyword]
+	}
+	return schema.EARSUbiquitous
+}
+
+// splitCondition separates a leading "When X, Y" or trailing "Y when X"
+// condition from the response. keyword is empty when there is none.
+func splitCondition(candidate string) (keyword, condition, response string) {
+	text := strings.TrimRight(strings.TrimSpace(candidate), ".!?; ")
+	lower := strings.ToLower(text)
+
+	first, _, _ := strings.Cut(lower, " ")
+	if _, ok := earsKeywords[first]; ok {
+		rest := strings.TrimSpace(text[len(first):])
+		if cond, resp, found := strings.Cut(rest, ","); found {
+			resp = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(resp), "then "))
+			return first, strings.TrimSpace(cond), resp
+		}
+		return first, rest, ""
+	}
+
+	// "Where" mid-sentence is usually a relative pronoun, not a feature condition
+	for _, kw := range []string{"when", "if", "while"} {
+		if i := strings.Index(lower, " "+kw+" "); i > 0 {
+			return kw, strings.TrimSpace(text[i+len(kw)+2:]), strings.TrimRight(text[:i], ", ")
+		}
+	}
+	return "", "", text
+}
+
+var (
+	modals = map[string]bool{"shall": true, "must": true, "should": true, "will": true, "can": true, "may": true}
+	// systemSubjects are subjects that already mean "the system"
+	systemSubjects = map[string]bool{"": true, "the system": true, "system": true, "it": true, "the app": true, "the application": true, "we": true}
+	// actionVerbs can follow "the system shall" directly
+	actionVerbs = map[string]bool{
+		"accept": true, "alert": true, "allow": true, "archive": true, "ask": true, "block": true,
+		"calculate": true, "check": true, "create": true, "delete": true, "deny": true, "display": true,
+		"email": true, "enable": true, "encrypt": true, "expire": true, "export": true, "filter": true,
+		"generate": true, "hide": true, "import": true, "let": true, "limit": true, "list": true,
+		"lock": true, "log": true, "notify": true, "prevent": true, "prompt": true, "provide": true,
+		"record": true, "redirect": true, "reject": true, "remind": true, "remove": true, "require": true,
+		"restore": true, "retry": true, "return": true, "save": true, "schedule": true, "search": true,
+		"send": true, "show": true, "sort": true, "store": true, "support": true, "sync": true,
+		"track": true, "update": true, "validate": true, "verify": true, "warn": true,
+	}
+)
+
+// shallClause rewrites a response as "<subject> shall <action>", e.g.
+// "users can export reports" -> "the system shall allow users to export reports".
+func shallClause(response string) string {
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return "the system shall perform the described behavior"
+	}
+	words := strings.Fields(response)
+
+	for i, w := range words {
+		modal := strings.ToLower(w)
+		if !modals[modal] || i == len(words)-1 {
+			continue
+		}
+		subject := strings.ToLower(strings.Join(words[:i], " "))
+		rest := strings.Join(words[i+1:], " ")
+		switch {
+		case systemSubjects[subject]:
+			return "the system shall " + rest
+		case modal == "can" || modal == "may":
+			rest = strings.TrimPrefix(rest, "be able to ")
+			return fmt.Sprintf("the system shall allow %s to %s", subject, rest)
+		default:
+			return subject + " shall " + rest
+		}
+	}
+
+	if actionVerbs[strings.ToLower(words[0])] {
+		return "the system shall " + lowerFirst(response)
+	}
+	return "the system shall support " + lowerFirst(response)
+}
+
+// priorityKeywords raise or lower a candidate's estimated priority.
+var priorityKeywords = []struct {
+	pattern  *regexp.Regexp
+	priority schema.Priority
+}{
+	{regexp.MustCompile(`(?i)\bcritical\b`), schema.PriorityCritical},
+	{regexp.MustCompile(`(?i)\b(must|security|never|always)\b`), schema.PriorityHigh},
+	{regexp.MustCompile(`(?i)\b(optional(ly)?|nice to have|could|eventually|later)\b`), schema.PriorityLow},
+}
+
+func estimatePriority(candidate string) schema.Priority {
+	for _, kw := range priorityKeywords {
+		if kw.pattern.MatchString(candidate) {
+			return kw.priority
+		}
+	}
+	return schema.PriorityMedium
+}
+
+// phraseBreaks end a noun phrase: function words, modals, common verbs and
+// nouns too generic to name a category.
+var phraseBreaks = func() map[string]bool {
+	words := strings.Fields(`a an the this that these those each every all any some no
+		of for to with on in at by from into onto about over under via as per than
+		and or but nor so then if when while where once after during until before
+		is are was were be been being has have had do does did
+		shall must should will can may might could would need needs
+		it its they them their he she his her we our you your i my
+		not only also always never just very more most less least
+		build create make add allow let enable support provide want like
+		system app application user users service platform software tool`)
+	breaks := make(map[string]bool, len(words)+len(actionVerbs))
+	for _, w := range words {
+		breaks[w] = true
+	}
+	for w := range actionVerbs {
+		breaks[w] = true
+	}
+	return breaks
+}()
+
+// nounPhrases returns runs of content words in text, in order.
+func nounPhrases(text string) []string {
+	var phrases []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			phrases = append(phrases, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}) {
+		if phraseBreaks[word] || len(word) < 3 {
+			flush()
+			continue
+		}
+		current = append(current, word)
+	}
+	flush()
+	return phrases
+}
+
+// categoryFor reuses a known category whose name appears in candidate, or
+// names a new one after the head noun of the candidate's first noun phrase.
+func categoryFor(candidate string, known []string) string {
+	terms := make(map[string]bool)
+	for _, t := range similarity.Terms(candidate) {
+		terms[t] = true
+	}
+	for _, cat := range known {
+		if name := similarity.Terms(cat); len(name) == 1 && terms[name[0]] {
+			return cat
+		}
+	}
+
+	for _, phrase := range nounPhrases(candidate) {
+		words := strings.Fields(phrase)
+		if name := categoryName(words[len(words)-1]); name != "" {
+			return name
+		}
+	}
+	return defaultHeuristicCategory
+}
+
+// categoryName turns a noun into a category name: singular, upper case,
+// ASCII letters and digits only, at most schema.CategoryNameMax characters.
+func categoryName(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "ies") && len(noun) > 4:
+		noun = strings.TrimSuffix(noun, "ies") + "y"
+	case strings.HasSuffix(noun, "s") && len(noun) > 3 &&
+		!strings.HasSuffix(noun, "ss") && !strings.HasSuffix(noun, "us") && !strings.HasSuffix(noun, "is"):
+		noun = strings.TrimSuffix(noun, "s")
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, noun)
+	if len(name) > schema.CategoryNameMax {
+		name = name[:schema.CategoryNameMax]
+	}
+	return name
+}
+
+func requirementByID(reqs []schema.Requirement, id string) schema.Requirement {
+	for _, req := range reqs {
+		if req.ID == id {
+			return req
+		}
+	}
+	return schema.Requirement{}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// lowerFirst lowercases the first letter unless the word is an acronym.
+func lowerFirst(s string) string {
+	if len(s) > 1 && unicode.IsUpper(rune(s[1])) {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
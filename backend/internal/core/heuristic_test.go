@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCandidates(t *testing.T) {
+	request := `Build a task manager. Users can create tasks, e.g. with a due date!
+Requirements:
+- When a task is due, notify the owner
+* While offline, queue changes
+2) Dark mode`
+
+	assert.Equal(t, []string{
+		"Build a task manager",
+		"Users can create tasks, e.g. with a due date",
+		"When a task is due, notify the owner",
+		"While offline, queue changes",
+		"Dark mode",
+	}, splitCandidates(request))
+}
+
+func TestClassifyEARS(t *testing.T) {
+	tests := map[string]schema.EARSType{
+		"When a task is due, notify the owner":       schema.EARSEvent,
+		"If the password is wrong, lock the account": schema.EARSEvent,
+		"Send a reminder when a task is due":         schema.EARSEvent,
+		"While offline, queue changes":               schema.EARSState,
+		"Where SSO is enabled, skip the login form":  schema.EARSOptional,
+		"Users can create tasks":                     schema.EARSUbiquitous,
+		"Show the page where tasks are listed":       schema.EARSUbiquitous,
+	}
+	for candidate, want := range tests {
+		assert.Equal(t, want, classifyEARS(candidate), candidate)
+	}
+}
+
+func TestShallClause(t *testing.T) {
+	tests := map[string]string{
+		"Users can create tasks":            "the system shall allow users to create tasks",
+		"users should be able to log out":   "users shall be able to log out",
+		"The system must encrypt passwords": "the system shall encrypt passwords",
+		"notify the owner":                  "the system shall notify the owner",
+		"Dark mode":                         "the system shall support dark mode",
+		"SSO login":                         "the system shall support SSO login",
+		"Passwords must be hashed":          "passwords shall be hashed",
+	}
+	for response, want := range tests {
+		assert.Equal(t, want, shallClause(response), response)
+	}
+}
+
+func TestCategoryFor(t *testing.T) {
+	assert.Equal(t, "TASK", categoryFor("Users can create tasks with a due date", nil))
+	assert.Equal(t, "REPORT", categoryFor("Users can export reports as CSV", nil))
+	assert.Equal(t, "TASK", categoryFor("When a task is due, notify the owner", []string{"TASK"}),
+		"known categories are reused")
+	assert.Equal(t, defaultHeuristicCategory, categoryFor("It must be so", nil))
+}
+
+func TestHeuristicTaskExecutor_ProcessPrompt(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	orch := NewOrchestrator(NewHeuristicTaskExecutor(), repo)
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), `Build a task manager.
+- Users can create tasks with a due date
+- When a task is due, notify the owner by email
+- While offline, the app must queue changes`)
+	require.NoError(t, err)
+
+	var added []schema.Requirement
+	categories := map[string]bool{}
+	for _, event := range state.PendingChangelog {
+		switch e := event.(type) {
+		case *schema.RequirementAdded:
+			added = append(added, e.Requirement)
+		case *schema.CategoryAdded:
+			categories[e.Name] = true
+		case *schema.ProjectMetadataUpdated:
+			assert.Equal(t, "Task Manager", e.NewMetadata.Name)
+		case *schema.VersionBumped:
+			assert.Equal(t, "0.1.0", e.NewVersion)
+		}
+	}
+
+	require.Len(t, added, 3, "the opening pitch names the project")
+	for _, req := range added {
+		assert.NoError(t, schema.ValidateRequirement(&req), req.Description)
+		assert.True(t, categories[req.Category], "category %s should be added", req.Category)
+	}
+
+	assert.Equal(t, "The system shall allow users to create tasks with a due date.", added[0].Description)
+	assert.Equal(t, "TASK", added[0].Category)
+	assert.Equal(t, "When a task is due, the system shall notify the owner by email.", added[1].Description)
+	assert.Equal(t, schema.EARSEvent, added[1].Type)
+	assert.Equal(t, "TASK", added[1].Category)
+	assert.Equal(t, "While offline, the system shall queue changes.", added[2].Description)
+	assert.Equal(t, schema.EARSState, added[2].Type)
+	assert.Equal(t, schema.PriorityHigh, added[2].Priority)
+
+	given := added[2].AcceptanceCriteria[0].(*schema.BehavioralCriterion)
+	assert.Equal(t, "offline", given.Given)
+	assert.Equal(t, "the system shall queue changes", given.Then)
+}
+
+func TestHeuristicTaskExecutor_Removal(t *testing.T) {
+	e := NewHeuristicTaskExecutor()
+	out, err := e.ExecuteRequirementsDelta(context.Background(), &tasks.RequirementsDeltaInput{
+		ExistingRequirements: []schema.Requirement{{ID: "REQ-TASK-abc", Category: "TASK", Description: "Tasks can be archived"}},
+		ExistingCategories:   []schema.Category{{Name: "TASK"}},
+		UpdateRequest:        "Remove req-task-abc. Tasks can be starred.",
+	})
+	require.NoError(t, err)
+	require.Len(t, out.ToRemove, 1)
+	assert.Equal(t, "REQ-TASK-abc", out.ToRemove[0].ID)
+	require.Len(t, out.ToAdd, 1)
+	assert.Equal(t, "TASK", out.ToAdd[0].Category)
+
+	// Categorization keeps the delta's choices instead of inventing new ones
+	cat, err := e.ExecuteCategorization(context.Background(), &tasks.CategorizationInput{
+		AllRequirementBriefs: []string{"Tasks can be archived", "Tasks can be starred"},
+	})
+	require.NoError(t, err)
+	require.Len(t, cat.Categories, 1)
+	assert.Equal(t, "TASK", cat.Categories[0].Name)
+	assert.Equal(t, 2, cat.Categories[0].Count)
+}
+
+func TestHeuristicTaskExecutor_Review(t *testing.T) {
+	e := NewHeuristicTaskExecutor()
+
+	out, err := e.ExecuteReview(context.Background(), &tasks.ReviewInput{Requirement: schema.Requirement{
+		Description: "The system shall be fast and the system shall be user-friendly",
+	}})
+	require.NoError(t, err)
+
+	attributes := map[string]int{}
+	for _, f := range out.Findings {
+		attributes[f.Attribute]++
+	}
+	assert.Equal(t, 2, attributes[tasks.QualityUnambiguous])
+	assert.Equal(t, 1, attributes[tasks.QualitySingular])
+	assert.Equal(t, 1, attributes[tasks.QualityVerifiable])
+	assert.Equal(t, 100-15-15-15-25, out.Score)
+}
+
+func TestHeuristicTaskExecutor_Conflicts(t *testing.T) {
+	e := NewHeuristicTaskExecutor()
+	out, err := e.ExecuteConflictAnalysis(context.Background(), &tasks.ConflictInput{
+		NewRequirements: []schema.Requirement{
+			{ID: "REQ-NEW", Description: "The system shall not store passwords in plain text logs"},
+		},
+		ExistingRequirements: []schema.Requirement{
+			{ID: "REQ-OLD", Description: "The system shall store passwords in plain text logs"},
+			{ID: "REQ-OTHER", Description: "The system shall export reports as CSV"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, out.Conflicts, 1)
+	assert.Equal(t, "REQ-NEW", out.Conflicts[0].RequirementA)
+	assert.Equal(t, "REQ-OLD", out.Conflicts[0].RequirementB)
+	assert.Equal(t, tasks.ConflictMajor, out.Conflicts[0].Severity, "heuristics never block commits")
+}
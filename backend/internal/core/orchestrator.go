@@ -3,44 +3,82 @@ package core
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"xdd/internal/llm"
 	"xdd/internal/llm/tasks"
 	"xdd/internal/repository"
+	"xdd/internal/telemetry"
+	"xdd/internal/versioning"
 	"xdd/pkg/schema"
 )
 
 // Orchestrator executes the 5-task LLM pipeline.
 type Orchestrator struct {
-	executor TaskExecutor
-	repo     *repository.Repository
+	executor   TaskExecutor
+	repo       *repository.Repository
+	generation GenerationOptions
+	versioning versioning.Policy
 }
 
 // NewOrchestrator creates a new orchestrator with a TaskExecutor.
 func NewOrchestrator(executor TaskExecutor, repo *repository.Repository) *Orchestrator {
 	return &Orchestrator{
-		executor: executor,
-		repo:     repo,
+		executor:   withTracing(executor),
+		repo:       repo,
+		generation: DefaultGenerationOptions(),
+		versioning: versioning.DefaultPolicy(),
 	}
 }
 
 // NewOrchestratorWithLLMClient creates an orchestrator with a real LLM client (legacy constructor).
 func NewOrchestratorWithLLMClient(llmClient *llm.Client, repo *repository.Repository) *Orchestrator {
 	return &Orchestrator{
-		executor: NewRealTaskExecutor(llmClient),
-		repo:     repo,
+		executor:   withTracing(NewRealTaskExecutor(llmClient)),
+		repo:       repo,
+		generation: DefaultGenerationOptions(),
+		versioning: versioning.DefaultPolicy(),
 	}
 }
 
+// SetGenerationOptions configures parallelism and the partial-failure policy
+// for requirement generation.
+func (o *Orchestrator) SetGenerationOptions(opts GenerationOptions) {
+	o.generation = opts
+}
+
+// SetVersionPolicy configures which bump each kind of change requires.
+func (o *Orchestrator) SetVersionPolicy(policy versioning.Policy) {
+	o.versioning = policy
+}
+
 // ProcessPrompt executes the full LLM pipeline for a user prompt.
 func (o *Orchestrator) ProcessPrompt(
 	ctx context.Context,
 	state *SessionState,
 	prompt string,
+) (*SessionState, error) {
+	ctx, span := telemetry.Start(ctx, "ProcessPrompt")
+	newState, err := o.processPrompt(ctx, state, prompt)
+	if err == nil {
+		recordProposal(span, newState)
+	}
+	telemetry.End(span, err)
+	return newState, err
+}
+
+func (o *Orchestrator) processPrompt(
+	ctx context.Context,
+	state *SessionState,
+	prompt string,
 ) (*SessionState, error) {
 	newState := state.Clone()
 
+	// Earlier turns (prompts, proposals, feedback) go to every task as conversation context
+	ctx = llm.WithConversation(ctx, conversationHistory(state.Messages))
+	newState.AddMessage("user", prompt)
+
 	// Load current specification
 	spec, err := o.repo.ReadSpecification()
 	if err != nil {
@@ -64,20 +102,32 @@ func (o *Orchestrator) ProcessPrompt(
 		ExistingRequirements: spec.Requirements,
 		ExistingCategories:   spec.Categories,
 		UpdateRequest:        prompt,
+		Resolutions:          state.Resolutions,
 	}
 
 	deltaOutput, err := o.executor.ExecuteRequirementsDelta(ctx, deltaInput)
 	if err != nil {
 		return nil, fmt.Errorf("requirements delta task: %w", err)
 	}
+	// The model may answer with aliases users mentioned
+	for i, rem := range deltaOutput.ToRemove {
+		deltaOutput.ToRemove[i].ID, _ = spec.ResolveRequirementID(rem.ID)
+	}
 
-	// Check for ambiguous modifications
+	// Ask about every ambiguous modification at once; ResolveAmbiguities re-runs the prompt
 	if len(deltaOutput.AmbiguousModifications) > 0 {
+		newState.PendingAmbiguities = newAmbiguities(deltaOutput, spec)
+		newState.PendingRequest = prompt
 		newState.AwaitingFeedback = true
-		newState.AddMessage("assistant", deltaOutput.AmbiguousModifications[0].Clarification)
+		newState.AddMessage("assistant", formatAmbiguities(newState.PendingAmbiguities))
 		return newState, nil
 	}
 
+	// Flag briefs restating existing requirements so the preview can offer to skip them
+	opts := o.generation
+	opts.SetDefaults()
+	duplicates := findDuplicates(spec.Requirements, deltaOutput, opts.DuplicateThreshold)
+
 	// 3. Categorization Task
 	allBriefs := []string{}
 	for _, req := range spec.Requirements {
@@ -91,18 +141,20 @@ func (o *Orchestrator) ProcessPrompt(
 		ProjectName:          metadataOutput.Name,
 		ProjectDescription:   metadataOutput.Description,
 		AllRequirementBriefs: allBriefs,
+		ExistingCategories:   spec.Categories,
 	}
 
 	catOutput, err := o.executor.ExecuteCategorization(ctx, catInput)
 	if err != nil {
 		return nil, fmt.Errorf("categorization task: %w", err)
 	}
+	categories := planCategories(spec, deltaOutput, catOutput)
 
-	// 4. Requirement Generation (sequential for simplicity)
-	newRequirements := []schema.Requirement{}
+	// 4. Requirement Generation (fanned out, results kept in ToAdd order)
+	genInputs := make([]*tasks.RequirementGenInput, 0, len(deltaOutput.ToAdd))
 	for _, add := range deltaOutput.ToAdd {
-		reqInput := &tasks.RequirementGenInput{
-			Category:          add.Category,
+		genInputs = append(genInputs, &tasks.RequirementGenInput{
+			Category:          categories.Resolve(add.Category),
 			EARSType:          add.EARSType,
 			BriefDescription:  add.BriefDescription,
 			EstimatedPriority: add.EstimatedPriority,
@@ -112,83 +164,251 @@ func (o *Orchestrator) ProcessPrompt(
 				ExistingRequirements: spec.Requirements,
 				UpdateRequest:        prompt,
 			},
-		}
+		})
+	}
 
-		reqOutput, err := o.executor.ExecuteRequirementGen(ctx, reqInput)
-		if err != nil {
-			return nil, fmt.Errorf("requirement generation: %w", err)
+	genOutputs, genFailures, err := o.generateAll(ctx, genInputs)
+	if err != nil {
+		return nil, fmt.Errorf("requirement generation: %w", err)
+	}
+	newState.GenerationFailures = genFailures
+	newState.PossibleDuplicates = nil
+
+	newRequirements := []schema.Requirement{}
+	for i, add := range deltaOutput.ToAdd {
+		reqOutput := genOutputs[i]
+		if reqOutput == nil {
+			continue // Failed, reported via GenerationFailures
 		}
 
 		// Convert AcceptanceCriterionJSON to AcceptanceCriterion
 		criteria := make([]schema.AcceptanceCriterion, 0, len(reqOutput.AcceptanceCriteria))
 		for _, acJSON := range reqOutput.AcceptanceCriteria {
+			verification := schema.Verification{
+				Method: schema.VerificationMethod(acJSON.VerificationMethod),
+				Ref:    acJSON.VerificationRef,
+			}
 			switch acJSON.Type {
 			case "behavioral":
 				acID, _ := schema.NewAcceptanceCriterionID()
 				criteria = append(criteria, &schema.BehavioralCriterion{
-					ID:        acID,
-					Type:      "behavioral",
-					Given:     acJSON.Given,
-					When:      acJSON.When,
-					Then:      acJSON.Then,
-					CreatedAt: time.Now(),
+					ID:           acID,
+					Type:         "behavioral",
+					Given:        acJSON.Given,
+					When:         acJSON.When,
+					Then:         acJSON.Then,
+					Verification: verification,
+					CreatedAt:    time.Now(),
 				})
 			case "assertion":
 				acID, _ := schema.NewAcceptanceCriterionID()
 				criteria = append(criteria, &schema.AssertionCriterion{
-					ID:        acID,
-					Type:      "assertion",
-					Statement: acJSON.Statement,
-					CreatedAt: time.Now(),
+					ID:           acID,
+					Type:         "assertion",
+					Statement:    acJSON.Statement,
+					Verification: verification,
+					CreatedAt:    time.Now(),
+				})
+			case "metric":
+				acID, _ := schema.NewAcceptanceCriterionID()
+				criteria = append(criteria, &schema.MetricCriterion{
+					ID:           acID,
+					Type:         "metric",
+					Measure:      acJSON.Measure,
+					Comparator:   acJSON.Comparator,
+					Threshold:    acJSON.Threshold,
+					Unit:         acJSON.Unit,
+					Conditions:   acJSON.Conditions,
+					Verification: verification,
+					CreatedAt:    time.Now(),
+				})
+			case "scenario_outline": // Examples checked by generateAll
+				acID, _ := schema.NewAcceptanceCriterionID()
+				criteria = append(criteria, &schema.ScenarioOutlineCriterion{
+					ID:           acID,
+					Type:         "scenario_outline",
+					Given:        acJSON.Given,
+					When:         acJSON.When,
+					Then:         acJSON.Then,
+					Examples:     *acJSON.Examples,
+					Verification: verification,
+					CreatedAt:    time.Now(),
 				})
 			}
 		}
 
-		reqID, _ := schema.NewRequirementID(add.Category)
+		category := categories.Resolve(add.Category)
+		reqID, _ := schema.NewRequirementID(category)
 		req := schema.Requirement{
 			ID:                 reqID,
 			Type:               schema.EARSType(add.EARSType),
-			Category:           add.Category,
+			Category:           category,
 			Description:        reqOutput.Description,
 			Rationale:          reqOutput.Rationale,
 			AcceptanceCriteria: criteria,
 			Priority:           schema.Priority(reqOutput.Priority),
+			Status:             schema.StatusDraft,
 			CreatedAt:          time.Now(),
 		}
 
 		newRequirements = append(newRequirements, req)
+
+		if dup := duplicates[i]; dup != nil {
+			dup.RequirementID = reqID
+			newState.PossibleDuplicates = append(newState.PossibleDuplicates, *dup)
+		}
+	}
+
+	// Quality review runs before the preview so findings show next to each addition
+	newState.Reviews = nil
+	if !opts.SkipReview {
+		newState.Reviews = o.ReviewRequirements(ctx, newRequirements)
+	}
+
+	// Critical conflicts block commit until resolved or overridden
+	newState.Conflicts = nil
+	if !opts.SkipConflicts {
+		newState.Conflicts, err = o.detectConflicts(ctx, spec, deltaOutput, newRequirements)
+		if err != nil {
+			return nil, fmt.Errorf("conflict analysis task: %w", err)
+		}
+	}
+
+	// Build changelog events
+	events := buildChangelog(
+		spec,
+		metadataOutput,
+		deltaOutput,
+		categories,
+		newRequirements,
+	)
+
+	// 5. Version Bump Task: rules pick the version, the model explains it
+	bump, err := versioning.Compute(o.versioning, spec.Metadata.Version, events)
+	if err != nil {
+		return nil, fmt.Errorf("version rules: %w", err)
 	}
 
-	// 5. Version Bump Task
 	versionInput := &tasks.VersionBumpInput{
 		CurrentVersion: spec.Metadata.Version,
+		NewVersion:     bump.NewVersion,
+		BumpType:       string(bump.Type),
+		Rules:          bump.Rules,
 		Changes: tasks.VersionChanges{
-			RequirementsAdded:   len(deltaOutput.ToAdd),
+			RequirementsAdded:   len(newRequirements),
 			RequirementsRemoved: len(deltaOutput.ToRemove),
 			MetadataChanged:     metadataOutput.Changed.Name || metadataOutput.Changed.Description,
 		},
-		ChangeDescriptions: buildChangeDescriptions(metadataOutput, deltaOutput, newRequirements),
+		ChangeDescriptions: append(buildChangeDescriptions(metadataOutput, deltaOutput, newRequirements), categories.describe()...),
 	}
 
 	versionOutput, err := o.executor.ExecuteVersionBump(ctx, versionInput)
 	if err != nil {
 		return nil, fmt.Errorf("version bump task: %w", err)
 	}
+	if versionOutput.BumpType != versionInput.BumpType || versionOutput.NewVersion != versionInput.NewVersion {
+		return nil, fmt.Errorf("version bump task proposed %s (%s), but the changes require %s (%s)",
+			versionOutput.NewVersion, versionOutput.BumpType, versionInput.NewVersion, versionInput.BumpType)
+	}
 
-	// Build changelog events
-	newState.PendingChangelog = buildChangelog(
-		spec,
-		metadataOutput,
-		deltaOutput,
-		catOutput,
-		newRequirements,
-		versionOutput,
-	)
+	newState.PendingChangelog = appendVersionBump(events, spec.Metadata.Version, versionOutput)
+
+	if hasher, ok := o.executor.(PromptHasher); ok {
+		newState.PromptHashes = hasher.PromptHashes()
+	}
+
+	newState.AddMessage("assistant", summarizeProposal(newState.PendingChangelog, genFailures, newState.Conflicts))
 
 	newState.AwaitingFeedback = false
 	return newState, nil
 }
 
+// ResolveAmbiguities binds answers (one per pending ambiguity, in order) to
+// their targets and re-runs the request that raised them with the
+// resolutions attached.
+func (o *Orchestrator) ResolveAmbiguities(
+	ctx context.Context,
+	state *SessionState,
+	answers []string,
+) (*SessionState, error) {
+	if len(state.PendingAmbiguities) == 0 {
+		return nil, fmt.Errorf("no pending ambiguities to resolve")
+	}
+	if len(answers) != len(state.PendingAmbiguities) {
+		return nil, fmt.Errorf("expected %d answer(s), got %d", len(state.PendingAmbiguities), len(answers))
+	}
+
+	resolved := state.Clone()
+	bound := make([]llm.ResolvedAmbiguity, 0, len(answers))
+	for i, amb := range state.PendingAmbiguities {
+		bound = append(bound, amb.Bind(answers[i]))
+	}
+	resolved.Resolutions = append(resolved.Resolutions, bound...)
+	resolved.AddMessage("user", describeResolutions(bound))
+
+	request := resolved.PendingRequest
+	resolved.PendingAmbiguities = nil
+	resolved.PendingRequest = ""
+	resolved.AwaitingFeedback = false
+
+	return o.ProcessPrompt(ctx, resolved, request)
+}
+
+// conversationHistory converts session messages to LLM conversation turns.
+func conversationHistory(messages []Message) []llm.OpenRouterMsg {
+	history := make([]llm.OpenRouterMsg, 0, len(messages))
+	for _, m := range messages {
+		history = append(history, llm.OpenRouterMsg{Role: m.Role, Content: m.Content})
+	}
+	return history
+}
+
+// summarizeProposal describes a proposed changelog so later turns know what
+// the user is responding to.
+func summarizeProposal(events []schema.ChangelogEvent, failures []GenerationFailure, conflicts []tasks.Conflict) string {
+	lines := []string{"Proposed changes:"}
+	for _, event := range events {
+		switch e := event.(type) {
+		case *schema.RequirementAdded:
+			lines = append(lines, fmt.Sprintf("- Add %s [%s, %s]: %s",
+				e.Requirement.ID, e.Requirement.Category, e.Requirement.Priority, e.Requirement.Description))
+		case *schema.RequirementDeleted:
+			lines = append(lines, fmt.Sprintf("- Remove %s: %s", e.RequirementID, e.Requirement.Description))
+		case *schema.ProjectMetadataUpdated:
+			lines = append(lines, fmt.Sprintf("- Update project metadata: %s", e.NewMetadata.Name))
+		case *schema.CategoryAdded:
+			lines = append(lines, fmt.Sprintf("- Add category %s", e.Name))
+		case *schema.CategoryDeleted:
+			lines = append(lines, fmt.Sprintf("- Delete category %s", e.Name))
+		case *schema.CategoryRenamed:
+			lines = append(lines, fmt.Sprintf("- Rename category %s -> %s", e.OldName, e.NewName))
+		case *schema.CategoryMerged:
+			lines = append(lines, fmt.Sprintf("- Merge category %s into %s", e.Source, e.Target))
+		case *schema.RequirementLinkAdded:
+			lines = append(lines, fmt.Sprintf("- Link %s %s %s", e.RequirementID, e.Link.Type, e.Link.Target))
+		case *schema.RequirementLinkRemoved:
+			lines = append(lines, fmt.Sprintf("- Unlink %s %s %s", e.RequirementID, e.Link.Type, e.Link.Target))
+		case *schema.RequirementStatusChanged:
+			lines = append(lines, fmt.Sprintf("- Mark %s %s (was %s)", e.RequirementID, e.To, e.From))
+		case *schema.RequirementTagsChanged:
+			lines = append(lines, fmt.Sprintf("- Retag %s: +%v -%v", e.RequirementID, e.Added, e.Removed))
+		case *schema.RequirementFieldSet:
+			lines = append(lines, fmt.Sprintf("- Set %s %s = %q", e.RequirementID, e.Name, e.Value))
+		case *schema.AcceptanceCriterionVerificationSet:
+			lines = append(lines, fmt.Sprintf("- Verify %s %s by %q", e.RequirementID, e.CriterionID, e.Verification))
+		case *schema.VersionBumped:
+			lines = append(lines, fmt.Sprintf("- Bump version %s -> %s (%s)", e.OldVersion, e.NewVersion, e.BumpType))
+		}
+	}
+	for _, f := range failures {
+		lines = append(lines, fmt.Sprintf("- Failed to generate: %s", f.BriefDescription))
+	}
+	for _, c := range conflicts {
+		lines = append(lines, fmt.Sprintf("- Conflict (%s) %s vs %s: %s", c.Severity, c.RequirementA, c.RequirementB, c.Explanation))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // buildChangeDescriptions creates human-readable change summaries.
 func buildChangeDescriptions(
 	metadata *tasks.MetadataOutput,
@@ -220,9 +440,8 @@ func buildChangelog(
 	spec *schema.Specification,
 	metadata *tasks.MetadataOutput,
 	delta *tasks.RequirementsDeltaOutput,
-	categorization *tasks.CategorizationOutput,
+	categories *categoryPlan,
 	newRequirements []schema.Requirement,
-	version *tasks.VersionBumpOutput,
 ) []schema.ChangelogEvent {
 	events := []schema.ChangelogEvent{}
 
@@ -235,7 +454,7 @@ func buildChangelog(
 			NewMetadata: schema.ProjectMetadata{
 				Name:        metadata.Name,
 				Description: metadata.Description,
-				Version:     version.NewVersion,
+				Version:     spec.Metadata.Version, // Set by appendVersionBump
 				CreatedAt:   spec.Metadata.CreatedAt,
 				UpdatedAt:   time.Now(),
 			},
@@ -243,23 +462,38 @@ func buildChangelog(
 		})
 	}
 
-	// Category changes (simplified - just add new categories)
-	existingCats := make(map[string]bool)
-	for _, cat := range spec.Categories {
-		existingCats[cat] = true
-	}
-
-	for _, cat := range categorization.Categories {
-		if !existingCats[cat.Name] {
+	// Links to removed requirements go first, so none dangle, and before
+	// renames re-identify their sources
+	for _, rem := range delta.ToRemove {
+		for _, edge := range spec.LinksTo(rem.ID) {
 			evtID, _ := schema.NewEventID()
-			events = append(events, &schema.CategoryAdded{
-				EventID_:   evtID,
-				Name:       cat.Name,
-				Timestamp_: time.Now(),
+			events = append(events, &schema.RequirementLinkRemoved{
+				EventID_:      evtID,
+				RequirementID: edge.Source,
+				Link:          schema.RequirementLink{Type: edge.Type, Target: edge.Target},
+				Timestamp_:    time.Now(),
 			})
 		}
 	}
 
+	// Category renames and merges, then the categories new requirements need
+	newCategories := make([]string, 0, len(newRequirements))
+	for _, req := range newRequirements {
+		newCategories = append(newCategories, req.Category)
+	}
+	categories.settle(newCategories)
+
+	events = append(events, categories.moveEvents()...)
+	for _, cat := range categories.Added {
+		evtID, _ := schema.NewEventID()
+		events = append(events, &schema.CategoryAdded{
+			EventID_:    evtID,
+			Name:        cat,
+			Description: categories.Description(cat),
+			Timestamp_:  time.Now(),
+		})
+	}
+
 	// Requirement deletions
 	for _, rem := range delta.ToRemove {
 		// Find requirement to snapshot
@@ -290,16 +524,39 @@ func buildChangelog(
 		})
 	}
 
-	// Version bump
+	// Categories left empty go last, once nothing refers to them
+	for _, cat := range categories.Deleted {
+		evtID, _ := schema.NewEventID()
+		events = append(events, &schema.CategoryDeleted{
+			EventID_:   evtID,
+			Name:       cat,
+			Timestamp_: time.Now(),
+		})
+	}
+
+	return events
+}
+
+// appendVersionBump records the bump as the last event and stamps the new
+// version on any metadata update.
+func appendVersionBump(
+	events []schema.ChangelogEvent,
+	oldVersion string,
+	version *tasks.VersionBumpOutput,
+) []schema.ChangelogEvent {
+	for _, event := range events {
+		if e, ok := event.(*schema.ProjectMetadataUpdated); ok {
+			e.NewMetadata.Version = version.NewVersion
+		}
+	}
+
 	evtID, _ := schema.NewEventID()
-	events = append(events, &schema.VersionBumped{
+	return append(events, &schema.VersionBumped{
 		EventID_:   evtID,
-		OldVersion: spec.Metadata.Version,
+		OldVersion: oldVersion,
 		NewVersion: version.NewVersion,
 		BumpType:   version.BumpType,
 		Reasoning:  version.Reasoning,
 		Timestamp_: time.Now(),
 	})
-
-	return events
 }
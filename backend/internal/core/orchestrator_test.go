@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"xdd/internal/llm"
 	"xdd/internal/llm/tasks"
 	"xdd/internal/repository"
 	"xdd/pkg/schema"
@@ -51,9 +52,9 @@ func TestOrchestrator_ProcessPrompt_NewProject(t *testing.T) {
 	initialSpec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err := repo.WriteSpecification(initialSpec)
+	err := repo.WriteSpecification(context.Background(), initialSpec)
 	require.NoError(t, err)
 
 	mockExecutor := NewMockTaskExecutor()
@@ -83,9 +84,10 @@ func TestOrchestrator_ProcessPrompt_NewProject(t *testing.T) {
 	hasCategoryAdded := false
 
 	for _, event := range newState.PendingChangelog {
-		switch event.(type) {
+		switch e := event.(type) {
 		case *schema.RequirementAdded:
 			hasRequirementAdded = true
+			assert.Equal(t, schema.StatusDraft, e.Requirement.Status, "New requirements start as drafts")
 		case *schema.ProjectMetadataUpdated:
 			hasMetadataUpdate = true
 		case *schema.VersionBumped:
@@ -123,10 +125,10 @@ func TestOrchestrator_ProcessPrompt_AmbiguousModification(t *testing.T) {
 				CreatedAt:   time.Now(),
 			},
 		},
-		Categories: []string{"AUTH"},
+		Categories: []schema.Category{{Name: "AUTH"}},
 	}
 
-	err := repo.WriteSpecification(initialSpec)
+	err := repo.WriteSpecification(context.Background(), initialSpec)
 	require.NoError(t, err)
 
 	// Mock executor with ambiguous modification
@@ -223,7 +225,7 @@ func TestOrchestrator_buildChangelog(t *testing.T) {
 				Description: "Old requirement",
 			},
 		},
-		Categories: []string{"OLD"},
+		Categories: []schema.Category{{Name: "OLD"}},
 	}
 
 	metadata := &tasks.MetadataOutput{
@@ -287,7 +289,7 @@ func TestOrchestrator_buildChangelog(t *testing.T) {
 		Reasoning:  "New features added",
 	}
 
-	events := buildChangelog(spec, metadata, delta, categorization, newRequirements, version)
+	events := appendVersionBump(buildChangelog(spec, metadata, delta, planCategories(spec, delta, categorization), newRequirements), spec.Metadata.Version, version)
 
 	// Verify event types
 	var hasMetadataUpdate, hasCategoryAdd, hasReqDelete, hasReqAdd, hasVersionBump bool
@@ -323,7 +325,7 @@ func TestOrchestrator_buildChangelog_NoChanges(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{"EXISTING"},
+		Categories:   []schema.Category{{Name: "EXISTING"}},
 	}
 
 	metadata := &tasks.MetadataOutput{
@@ -363,7 +365,7 @@ func TestOrchestrator_buildChangelog_NoChanges(t *testing.T) {
 		Reasoning:  "Clarifications only",
 	}
 
-	events := buildChangelog(spec, metadata, delta, categorization, newRequirements, version)
+	events := appendVersionBump(buildChangelog(spec, metadata, delta, planCategories(spec, delta, categorization), newRequirements), spec.Metadata.Version, version)
 
 	// Should only have version bump
 	assert.Len(t, events, 1)
@@ -378,9 +380,9 @@ func TestOrchestrator_ProcessPrompt_RepositoryError(t *testing.T) {
 	initialSpec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err := repo.WriteSpecification(initialSpec)
+	err := repo.WriteSpecification(context.Background(), initialSpec)
 	require.NoError(t, err)
 
 	// Now remove ALL permissions to cause read errors
@@ -411,9 +413,9 @@ func TestOrchestrator_ProcessPrompt_TaskError(t *testing.T) {
 	initialSpec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err := repo.WriteSpecification(initialSpec)
+	err := repo.WriteSpecification(context.Background(), initialSpec)
 	require.NoError(t, err)
 
 	// Create mock executor that returns errors
@@ -431,3 +433,43 @@ func TestOrchestrator_ProcessPrompt_TaskError(t *testing.T) {
 	assert.Nil(t, newState)
 	assert.Contains(t, err.Error(), "metadata task")
 }
+
+// conversationRecorder captures the conversation each metadata call receives.
+type conversationRecorder struct {
+	*MockTaskExecutor
+	seen [][]llm.OpenRouterMsg
+}
+
+func (r *conversationRecorder) ExecuteMetadata(ctx context.Context, input *tasks.MetadataInput) (*tasks.MetadataOutput, error) {
+	r.seen = append(r.seen, llm.ConversationFromContext(ctx))
+	return r.MockTaskExecutor.ExecuteMetadata(ctx, input)
+}
+
+func TestOrchestrator_ProcessPrompt_ConversationContext(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	recorder := &conversationRecorder{MockTaskExecutor: NewMockTaskExecutor()}
+	orch := NewOrchestrator(recorder, repo)
+	ctx := context.Background()
+
+	state, err := orch.ProcessPrompt(ctx, NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+
+	// Prompt and proposal are recorded
+	require.Len(t, state.Messages, 2)
+	assert.Equal(t, Message{Role: "user", Content: "Build a task app"}, state.Messages[0])
+	assert.Equal(t, "assistant", state.Messages[1].Role)
+	assert.Contains(t, state.Messages[1].Content, "Add REQ-AUTH-")
+
+	// Feedback round sees the earlier prompt and proposal
+	state, err = orch.ProcessPrompt(ctx, state, "Drop the task management part")
+	require.NoError(t, err)
+
+	require.Len(t, recorder.seen, 2)
+	assert.Empty(t, recorder.seen[0])
+	require.Len(t, recorder.seen[1], 2)
+	assert.Equal(t, "Build a task app", recorder.seen[1][0].Content)
+	assert.Equal(t, "assistant", recorder.seen[1][1].Role)
+
+	require.Len(t, state.Messages, 4)
+	assert.Equal(t, "Drop the task management part", state.Messages[2].Content)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// runBounded calls work for each index in [0, n) with at most limit calls in
+// flight and returns their errors by index. Items not yet started when ctx
+// is done are skipped and get ctx.Err().
+func runBounded(ctx context.Context, n, limit int, work func(i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = work(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBounded_LimitsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	release := make(chan struct{})
+	done := make(chan []error)
+
+	go func() {
+		done <- runBounded(context.Background(), 6, 2, func(i int) error {
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			<-release
+			inFlight.Add(-1)
+			if i == 3 {
+				return errors.New("boom")
+			}
+			return nil
+		})
+	}()
+	close(release)
+	errs := <-done
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Len(t, errs, 6)
+	for i, err := range errs {
+		if i == 3 {
+			assert.EqualError(t, err, "boom")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestRunBounded_SkipsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+
+	errs := runBounded(ctx, 4, 1, func(i int) error {
+		calls.Add(1)
+		cancel()
+		return nil
+	})
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.NoError(t, errs[0])
+	for _, err := range errs[1:] {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
+	"xdd/internal/versioning"
+	"xdd/pkg/schema"
+)
+
+// DefaultProjectConfigPath is the location of the per-project configuration file.
+const DefaultProjectConfigPath = ".xdd/config.yml"
+
+// ProjectConfig holds per-project settings read from .xdd/config.yml.
+//
+// Example:
+//
+//	models:
+//	  - name: openai/gpt-4o-mini
+//	    context_window: 128000
+//	tasks:
+//	  version_bump:
+//	    model: openai/gpt-4o-mini
+//	    fallbacks: [google/gemini-2.5-flash]
+//	generation:
+//	  concurrency: 8
+//	  on_failure: keep
+//	  duplicate_threshold: 0.7
+//	versioning:
+//	  removal: major
+//	  addition: minor
+//	  metadata: patch
+//	fields:
+//	  - name: compliance
+//	    type: enum
+//	    values: [SOC2, HIPAA]
+//	  - name: target_release
+//	    type: string
+type ProjectConfig struct {
+	// Models declares models in addition to llm.DefaultModels()
+	Models []llm.ModelConfig `yaml:"models,omitempty"`
+
+	// Tasks maps task names to a primary model and ordered fallbacks
+	Tasks map[string]llm.ModelRoute `yaml:"tasks,omitempty"`
+
+	// Generation configures parallel requirement generation
+	Generation GenerationOptions `yaml:"generation,omitempty"`
+
+	// Versioning maps kinds of change to the version bump they require
+	Versioning versioning.Policy `yaml:"versioning,omitempty"`
+
+	// Fields declares the typed custom fields requirements may carry
+	Fields []schema.FieldDefinition `yaml:"fields,omitempty"`
+}
+
+// LoadProjectConfig reads the project configuration at path.
+// A missing file yields an empty configuration.
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ProjectConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read project config: %w", err)
+	}
+
+	var cfg ProjectConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse project config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid project config %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that task names are known, routed models are declared
+// and custom fields are well formed.
+func (c *ProjectConfig) Validate() error {
+	if err := c.Generation.Validate(); err != nil {
+		return fmt.Errorf("generation: %w", err)
+	}
+	if err := c.Versioning.Validate(); err != nil {
+		return fmt.Errorf("versioning: %w", err)
+	}
+
+	for i, f := range c.Fields {
+		if err := f.Validate(); err != nil {
+			return fmt.Errorf("fields: %w", err)
+		}
+		if schema.FindField(c.Fields[:i], f.Name) != nil {
+			return fmt.Errorf("fields: %s declared twice", f.Name)
+		}
+	}
+
+	for _, m := range c.Models {
+		if m.Name == "" {
+			return fmt.Errorf("models: name is required")
+		}
+	}
+
+	names := tasks.RoutableTaskNames()
+	for task := range c.Tasks {
+		if !slices.Contains(names, task) {
+			return fmt.Errorf("tasks: unknown task %q, must be one of %v", task, names)
+		}
+	}
+
+	routes := &llm.Config{Models: c.Models, Routes: c.Tasks}
+	return routes.ValidateRoutes()
+}
+
+// ApplyTo merges the project's models, routes and prompt overrides into an LLM client config.
+// Built-in routes from llm.DefaultRoutes() still apply to tasks the project leaves unset.
+func (c *ProjectConfig) ApplyTo(cfg *llm.Config) {
+	cfg.Models = append(cfg.Models, c.Models...)
+
+	if cfg.PromptDir == "" {
+		cfg.PromptDir = llm.DefaultPromptDir
+	}
+
+	if cfg.Routes == nil {
+		cfg.Routes = make(map[string]llm.ModelRoute)
+	}
+	for task, route := range c.Tasks {
+		cfg.Routes[task] = route
+	}
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
+	"xdd/internal/versioning"
+)
+
+func writeProjectConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadProjectConfig_Missing(t *testing.T) {
+	cfg, err := LoadProjectConfig(filepath.Join(t.TempDir(), "config.yml"))
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Tasks)
+	assert.Empty(t, cfg.Models)
+}
+
+func TestLoadProjectConfig_Routes(t *testing.T) {
+	path := writeProjectConfig(t, `
+models:
+  - name: openai/gpt-4o-mini
+    context_window: 128000
+tasks:
+  version_bump:
+    model: openai/gpt-4o-mini
+    fallbacks: [google/gemini-2.5-flash]
+`)
+
+	cfg, err := LoadProjectConfig(path)
+	require.NoError(t, err)
+	require.Contains(t, cfg.Tasks, tasks.TaskVersionBump)
+	assert.Equal(t, []string{"openai/gpt-4o-mini", "google/gemini-2.5-flash"}, cfg.Tasks[tasks.TaskVersionBump].Chain())
+
+	llmCfg := &llm.Config{APIKey: "k", BaseURL: "u", DefaultModel: "m"}
+	cfg.ApplyTo(llmCfg)
+	require.NoError(t, llmCfg.Validate())
+	llmCfg.SetDefaults() // As NewClient does
+
+	// Project route overrides the built-in one, other built-ins are kept
+	assert.Equal(t, "openai/gpt-4o-mini", llmCfg.Routes[tasks.TaskVersionBump].Model)
+	assert.Equal(t, llm.DefaultRoutes()[tasks.TaskCategorization], llmCfg.Routes[tasks.TaskCategorization])
+}
+
+func TestDefaultRoutes_KnownTasks(t *testing.T) {
+	for task := range llm.DefaultRoutes() {
+		assert.Contains(t, tasks.TaskNames(), task)
+	}
+}
+
+func TestLoadProjectConfig_JudgeRoute(t *testing.T) {
+	cfg, err := LoadProjectConfig(writeProjectConfig(t, "tasks:\n  eval_judge:\n    model: google/gemini-2.5-flash\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "google/gemini-2.5-flash", cfg.Tasks[tasks.TaskEvalJudge].Model)
+}
+
+func TestLoadProjectConfig_Versioning(t *testing.T) {
+	cfg, err := LoadProjectConfig(writeProjectConfig(t, "versioning:\n  removal: minor\n"))
+	require.NoError(t, err)
+	assert.Equal(t, versioning.BumpMinor, cfg.Versioning.Removal)
+	assert.Empty(t, cfg.Versioning.Addition, "unset rules keep their defaults")
+}
+
+func TestLoadProjectConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "unknown task",
+			content: "tasks:\n  summarize:\n    model: google/gemini-2.5-flash\n",
+			errMsg:  "unknown task",
+		},
+		{
+			name:    "undeclared model",
+			content: "tasks:\n  metadata:\n    model: acme/unknown\n",
+			errMsg:  "unknown model",
+		},
+		{
+			name:    "missing model",
+			content: "tasks:\n  metadata:\n    fallbacks: [google/gemini-2.5-flash]\n",
+			errMsg:  "model is required",
+		},
+		{
+			name:    "bad failure policy",
+			content: "generation:\n  on_failure: ignore\n",
+			errMsg:  "on_failure",
+		},
+		{
+			name:    "bad version policy",
+			content: "versioning:\n  removal: breaking\n",
+			errMsg:  "versioning: removal",
+		},
+		{
+			name:    "malformed yaml",
+			content: "tasks: [",
+			errMsg:  "parse project config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadProjectConfig(writeProjectConfig(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestLoadProjectConfig_Fields(t *testing.T) {
+	cfg, err := LoadProjectConfig(writeProjectConfig(t, `
+fields:
+  - name: compliance
+    type: enum
+    values: [SOC2, HIPAA]
+  - name: due
+    type: date
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Fields, 2)
+	assert.Equal(t, []string{"SOC2", "HIPAA"}, cfg.Fields[0].Values)
+
+	_, err = LoadProjectConfig(writeProjectConfig(t, "fields:\n  - name: due\n    type: date\n  - name: due\n    type: string\n"))
+	assert.ErrorContains(t, err, "due declared twice")
+
+	_, err = LoadProjectConfig(writeProjectConfig(t, "fields:\n  - name: points\n    type: float\n"))
+	assert.ErrorContains(t, err, `unknown type "float"`)
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+)
+
+// RequirementReview is the quality review of one requirement. Reviews are
+// advisory, so a failed review is recorded in Err rather than aborting.
+type RequirementReview struct {
+	RequirementID string
+	Output        *tasks.ReviewOutput
+	Err           error
+}
+
+// ReviewRequirements reviews each requirement against the ISO/IEC/IEEE 29148
+// quality attributes over the generation worker pool. Results are returned
+// in input order.
+func (o *Orchestrator) ReviewRequirements(ctx context.Context, requirements []schema.Requirement) []RequirementReview {
+	opts := o.generation
+	opts.SetDefaults()
+
+	reviews := make([]RequirementReview, len(requirements))
+	errs := runBounded(ctx, len(requirements), opts.Concurrency, func(i int) error {
+		var err error
+		reviews[i].Output, err = o.executor.ExecuteReview(ctx, &tasks.ReviewInput{Requirement: requirements[i]})
+		return err
+	})
+	for i, req := range requirements {
+		reviews[i].RequirementID = req.ID
+		reviews[i].Err = errs[i]
+	}
+
+	return reviews
+}
+
+// reviewNotes formats a review for the preview: the score, then one line
+// per finding with its suggested rewrite.
+func reviewNotes(review RequirementReview) []string {
+	if review.Err != nil {
+		return []string{fmt.Sprintf("🔎 Review unavailable: %v", review.Err)}
+	}
+	if review.Output == nil {
+		return nil
+	}
+
+	notes := []string{fmt.Sprintf("🔎 Quality %d/100: %s", review.Output.Score, review.Output.Summary)}
+	for _, f := range review.Output.Findings {
+		note := fmt.Sprintf("[%s] %s (%d/5): %s", f.Severity, f.Attribute, f.Score, f.Issue)
+		if f.Excerpt != "" {
+			note += fmt.Sprintf(" — %q", f.Excerpt)
+		}
+		notes = append(notes, "   "+note, "     Suggested: "+truncate(f.SuggestedRewrite, 120))
+	}
+	return notes
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reviewByID returns a canned review per requirement ID.
+type reviewByID struct {
+	*MockTaskExecutor
+	outputs map[string]*tasks.ReviewOutput
+	errs    map[string]error
+}
+
+func (r *reviewByID) ExecuteReview(ctx context.Context, input *tasks.ReviewInput) (*tasks.ReviewOutput, error) {
+	if err := r.errs[input.Requirement.ID]; err != nil {
+		return nil, err
+	}
+	return r.outputs[input.Requirement.ID], nil
+}
+
+func TestOrchestrator_ReviewRequirements(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	exec := &reviewByID{
+		MockTaskExecutor: NewMockTaskExecutor(),
+		outputs: map[string]*tasks.ReviewOutput{
+			"REQ-A": {Score: 95},
+			"REQ-C": {Score: 40},
+		},
+		errs: map[string]error{"REQ-B": errors.New("provider down")},
+	}
+	orch := NewOrchestrator(exec, repo)
+
+	reviews := orch.ReviewRequirements(context.Background(), []schema.Requirement{
+		{ID: "REQ-A"}, {ID: "REQ-B"}, {ID: "REQ-C"},
+	})
+	require.Len(t, reviews, 3)
+	assert.Equal(t, "REQ-A", reviews[0].RequirementID)
+	assert.Equal(t, 95, reviews[0].Output.Score)
+	assert.EqualError(t, reviews[1].Err, "provider down")
+	assert.Equal(t, 40, reviews[2].Output.Score)
+}
+
+func TestOrchestrator_ProcessPrompt_ReviewsNewRequirements(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	mockExecutor := NewMockTaskExecutor()
+	mockExecutor.ReviewOutput = &tasks.ReviewOutput{
+		Score:   55,
+		Summary: "Vague performance wording",
+		Findings: []tasks.ReviewFinding{{
+			Attribute:        tasks.QualityUnambiguous,
+			Severity:         "high",
+			Score:            2,
+			Issue:            "No measurable response time",
+			Excerpt:          "fast",
+			SuggestedRewrite: "When the user logs in, the system shall respond within 2 seconds",
+		}},
+	}
+	orch := NewOrchestrator(mockExecutor, repo)
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, mockExecutor.ReviewCalls)
+	require.Len(t, state.Reviews, 2)
+
+	// Findings are attached to the generated requirement for the preview
+	var added *schema.RequirementAdded
+	for _, event := range state.PendingChangelog {
+		if e, ok := event.(*schema.RequirementAdded); ok {
+			added = e
+			break
+		}
+	}
+	require.NotNil(t, added)
+	notes := state.requirementNotes()[added.Requirement.ID]
+	require.Len(t, notes, 3)
+	assert.Contains(t, notes[0], "Quality 55/100")
+	assert.Contains(t, notes[1], "[high] unambiguous (2/5): No measurable response time")
+	assert.Contains(t, notes[2], "respond within 2 seconds")
+}
+
+func TestOrchestrator_ProcessPrompt_SkipReview(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	mockExecutor := NewMockTaskExecutor()
+	orch := NewOrchestrator(mockExecutor, repo)
+	orch.SetGenerationOptions(GenerationOptions{SkipReview: true})
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+	assert.Zero(t, mockExecutor.ReviewCalls)
+	assert.Empty(t, state.Reviews)
+}
+
+func TestOrchestrator_ProcessPrompt_ReviewFailureIsAdvisory(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	mockExecutor := NewMockTaskExecutor()
+	mockExecutor.ReviewError = errors.New("review model unavailable")
+	orch := NewOrchestrator(mockExecutor, repo)
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task app")
+	require.NoError(t, err)
+	require.Len(t, state.Reviews, 2)
+	assert.Contains(t, reviewNotes(state.Reviews[0])[0], "Review unavailable: review model unavailable")
+}
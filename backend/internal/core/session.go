@@ -1,6 +1,8 @@
 package core
 
 import (
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
 	"xdd/pkg/schema"
 )
 
@@ -10,6 +12,29 @@ type SessionState struct {
 	PendingChangelog []schema.ChangelogEvent
 	Committed        bool
 	AwaitingFeedback bool
+
+	// GenerationFailures lists requirements skipped under FailurePolicyKeep
+	GenerationFailures []GenerationFailure
+
+	// PossibleDuplicates flags proposed requirements resembling existing ones
+	PossibleDuplicates []PossibleDuplicate
+
+	// Reviews holds the quality review of each generated requirement
+	Reviews []RequirementReview
+
+	// Conflicts pairs generated requirements with requirements they contradict
+	Conflicts []tasks.Conflict
+
+	// PromptHashes records the prompt templates behind PendingChangelog
+	PromptHashes map[string]string
+
+	// PendingAmbiguities are questions awaiting answers via ResolveAmbiguities;
+	// PendingRequest is the prompt that raised them
+	PendingAmbiguities []Ambiguity
+	PendingRequest     string
+
+	// Resolutions are answered clarifications, sent with every later delta request
+	Resolutions []llm.ResolvedAmbiguity
 }
 
 // Message represents a conversation message.
@@ -38,10 +63,18 @@ func (s *SessionState) Clone() *SessionState {
 		PendingChangelog: make([]schema.ChangelogEvent, len(s.PendingChangelog)),
 		Committed:        s.Committed,
 		AwaitingFeedback: s.AwaitingFeedback,
+		PromptHashes:     s.PromptHashes,
+		PendingRequest:   s.PendingRequest,
 	}
 
 	copy(clone.Messages, s.Messages)
 	copy(clone.PendingChangelog, s.PendingChangelog)
+	clone.GenerationFailures = append([]GenerationFailure(nil), s.GenerationFailures...)
+	clone.PossibleDuplicates = append([]PossibleDuplicate(nil), s.PossibleDuplicates...)
+	clone.Reviews = append([]RequirementReview(nil), s.Reviews...)
+	clone.Conflicts = append([]tasks.Conflict(nil), s.Conflicts...)
+	clone.PendingAmbiguities = append([]Ambiguity(nil), s.PendingAmbiguities...)
+	clone.Resolutions = append([]llm.ResolvedAmbiguity(nil), s.Resolutions...)
 
 	return clone
 }
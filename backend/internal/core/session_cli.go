@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
 	"xdd/internal/repository"
 	"xdd/pkg/schema"
 )
@@ -20,11 +22,20 @@ type CLISession struct {
 	Repo         *repository.Repository
 }
 
-// NewCLISession creates a new CLI session with an LLM client.
-func NewCLISession(llmClient *llm.Client, repo *repository.Repository) *CLISession {
+// NewCLISession creates a new CLI session with an LLM client. The project's
+// generation and versioning settings apply to the session's orchestrator,
+// and its custom fields to what repo writes; a nil project keeps the
+// defaults.
+func NewCLISession(llmClient *llm.Client, repo *repository.Repository, project *ProjectConfig) *CLISession {
+	orch := NewOrchestratorWithLLMClient(llmClient, repo)
+	if project != nil {
+		orch.SetGenerationOptions(project.Generation)
+		orch.SetVersionPolicy(project.Versioning)
+		repo.SetFieldDefinitions(project.Fields)
+	}
 	return &CLISession{
 		State:        NewSessionState(),
-		Orchestrator: NewOrchestratorWithLLMClient(llmClient, repo),
+		Orchestrator: orch,
 		Lock:         repository.NewFileLock(".xdd/.lock", "cli"),
 		Repo:         repo,
 	}
@@ -55,23 +66,37 @@ func (s *CLISession) Run(initialPrompt string) error {
 	fmt.Println("✅ Lock acquired")
 
 	ctx := context.Background()
+	reader := bufio.NewReader(os.Stdin)
 	prompt := initialPrompt
+	var answers []string
 
 	// Interactive loop
 	for !s.State.Committed {
 		fmt.Println("🤖 Analyzing request...")
 
-		newState, err := s.Orchestrator.ProcessPrompt(ctx, s.State, prompt)
+		var newState *SessionState
+		var err error
+		if answers != nil {
+			newState, err = s.Orchestrator.ResolveAmbiguities(ctx, s.State, answers)
+			answers = nil
+		} else {
+			newState, err = s.Orchestrator.ProcessPrompt(ctx, s.State, prompt)
+		}
 		if err != nil {
 			return fmt.Errorf("orchestration failed: %w", err)
 		}
 		s.State = newState
 
+		// Ask every clarification question before re-running the request
+		if len(s.State.PendingAmbiguities) > 0 {
+			answers = askAmbiguities(reader, s.State.PendingAmbiguities)
+			continue
+		}
+
 		// Check if awaiting feedback
 		if s.State.AwaitingFeedback {
 			lastMsg := s.State.Messages[len(s.State.Messages)-1]
 			fmt.Printf("\n📝 %s\n> ", lastMsg.Content)
-			reader := bufio.NewReader(os.Stdin)
 			feedback, _ := reader.ReadString('\n')
 			prompt = strings.TrimSpace(feedback)
 			s.State.AwaitingFeedback = false
@@ -80,30 +105,67 @@ func (s *CLISession) Run(initialPrompt string) error {
 
 		// Show changelog preview
 		fmt.Println("\n📊 Proposed Changes:")
-		displayChangelog(s.State.PendingChangelog)
-
-		// Confirm
-		fmt.Print("\nAre you satisfied? [yes/no/feedback]: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(response)
-
-		switch strings.ToLower(response) {
-		case "yes", "y":
-			// Commit
-			if err := s.commit(); err != nil {
-				return fmt.Errorf("commit failed: %w", err)
-			}
-			s.State.Committed = true
+		displayChangelog(s.State.PendingChangelog, s.State.requirementNotes())
+		displayGenerationFailures(s.State.GenerationFailures)
+		displayConflicts(s.State.Conflicts)
+
+		// Confirm; skipping duplicates or overriding conflicts asks again
+		decided := false
+		for !decided {
+			fmt.Printf("\nAre you satisfied? [%s]: ", s.confirmOptions())
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(response)
+			decided = true
+
+			switch strings.ToLower(response) {
+			case "yes", "y":
+				if s.State.CommitBlocked() {
+					fmt.Println("⛔ Critical conflicts block this commit. Give feedback to resolve them, or type 'override' to commit anyway.")
+					decided = false
+					continue
+				}
+				if err := s.commit(ctx); err != nil {
+					return fmt.Errorf("commit failed: %w", err)
+				}
+				s.State.Committed = true
+
+			case "no", "n":
+				fmt.Println("❌ Changes discarded.")
+				return nil
 
-		case "no", "n":
-			fmt.Println("❌ Changes discarded.")
-			return nil
+			case "skip", "skip duplicates":
+				if len(s.State.PossibleDuplicates) == 0 {
+					prompt = response // Not a command here, treat as feedback
+					break
+				}
+				skipped, err := s.Orchestrator.SkipDuplicates(s.State)
+				if err != nil {
+					return fmt.Errorf("skip duplicates: %w", err)
+				}
+				fmt.Printf("⏭️  Skipped %d possible duplicate(s).\n", skipped)
+				fmt.Println("\n📊 Proposed Changes:")
+				displayChangelog(s.State.PendingChangelog, s.State.requirementNotes())
+				decided = false
+
+			case "override":
+				if !s.State.CommitBlocked() {
+					prompt = response
+					break
+				}
+				fmt.Print("Reason for committing despite critical conflicts: ")
+				reason, _ := reader.ReadString('\n')
+				if err := s.State.OverrideConflicts(strings.TrimSpace(reason)); err != nil {
+					fmt.Printf("⚠️  %v\n", err)
+				} else {
+					fmt.Println("📝 Override will be recorded in the changelog.")
+				}
+				decided = false
 
-		default:
-			// Treat as feedback
-			fmt.Println()
-			prompt = response
+			default:
+				// Treat as feedback
+				fmt.Println()
+				prompt = response
+			}
 		}
 	}
 
@@ -112,8 +174,24 @@ func (s *CLISession) Run(initialPrompt string) error {
 	return nil
 }
 
+// confirmOptions lists the answers the confirmation prompt accepts.
+func (s *CLISession) confirmOptions() string {
+	options := []string{"yes", "no"}
+	if len(s.State.PossibleDuplicates) > 0 {
+		options = append(options, "skip duplicates")
+	}
+	if s.State.CommitBlocked() {
+		options = append(options, "override")
+	}
+	return strings.Join(append(options, "feedback"), "/")
+}
+
 // commit writes changes to disk.
-func (s *CLISession) commit() error {
+func (s *CLISession) commit(ctx context.Context) error {
+	if s.State.CommitBlocked() {
+		return ErrCommitBlocked
+	}
+
 	fmt.Println("\n✅ Committing changes...")
 
 	// Load current spec to merge changes
@@ -122,42 +200,16 @@ func (s *CLISession) commit() error {
 		return fmt.Errorf("load spec: %w", err)
 	}
 
-	// Apply changes from changelog
+	// Apply changes exactly as replay will
 	for _, event := range s.State.PendingChangelog {
-		switch e := event.(type) {
-		case *schema.RequirementAdded:
-			spec.Requirements = append(spec.Requirements, e.Requirement)
-
-		case *schema.RequirementDeleted:
-			// Remove requirement
-			filtered := []schema.Requirement{}
-			for _, req := range spec.Requirements {
-				if req.ID != e.RequirementID {
-					filtered = append(filtered, req)
-				}
-			}
-			spec.Requirements = filtered
-
-		case *schema.ProjectMetadataUpdated:
-			spec.Metadata = e.NewMetadata
-
-		case *schema.CategoryAdded:
-			spec.Categories = append(spec.Categories, e.Name)
-
-		case *schema.CategoryDeleted:
-			// Remove category
-			filtered := []string{}
-			for _, cat := range spec.Categories {
-				if cat != e.Name {
-					filtered = append(filtered, cat)
-				}
-			}
-			spec.Categories = filtered
+		if err := repository.ApplyEvent(spec, event); err != nil {
+			return fmt.Errorf("apply %s: %w", event.EventType(), err)
 		}
 	}
 
 	// Write specification and changelog atomically
-	if err := s.Repo.WriteSpecificationAndChangelog(spec, s.State.PendingChangelog); err != nil {
+	envelope := &repository.EventEnvelope{PromptHashes: s.State.PromptHashes}
+	if err := s.Repo.WriteSpecificationAndChangelogWithEnvelope(ctx, spec, s.State.PendingChangelog, envelope); err != nil {
 		return fmt.Errorf("write specification and changelog: %w", err)
 	}
 	fmt.Println("   Writing specification.yaml")
@@ -166,17 +218,55 @@ func (s *CLISession) commit() error {
 	return nil
 }
 
-// displayChangelog formats and prints changelog events.
-func displayChangelog(events []schema.ChangelogEvent) {
+// displayChangelog formats and prints changelog events. notes, keyed by
+// requirement ID, are printed under the matching [+] or [-] line.
+func displayChangelog(events []schema.ChangelogEvent, notes map[string][]string) {
 	for _, event := range events {
 		switch e := event.(type) {
 		case *schema.RequirementAdded:
 			fmt.Printf("  [+] %s: %s\n", e.Requirement.ID, truncate(e.Requirement.Description, 80))
 			fmt.Printf("      Category: %s, Priority: %s\n", e.Requirement.Category, e.Requirement.Priority)
 			fmt.Printf("      Acceptance Criteria: %d\n", len(e.Requirement.AcceptanceCriteria))
+			for _, note := range notes[e.Requirement.ID] {
+				fmt.Printf("      %s\n", note)
+			}
 
 		case *schema.RequirementDeleted:
 			fmt.Printf("  [-] %s: %s\n", e.RequirementID, truncate(e.Requirement.Description, 80))
+			for _, note := range notes[e.RequirementID] {
+				fmt.Printf("      %s\n", note)
+			}
+
+		case *schema.RequirementLinkAdded:
+			fmt.Printf("  [+] Link: %s %s %s\n", e.RequirementID, e.Link.Type, e.Link.Target)
+
+		case *schema.RequirementLinkRemoved:
+			fmt.Printf("  [-] Link: %s %s %s\n", e.RequirementID, e.Link.Type, e.Link.Target)
+
+		case *schema.RequirementStatusChanged:
+			fmt.Printf("  [~] Status: %s %s → %s (%s)\n", e.RequirementID, e.From, e.To, e.Actor)
+
+		case *schema.RequirementTagsChanged:
+			for _, tag := range e.Added {
+				fmt.Printf("  [+] Tag: %s %s\n", e.RequirementID, tag)
+			}
+			for _, tag := range e.Removed {
+				fmt.Printf("  [-] Tag: %s %s\n", e.RequirementID, tag)
+			}
+
+		case *schema.RequirementFieldSet:
+			if e.Value == "" {
+				fmt.Printf("  [-] Field: %s %s\n", e.RequirementID, e.Name)
+			} else {
+				fmt.Printf("  [~] Field: %s %s = %s\n", e.RequirementID, e.Name, e.Value)
+			}
+
+		case *schema.AcceptanceCriterionVerificationSet:
+			if e.Verification == (schema.Verification{}) {
+				fmt.Printf("  [-] Verification: %s %s\n", e.RequirementID, e.CriterionID)
+			} else {
+				fmt.Printf("  [~] Verification: %s %s %s\n", e.RequirementID, e.CriterionID, e.Verification)
+			}
 
 		case *schema.ProjectMetadataUpdated:
 			if e.OldMetadata.Name != e.NewMetadata.Name {
@@ -191,11 +281,88 @@ func displayChangelog(events []schema.ChangelogEvent) {
 			fmt.Printf("      Reason: %s\n", truncate(e.Reasoning, 80))
 
 		case *schema.CategoryAdded:
-			fmt.Printf("  [+] Category: %s\n", e.Name)
+			if e.Description != "" {
+				fmt.Printf("  [+] Category: %s (%s)\n", e.Name, e.Description)
+			} else {
+				fmt.Printf("  [+] Category: %s\n", e.Name)
+			}
 
 		case *schema.CategoryDeleted:
 			fmt.Printf("  [-] Category: %s\n", e.Name)
+
+		case *schema.CategoryRenamed:
+			fmt.Printf("  [~] Category: %s → %s\n", e.OldName, e.NewName)
+			printRequirementIDChanges(e.RequirementIDs)
+
+		case *schema.CategoryMerged:
+			fmt.Printf("  [~] Category: %s merged into %s\n", e.Source, e.Target)
+			printRequirementIDChanges(e.RequirementIDs)
+
+		case *schema.ConflictOverridden:
+			fmt.Printf("  [!] Override %d critical conflict(s): %s\n", len(e.Conflicts), truncate(e.Reason, 80))
+		}
+	}
+}
+
+// askAmbiguities prompts for an answer to each ambiguity in turn.
+func askAmbiguities(reader *bufio.Reader, ambiguities []Ambiguity) []string {
+	fmt.Printf("\n❓ Your request is ambiguous (%d question(s)):\n", len(ambiguities))
+	answers := make([]string, 0, len(ambiguities))
+	for i, amb := range ambiguities {
+		fmt.Printf("\n%d. %s\n> ", i+1, amb.Format())
+		answer, _ := reader.ReadString('\n')
+		answers = append(answers, strings.TrimSpace(answer))
+	}
+	fmt.Println()
+	return answers
+}
+
+// requirementNotes collects per-requirement annotations for the preview,
+// keyed by requirement ID.
+func (s *SessionState) requirementNotes() map[string][]string {
+	notes := make(map[string][]string)
+	for _, d := range s.PossibleDuplicates {
+		notes[d.RequirementID] = append(notes[d.RequirementID], fmt.Sprintf(
+			"⚠️  Possible duplicate of %s (%.0f%% similar): %s",
+			d.ExistingID, d.Score*100, truncate(d.ExistingDescription, 60)))
+	}
+	for _, r := range s.Reviews {
+		notes[r.RequirementID] = append(notes[r.RequirementID], reviewNotes(r)...)
+	}
+	for _, event := range s.PendingChangelog {
+		if e, ok := event.(*schema.RequirementLinkRemoved); ok {
+			notes[e.Link.Target] = append(notes[e.Link.Target], fmt.Sprintf(
+				"⚠️  %s %s it; that link is removed too", e.RequirementID, e.Link.Type))
+		}
+	}
+	return notes
+}
+
+// displayConflicts prints contradictions between proposed and existing requirements.
+func displayConflicts(conflicts []tasks.Conflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+	fmt.Printf("\n⚔️  %d conflict(s) found:\n", len(conflicts))
+	for _, c := range conflicts {
+		marker := "[!]"
+		if c.Severity == tasks.ConflictCritical {
+			marker = "[⛔]"
 		}
+		fmt.Printf("  %s %s: %s ↔ %s\n", marker, c.Severity, c.RequirementA, c.RequirementB)
+		fmt.Printf("      %s\n", truncate(c.Explanation, 120))
+	}
+}
+
+// displayGenerationFailures prints requirements that could not be generated.
+func displayGenerationFailures(failures []GenerationFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	fmt.Printf("\n⚠️  %d requirement(s) failed to generate and were skipped:\n", len(failures))
+	for _, f := range failures {
+		fmt.Printf("  [!] %s: %s\n", f.Category, truncate(f.BriefDescription, 80))
+		fmt.Printf("      Error: %v\n", f.Err)
 	}
 }
 
@@ -206,3 +373,15 @@ func truncate(s string, max int) string {
 	}
 	return s[:max-3] + "..."
 }
+
+// printRequirementIDChanges lists requirement IDs rewritten by a category move.
+func printRequirementIDChanges(ids map[string]string) {
+	old := make([]string, 0, len(ids))
+	for id := range ids {
+		old = append(old, id)
+	}
+	sort.Strings(old)
+	for _, id := range old {
+		fmt.Printf("      %s → %s\n", id, ids[id])
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
@@ -28,7 +29,7 @@ func TestNewCLISession(t *testing.T) {
 
 	repo, _ := createTestRepository(t)
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 
 	assert.NotNil(t, session)
 	assert.NotNil(t, session.State)
@@ -39,6 +40,21 @@ func TestNewCLISession(t *testing.T) {
 	assert.False(t, session.State.AwaitingFeedback)
 }
 
+func TestNewCLISession_AppliesProjectConfig(t *testing.T) {
+	client, err := llm.NewClient(&llm.Config{APIKey: "test-key", BaseURL: "https://test.com", DefaultModel: "test-model"})
+	require.NoError(t, err)
+	repo, _ := createTestRepository(t)
+
+	project := &ProjectConfig{Generation: GenerationOptions{
+		Concurrency:        2,
+		OnFailure:          FailurePolicyKeep,
+		DuplicateThreshold: 0.9,
+	}}
+	session := NewCLISession(client, repo, project)
+
+	assert.Equal(t, project.Generation, session.Orchestrator.generation)
+}
+
 func TestCLISession_Run_LockAcquisitionFailure(t *testing.T) {
 	repo, tempDir := createTestRepository(t)
 	mockExecutor := NewMockTaskExecutor()
@@ -81,7 +97,7 @@ func TestCLISession_commit_Success(t *testing.T) {
 	// Start with empty project (no spec or changelog)
 	// The repository will return empty spec on first read
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 
 	// Add pending changes
 	reqID, _ := schema.NewRequirementID("AUTH")
@@ -121,7 +137,7 @@ func TestCLISession_commit_Success(t *testing.T) {
 	}
 
 	// Commit
-	err = session.commit()
+	err = session.commit(context.Background())
 	require.NoError(t, err)
 
 	// Verify specification updated
@@ -129,7 +145,7 @@ func TestCLISession_commit_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, updatedSpec.Requirements, 1)
 	assert.Equal(t, reqID, updatedSpec.Requirements[0].ID)
-	assert.Contains(t, updatedSpec.Categories, "AUTH")
+	assert.Contains(t, updatedSpec.CategoryNames(), "AUTH")
 
 	// Verify changelog exists
 	changelogPath := filepath.Join(tempDir, "01-specs", "changelog.yaml")
@@ -164,12 +180,12 @@ func TestCLISession_commit_RequirementDeleted(t *testing.T) {
 				CreatedAt:   time.Now(),
 			},
 		},
-		Categories: []string{"AUTH"},
+		Categories: []schema.Category{{Name: "AUTH"}},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 
 	// Add deletion event
 	evtID, _ := schema.NewEventID()
@@ -183,7 +199,7 @@ func TestCLISession_commit_RequirementDeleted(t *testing.T) {
 	}
 
 	// Commit
-	err = session.commit()
+	err = session.commit(context.Background())
 	require.NoError(t, err)
 
 	// Verify requirement removed
@@ -192,6 +208,49 @@ func TestCLISession_commit_RequirementDeleted(t *testing.T) {
 	assert.Len(t, updatedSpec.Requirements, 0)
 }
 
+func TestCLISession_commit_AppliesEventsLikeReplay(t *testing.T) {
+	repo, _ := createTestRepository(t)
+	spec := &schema.Specification{
+		Metadata:     schema.ProjectMetadata{Name: "App", Version: "0.1.0", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		Requirements: []schema.Requirement{{ID: "REQ-AUTH-abc123", Category: "AUTH", Description: "Login", CreatedAt: time.Now()}},
+		Categories:   []schema.Category{{Name: "AUTH"}},
+	}
+	require.NoError(t, repo.WriteSpecification(context.Background(), spec))
+
+	tests := []struct {
+		name   string
+		event  schema.ChangelogEvent
+		errMsg string
+	}{
+		{
+			name:   "category still in use",
+			event:  &schema.CategoryDeleted{EventID_: "EVT-1", Name: "AUTH", Timestamp_: time.Now()},
+			errMsg: "category AUTH is still in use",
+		},
+		{
+			name: "link to missing requirement",
+			event: &schema.RequirementLinkAdded{
+				EventID_:      "EVT-2",
+				RequirementID: "REQ-AUTH-abc123",
+				Link:          schema.RequirementLink{Type: schema.LinkDependsOn, Target: "REQ-AUTH-missing"},
+				Timestamp_:    time.Now(),
+			},
+			errMsg: "REQ-AUTH-missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewCLISessionWithExecutor(NewMockTaskExecutor(), repo)
+			session.State.PendingChangelog = []schema.ChangelogEvent{tt.event}
+
+			err := session.commit(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
 func TestCLISession_commit_MetadataUpdated(t *testing.T) {
 	config := &llm.Config{APIKey: "test-key", BaseURL: "https://test.com", DefaultModel: "test-model"}
 	client, err := llm.NewClient(config)
@@ -209,12 +268,12 @@ func TestCLISession_commit_MetadataUpdated(t *testing.T) {
 	spec := &schema.Specification{
 		Metadata:     oldMetadata,
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 
 	// Add metadata update event
 	newMetadata := schema.ProjectMetadata{
@@ -235,7 +294,7 @@ func TestCLISession_commit_MetadataUpdated(t *testing.T) {
 	}
 
 	// Commit
-	err = session.commit()
+	err = session.commit(context.Background())
 	require.NoError(t, err)
 
 	// Verify metadata updated
@@ -264,12 +323,12 @@ func TestCLISession_commit_CategoryOperations(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{"OLD_CATEGORY"},
+		Categories:   []schema.Category{{Name: "OLD_CATEGORY"}},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 
 	// Add category operations
 	evtID1, _ := schema.NewEventID()
@@ -288,14 +347,14 @@ func TestCLISession_commit_CategoryOperations(t *testing.T) {
 	}
 
 	// Commit
-	err = session.commit()
+	err = session.commit(context.Background())
 	require.NoError(t, err)
 
 	// Verify categories updated
 	updatedSpec, err := repo.ReadSpecification()
 	require.NoError(t, err)
-	assert.Contains(t, updatedSpec.Categories, "NEW_CATEGORY")
-	assert.NotContains(t, updatedSpec.Categories, "OLD_CATEGORY")
+	assert.Contains(t, updatedSpec.CategoryNames(), "NEW_CATEGORY")
+	assert.NotContains(t, updatedSpec.CategoryNames(), "OLD_CATEGORY")
 }
 
 func TestCLISession_displayChangelog(t *testing.T) {
@@ -341,7 +400,7 @@ func TestCLISession_displayChangelog(t *testing.T) {
 		},
 	}
 
-	displayChangelog(events)
+	displayChangelog(events, nil)
 
 	// Restore stdout and read output
 	w.Close()
@@ -421,16 +480,16 @@ func TestCLISession_commit_EmptyChangelog(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 	session.State.PendingChangelog = []schema.ChangelogEvent{} // Empty
 
 	// Commit should succeed even with no changes
-	err = session.commit()
+	err = session.commit(context.Background())
 	require.NoError(t, err)
 }
 
@@ -459,9 +518,9 @@ func TestCLISession_commit_RepositoryWriteFailure(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
 	// Make specs directory read-only
@@ -469,7 +528,7 @@ func TestCLISession_commit_RepositoryWriteFailure(t *testing.T) {
 	require.NoError(t, err)
 	defer os.Chmod(specsDir, 0755) // Restore for cleanup
 
-	session := NewCLISession(client, repo)
+	session := NewCLISession(client, repo, nil)
 
 	// Add pending change
 	evtID, _ := schema.NewEventID()
@@ -482,7 +541,7 @@ func TestCLISession_commit_RepositoryWriteFailure(t *testing.T) {
 	}
 
 	// Commit should fail
-	err = session.commit()
+	err = session.commit(context.Background())
 	assert.Error(t, err)
 }
 
@@ -499,9 +558,9 @@ func TestCLISession_Run_Success(t *testing.T) {
 	spec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
 	mockExecutor := NewMockTaskExecutor()
@@ -543,9 +602,9 @@ func TestCLISession_Run_UserDecline(t *testing.T) {
 	spec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
 	mockExecutor := NewMockTaskExecutor()
@@ -591,9 +650,9 @@ func TestCLISession_Run_FeedbackLoop(t *testing.T) {
 	spec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
 	// Create mock executor that will be called multiple times
@@ -692,7 +751,7 @@ func TestCLISession_displayChangelog_AllEventTypes(t *testing.T) {
 		},
 	}
 
-	displayChangelog(events)
+	displayChangelog(events, nil)
 
 	// Restore stdout and read output
 	w.Close()
@@ -744,6 +803,6 @@ func BenchmarkDisplayChangelog(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		displayChangelog(events)
+		displayChangelog(events, nil)
 	}
 }
@@ -2,7 +2,10 @@ package core
 
 import (
 	"context"
+	"errors"
+	"sync"
 
+	"xdd/internal/llm"
 	"xdd/internal/llm/tasks"
 )
 
@@ -12,90 +15,70 @@ type TaskExecutor interface {
 	ExecuteRequirementsDelta(ctx context.Context, input *tasks.RequirementsDeltaInput) (*tasks.RequirementsDeltaOutput, error)
 	ExecuteCategorization(ctx context.Context, input *tasks.CategorizationInput) (*tasks.CategorizationOutput, error)
 	ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error)
+	ExecuteReview(ctx context.Context, input *tasks.ReviewInput) (*tasks.ReviewOutput, error)
+	ExecuteConflictAnalysis(ctx context.Context, input *tasks.ConflictInput) (*tasks.ConflictOutput, error)
 	ExecuteVersionBump(ctx context.Context, input *tasks.VersionBumpInput) (*tasks.VersionBumpOutput, error)
 }
 
+// PromptHasher is implemented by executors that render prompt templates,
+// so commits can record which template versions produced them.
+type PromptHasher interface {
+	PromptHashes() map[string]string
+}
+
 // RealTaskExecutor implements TaskExecutor using real LLM calls.
+// Model selection per task follows the client's configured routes.
 type RealTaskExecutor struct {
-	client interface{} // Will be *llm.Client once we import it
+	client *llm.Client
 }
 
 // NewRealTaskExecutor creates a TaskExecutor that calls real LLM APIs.
-func NewRealTaskExecutor(client interface{}) TaskExecutor {
+func NewRealTaskExecutor(client *llm.Client) TaskExecutor {
 	return &RealTaskExecutor{client: client}
 }
 
+// PromptHashes returns the hashes of the client's prompt templates.
+func (e *RealTaskExecutor) PromptHashes() map[string]string {
+	return e.client.Prompts().Hashes()
+}
+
 // Execute methods delegate to actual LLM task functions.
 func (e *RealTaskExecutor) ExecuteMetadata(ctx context.Context, input *tasks.MetadataInput) (*tasks.MetadataOutput, error) {
-	// For now, return mock data - will be replaced with real LLM call
-	return &tasks.MetadataOutput{
-		Name:        "MockProject",
-		Description: "Mock description",
-		Changed: struct {
-			Name        bool `json:"name"`
-			Description bool `json:"description"`
-		}{Name: true, Description: true},
-		Reasoning: "Mock reasoning",
-	}, nil
+	return tasks.ExecuteMetadataTask(e.client, ctx, input)
 }
 
 func (e *RealTaskExecutor) ExecuteRequirementsDelta(ctx context.Context, input *tasks.RequirementsDeltaInput) (*tasks.RequirementsDeltaOutput, error) {
-	return &tasks.RequirementsDeltaOutput{
-		ToRemove: []struct {
-			ID        string `json:"id"`
-			Reasoning string `json:"reasoning"`
-		}{},
-		ToAdd: []struct {
-			Category          string `json:"category"`
-			BriefDescription  string `json:"brief_description"`
-			EARSType          string `json:"ears_type"`
-			EstimatedPriority string `json:"estimated_priority"`
-			Reasoning         string `json:"reasoning"`
-		}{},
-		AmbiguousModifications: []struct {
-			PossibleTargets []string `json:"possible_targets"`
-			Clarification   string   `json:"clarification"`
-		}{},
-	}, nil
+	output, err := tasks.ExecuteRequirementsDeltaTask(e.client, ctx, input)
+
+	// Ambiguity is not a failure, the orchestrator asks the user to clarify
+	var ambiguous *tasks.AmbiguousModificationError
+	if errors.As(err, &ambiguous) {
+		return &tasks.RequirementsDeltaOutput{
+			AmbiguousModifications: ambiguous.Clarifications,
+		}, nil
+	}
+
+	return output, err
 }
 
 func (e *RealTaskExecutor) ExecuteCategorization(ctx context.Context, input *tasks.CategorizationInput) (*tasks.CategorizationOutput, error) {
-	return &tasks.CategorizationOutput{
-		Categories: []struct {
-			Name        string `json:"name"`
-			Description string `json:"description"`
-			Count       int    `json:"count"`
-		}{
-			{Name: "MOCK", Description: "Mock category", Count: 1},
-		},
-		RequirementMapping: map[string]string{},
-		Reasoning:          "Mock reasoning",
-	}, nil
+	return tasks.ExecuteCategorizationTask(e.client, ctx, input)
 }
 
 func (e *RealTaskExecutor) ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error) {
-	return &tasks.RequirementGenOutput{
-		Description: "Mock requirement description",
-		Rationale:   "Mock rationale",
-		AcceptanceCriteria: []tasks.AcceptanceCriterionJSON{
-			{
-				Type:      "behavioral",
-				Given:     "Mock precondition",
-				When:      "Mock trigger",
-				Then:      "Mock outcome",
-				Statement: "",
-			},
-		},
-		Priority: "medium",
-	}, nil
+	return tasks.ExecuteRequirementGenTask(e.client, ctx, input)
+}
+
+func (e *RealTaskExecutor) ExecuteReview(ctx context.Context, input *tasks.ReviewInput) (*tasks.ReviewOutput, error) {
+	return tasks.ExecuteReviewTask(e.client, ctx, input)
+}
+
+func (e *RealTaskExecutor) ExecuteConflictAnalysis(ctx context.Context, input *tasks.ConflictInput) (*tasks.ConflictOutput, error) {
+	return tasks.ExecuteConflictTask(e.client, ctx, input)
 }
 
 func (e *RealTaskExecutor) ExecuteVersionBump(ctx context.Context, input *tasks.VersionBumpInput) (*tasks.VersionBumpOutput, error) {
-	return &tasks.VersionBumpOutput{
-		NewVersion: "0.1.0",
-		BumpType:   "minor",
-		Reasoning:  "Mock version bump",
-	}, nil
+	return tasks.ExecuteVersionBumpTask(e.client, ctx, input)
 }
 
 // MockTaskExecutor implements TaskExecutor for testing with canned responses.
@@ -104,18 +87,28 @@ type MockTaskExecutor struct {
 	RequirementsDeltaOutput *tasks.RequirementsDeltaOutput
 	CategorizationOutput    *tasks.CategorizationOutput
 	RequirementGenOutput    *tasks.RequirementGenOutput
+	ReviewOutput            *tasks.ReviewOutput
+	ConflictOutput          *tasks.ConflictOutput
 	VersionBumpOutput       *tasks.VersionBumpOutput
 
 	MetadataError          error
 	RequirementsDeltaError error
 	CategorizationError    error
 	RequirementGenError    error
+	ReviewError            error
+	ConflictError          error
 	VersionBumpError       error
 
+	// RequirementGenErrors fails individual generations, keyed by brief description
+	RequirementGenErrors map[string]error
+
+	mu                     sync.Mutex // Guards call counters during parallel generation and review
 	MetadataCalls          int
 	RequirementsDeltaCalls int
 	CategorizationCalls    int
 	RequirementGenCalls    int
+	ReviewCalls            int
+	ConflictCalls          int
 	VersionBumpCalls       int
 }
 
@@ -187,18 +180,25 @@ func NewMockTaskExecutor() *MockTaskExecutor {
 					Given: "User is on login page",
 					When:  "User submits valid credentials",
 					Then:  "System authenticates and redirects to dashboard",
+
+					VerificationMethod: "test",
 				},
 				{
 					Type:      "assertion",
 					Statement: "Authentication must complete within 2 seconds",
+
+					VerificationMethod: "analysis",
 				},
 			},
 			Priority: "high",
 		},
+		ReviewOutput: &tasks.ReviewOutput{
+			Score:   90,
+			Summary: "Clear, testable and atomic",
+		},
+		ConflictOutput: &tasks.ConflictOutput{},
 		VersionBumpOutput: &tasks.VersionBumpOutput{
-			NewVersion: "0.1.0",
-			BumpType:   "minor",
-			Reasoning:  "Initial version with core features",
+			Reasoning: "Initial version with core features",
 		},
 	}
 }
@@ -228,17 +228,50 @@ func (m *MockTaskExecutor) ExecuteCategorization(ctx context.Context, input *tas
 }
 
 func (m *MockTaskExecutor) ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error) {
+	m.mu.Lock()
 	m.RequirementGenCalls++
+	m.mu.Unlock()
+
 	if m.RequirementGenError != nil {
 		return nil, m.RequirementGenError
 	}
+	if err := m.RequirementGenErrors[input.BriefDescription]; err != nil {
+		return nil, err
+	}
 	return m.RequirementGenOutput, nil
 }
 
+func (m *MockTaskExecutor) ExecuteReview(ctx context.Context, input *tasks.ReviewInput) (*tasks.ReviewOutput, error) {
+	m.mu.Lock()
+	m.ReviewCalls++
+	m.mu.Unlock()
+
+	if m.ReviewError != nil {
+		return nil, m.ReviewError
+	}
+	return m.ReviewOutput, nil
+}
+
+func (m *MockTaskExecutor) ExecuteConflictAnalysis(ctx context.Context, input *tasks.ConflictInput) (*tasks.ConflictOutput, error) {
+	m.ConflictCalls++
+	if m.ConflictError != nil {
+		return nil, m.ConflictError
+	}
+	return m.ConflictOutput, nil
+}
+
 func (m *MockTaskExecutor) ExecuteVersionBump(ctx context.Context, input *tasks.VersionBumpInput) (*tasks.VersionBumpOutput, error) {
 	m.VersionBumpCalls++
 	if m.VersionBumpError != nil {
 		return nil, m.VersionBumpError
 	}
-	return m.VersionBumpOutput, nil
+	// Like a well-behaved model, echo the computed bump unless told otherwise
+	output := *m.VersionBumpOutput
+	if output.NewVersion == "" {
+		output.NewVersion = input.NewVersion
+	}
+	if output.BumpType == "" {
+		output.BumpType = input.BumpType
+	}
+	return &output, nil
 }
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"xdd/pkg/schema"
+)
+
+// Test export formats.
+const (
+	TestsGherkin = "gherkin"
+	TestsJSON    = "json"
+)
+
+// TestCase is one concrete check derived from an acceptance criterion.
+// Scenario outlines arrive expanded, one case per example row.
+type TestCase struct {
+	RequirementID string `json:"requirement_id"`
+	Alias         string `json:"alias,omitempty"`
+	CriterionID   string `json:"criterion_id"`
+	Type          string `json:"type"` // Criterion type; expanded outlines keep "scenario_outline"
+	Given         string `json:"given,omitempty"`
+	When          string `json:"when,omitempty"`
+	Then          string `json:"then,omitempty"` // Also an assertion's statement or a metric's target
+	schema.Verification
+}
+
+// ExpandTestCases lists the test cases for every criterion of every
+// requirement in spec, in specification order.
+func ExpandTestCases(spec *schema.Specification) []TestCase {
+	var cases []TestCase
+	for _, req := range spec.Requirements {
+		for _, ac := range req.AcceptanceCriteria {
+			base := TestCase{
+				RequirementID: req.ID,
+				Alias:         req.Alias,
+				CriterionID:   ac.GetID(),
+				Type:          ac.GetType(),
+				Verification:  ac.GetVerification(),
+			}
+			switch c := ac.(type) {
+			case *schema.BehavioralCriterion:
+				base.Given, base.When, base.Then = c.Given, c.When, c.Then
+				cases = append(cases, base)
+			case *schema.AssertionCriterion:
+				base.Then = c.Statement
+				cases = append(cases, base)
+			case *schema.MetricCriterion:
+				base.Then = c.Expression()
+				cases = append(cases, base)
+			case *schema.ScenarioOutlineCriterion:
+				for _, example := range c.Expand() {
+					tc := base
+					tc.CriterionID = example.ID
+					tc.Given, tc.When, tc.Then = example.Given, example.When, example.Then
+					cases = append(cases, tc)
+				}
+			}
+		}
+	}
+	return cases
+}
+
+// ExportTests renders the test cases for spec as Gherkin features, one
+// per requirement, or as a JSON array.
+func ExportTests(spec *schema.Specification, format string) (string, error) {
+	cases := ExpandTestCases(spec)
+	switch format {
+	case TestsGherkin:
+		return gherkinFeatures(spec, cases), nil
+	case TestsJSON:
+		if cases == nil {
+			cases = []TestCase{}
+		}
+		data, err := json.MarshalIndent(cases, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unknown test format %q (want %s or %s)", format, TestsGherkin, TestsJSON)
+	}
+}
+
+func gherkinFeatures(spec *schema.Specification, cases []TestCase) string {
+	byRequirement := make(map[string][]TestCase)
+	for _, tc := range cases {
+		byRequirement[tc.RequirementID] = append(byRequirement[tc.RequirementID], tc)
+	}
+
+	var b strings.Builder
+	for _, req := range spec.Requirements {
+		reqCases := byRequirement[req.ID]
+		if len(reqCases) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		name := req.Alias
+		if name == "" {
+			name = req.ID
+		}
+		fmt.Fprintf(&b, "@%s\nFeature: %s %s\n", req.ID, name, oneLine(req.Description))
+		for _, tc := range reqCases {
+			fmt.Fprintf(&b, "\n  @%s\n  Scenario: %s\n", tc.Type, tc.CriterionID)
+			if tc.Given != "" {
+				fmt.Fprintf(&b, "    Given %s\n", oneLine(tc.Given))
+			}
+			if tc.When != "" {
+				fmt.Fprintf(&b, "    When %s\n", oneLine(tc.When))
+			}
+			fmt.Fprintf(&b, "    Then %s\n", oneLine(tc.Then))
+		}
+	}
+	return b.String()
+}
+
+// oneLine folds line breaks, which would end a Gherkin step early.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
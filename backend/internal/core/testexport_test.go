@@ -0,0 +1,94 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func criteriaSpec() *schema.Specification {
+	return &schema.Specification{
+		Requirements: []schema.Requirement{
+			{ID: "REQ-AUTH-aaa", Alias: "AUTH-001", Category: "AUTH", Description: "Admins manage users",
+				AcceptanceCriteria: []schema.AcceptanceCriterion{
+					&schema.BehavioralCriterion{ID: "AC-1", Type: "behavioral", Given: "an admin", When: "they add a user", Then: "the user can log in"},
+					&schema.ScenarioOutlineCriterion{ID: "AC-2", Type: "scenario_outline",
+						Given: "a user with role <role>", When: "they open /admin", Then: "the response is <status>",
+						Examples: schema.ExamplesTable{Columns: []string{"role", "status"}, Rows: [][]string{{"admin", "200"}, {"guest", "403"}}}},
+				}},
+			{ID: "REQ-SEARCH-bbb", Category: "SEARCH", Description: "Search is fast",
+				AcceptanceCriteria: []schema.AcceptanceCriterion{
+					&schema.AssertionCriterion{ID: "AC-3", Type: "assertion", Statement: "Results are ranked"},
+					&schema.MetricCriterion{ID: "AC-4", Type: "metric", Measure: "p95 latency", Comparator: "<", Threshold: 200, Unit: "ms"},
+				}},
+			{ID: "REQ-SEARCH-ccc", Category: "SEARCH", Description: "No criteria yet"},
+		},
+	}
+}
+
+func TestExpandTestCases(t *testing.T) {
+	cases := ExpandTestCases(criteriaSpec())
+	require.Len(t, cases, 5)
+	assert.Equal(t, TestCase{
+		RequirementID: "REQ-AUTH-aaa", Alias: "AUTH-001", CriterionID: "AC-2.2", Type: "scenario_outline",
+		Given: "a user with role guest", When: "they open /admin", Then: "the response is 403",
+	}, cases[2])
+	assert.Equal(t, "p95 latency < 200 ms", cases[4].Then)
+}
+
+func TestExportTests_Gherkin(t *testing.T) {
+	out, err := ExportTests(criteriaSpec(), TestsGherkin)
+	require.NoError(t, err)
+
+	assert.Equal(t, `@REQ-AUTH-aaa
+Feature: AUTH-001 Admins manage users
+
+  @behavioral
+  Scenario: AC-1
+    Given an admin
+    When they add a user
+    Then the user can log in
+
+  @scenario_outline
+  Scenario: AC-2.1
+    Given a user with role admin
+    When they open /admin
+    Then the response is 200
+
+  @scenario_outline
+  Scenario: AC-2.2
+    Given a user with role guest
+    When they open /admin
+    Then the response is 403
+
+@REQ-SEARCH-bbb
+Feature: REQ-SEARCH-bbb Search is fast
+
+  @assertion
+  Scenario: AC-3
+    Then Results are ranked
+
+  @metric
+  Scenario: AC-4
+    Then p95 latency < 200 ms
+`, out)
+}
+
+func TestExportTests_JSON(t *testing.T) {
+	out, err := ExportTests(criteriaSpec(), TestsJSON)
+	require.NoError(t, err)
+	var cases []TestCase
+	require.NoError(t, json.Unmarshal([]byte(out), &cases))
+	assert.Len(t, cases, 5)
+
+	out, err = ExportTests(&schema.Specification{}, TestsJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", out)
+
+	_, err = ExportTests(criteriaSpec(), "junit")
+	assert.ErrorContains(t, err, `unknown test format "junit"`)
+}
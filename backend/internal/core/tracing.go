@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/telemetry"
+	"xdd/pkg/schema"
+)
+
+// tracingExecutor wraps a TaskExecutor with one span per task execution.
+type tracingExecutor struct {
+	next TaskExecutor
+}
+
+// withTracing wraps executor unless it is already traced.
+func withTracing(executor TaskExecutor) TaskExecutor {
+	if _, ok := executor.(*tracingExecutor); ok || executor == nil {
+		return executor
+	}
+	return &tracingExecutor{next: executor}
+}
+
+// PromptHashes forwards to the wrapped executor, if it renders templates.
+func (t *tracingExecutor) PromptHashes() map[string]string {
+	if hasher, ok := t.next.(PromptHasher); ok {
+		return hasher.PromptHashes()
+	}
+	return nil
+}
+
+// traceTask runs fn inside a span named after the task.
+func traceTask[T any](ctx context.Context, task string, fn func(context.Context) (*T, error)) (*T, error) {
+	ctx, span := telemetry.Start(ctx, "task."+task, telemetry.AttrTask.String(task))
+	output, err := fn(ctx)
+	telemetry.End(span, err)
+	return output, err
+}
+
+func (t *tracingExecutor) ExecuteMetadata(ctx context.Context, input *tasks.MetadataInput) (*tasks.MetadataOutput, error) {
+	return traceTask(ctx, tasks.TaskMetadata, func(ctx context.Context) (*tasks.MetadataOutput, error) {
+		return t.next.ExecuteMetadata(ctx, input)
+	})
+}
+
+func (t *tracingExecutor) ExecuteRequirementsDelta(ctx context.Context, input *tasks.RequirementsDeltaInput) (*tasks.RequirementsDeltaOutput, error) {
+	return traceTask(ctx, tasks.TaskRequirementsDelta, func(ctx context.Context) (*tasks.RequirementsDeltaOutput, error) {
+		return t.next.ExecuteRequirementsDelta(ctx, input)
+	})
+}
+
+func (t *tracingExecutor) ExecuteCategorization(ctx context.Context, input *tasks.CategorizationInput) (*tasks.CategorizationOutput, error) {
+	return traceTask(ctx, tasks.TaskCategorization, func(ctx context.Context) (*tasks.CategorizationOutput, error) {
+		return t.next.ExecuteCategorization(ctx, input)
+	})
+}
+
+func (t *tracingExecutor) ExecuteRequirementGen(ctx context.Context, input *tasks.RequirementGenInput) (*tasks.RequirementGenOutput, error) {
+	return traceTask(ctx, tasks.TaskRequirementGen, func(ctx context.Context) (*tasks.RequirementGenOutput, error) {
+		return t.next.ExecuteRequirementGen(ctx, input)
+	})
+}
+
+func (t *tracingExecutor) ExecuteReview(ctx context.Context, input *tasks.ReviewInput) (*tasks.ReviewOutput, error) {
+	return traceTask(ctx, tasks.TaskReview, func(ctx context.Context) (*tasks.ReviewOutput, error) {
+		return t.next.ExecuteReview(ctx, input)
+	})
+}
+
+func (t *tracingExecutor) ExecuteConflictAnalysis(ctx context.Context, input *tasks.ConflictInput) (*tasks.ConflictOutput, error) {
+	return traceTask(ctx, tasks.TaskConflicts, func(ctx context.Context) (*tasks.ConflictOutput, error) {
+		return t.next.ExecuteConflictAnalysis(ctx, input)
+	})
+}
+
+func (t *tracingExecutor) ExecuteVersionBump(ctx context.Context, input *tasks.VersionBumpInput) (*tasks.VersionBumpOutput, error) {
+	return traceTask(ctx, tasks.TaskVersionBump, func(ctx context.Context) (*tasks.VersionBumpOutput, error) {
+		return t.next.ExecuteVersionBump(ctx, input)
+	})
+}
+
+// recordProposal annotates a ProcessPrompt span with what the turn proposed.
+func recordProposal(span trace.Span, state *SessionState) {
+	added, removed := 0, 0
+	for _, event := range state.PendingChangelog {
+		switch event.(type) {
+		case *schema.RequirementAdded:
+			added++
+		case *schema.RequirementDeleted:
+			removed++
+		}
+	}
+	span.SetAttributes(
+		telemetry.AttrEventCount.Int(len(state.PendingChangelog)),
+		telemetry.AttrRequirementsAdded.Int(added),
+		telemetry.AttrRequirementsRemoved.Int(removed),
+		telemetry.AttrConflicts.Int(len(state.Conflicts)),
+		telemetry.AttrGenerationFailures.Int(len(state.GenerationFailures)),
+	)
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"xdd/internal/llm/tasks"
+	"xdd/internal/telemetry"
+	"xdd/internal/telemetry/telemetrytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spanAttrs flattens a recorded span's attributes for assertions.
+func spanAttrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestOrchestrator_ProcessPrompt_Tracing(t *testing.T) {
+	exporter, restore := telemetrytest.UseInMemoryExporter()
+	defer restore()
+
+	repo, _ := createTestRepository(t)
+	orch := NewOrchestrator(NewMockTaskExecutor(), repo)
+
+	state, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task manager")
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	var root tracetest.SpanStub
+	taskSpans := map[string]int{}
+	for _, s := range spans {
+		if s.Name == "ProcessPrompt" {
+			root = s
+		}
+	}
+	require.Equal(t, "ProcessPrompt", root.Name)
+
+	for _, s := range spans {
+		if s.Name == "ProcessPrompt" {
+			continue
+		}
+		assert.Equal(t, root.SpanContext.SpanID(), s.Parent.SpanID(), "%s should be a child of ProcessPrompt", s.Name)
+		taskSpans[spanAttrs(s)[telemetry.AttrTask].AsString()]++
+	}
+	assert.Equal(t, map[string]int{
+		tasks.TaskMetadata:          1,
+		tasks.TaskRequirementsDelta: 1,
+		tasks.TaskCategorization:    1,
+		tasks.TaskRequirementGen:    2,
+		tasks.TaskReview:            2,
+		tasks.TaskConflicts:         1,
+		tasks.TaskVersionBump:       1,
+	}, taskSpans)
+
+	attrs := spanAttrs(root)
+	assert.Equal(t, int64(len(state.PendingChangelog)), attrs[telemetry.AttrEventCount].AsInt64())
+	assert.Equal(t, int64(2), attrs[telemetry.AttrRequirementsAdded].AsInt64())
+	assert.Equal(t, int64(0), attrs[telemetry.AttrRequirementsRemoved].AsInt64())
+	assert.Equal(t, int64(0), attrs[telemetry.AttrConflicts].AsInt64())
+}
+
+func TestOrchestrator_ProcessPrompt_TracesTaskError(t *testing.T) {
+	exporter, restore := telemetrytest.UseInMemoryExporter()
+	defer restore()
+
+	repo, _ := createTestRepository(t)
+	mock := NewMockTaskExecutor()
+	mock.CategorizationError = errors.New("model unavailable")
+	orch := NewOrchestrator(mock, repo)
+
+	_, err := orch.ProcessPrompt(context.Background(), NewSessionState(), "Build a task manager")
+	require.Error(t, err)
+
+	status := map[string]codes.Code{}
+	for _, s := range exporter.GetSpans() {
+		status[s.Name] = s.Status.Code
+	}
+	assert.Equal(t, codes.Error, status["task."+tasks.TaskCategorization])
+	assert.Equal(t, codes.Error, status["ProcessPrompt"])
+	assert.Equal(t, codes.Unset, status["task."+tasks.TaskMetadata])
+}
+
+func TestWithTracing_ForwardsPromptHashes(t *testing.T) {
+	assert.Nil(t, withTracing(NewMockTaskExecutor()).(PromptHasher).PromptHashes())
+
+	traced := withTracing(NewMockTaskExecutor())
+	assert.Same(t, traced, withTracing(traced), "already traced executors are not wrapped twice")
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"xdd/pkg/schema"
+)
+
+// VerificationEntry is one row of the verification matrix: a criterion,
+// the requirement it belongs to, and how it is verified.
+type VerificationEntry struct {
+	Requirement schema.Requirement
+	CriterionID string
+	Type        string
+	Outcome     string // What the criterion expects, in one line
+	schema.Verification
+}
+
+// VerificationMatrix lists every acceptance criterion in spec, most urgent
+// requirement first and in specification order within a priority.
+func VerificationMatrix(spec *schema.Specification) []VerificationEntry {
+	var entries []VerificationEntry
+	for _, req := range spec.Requirements {
+		for _, ac := range req.AcceptanceCriteria {
+			entries = append(entries, VerificationEntry{
+				Requirement:  req,
+				CriterionID:  ac.GetID(),
+				Type:         ac.GetType(),
+				Outcome:      criterionOutcome(ac),
+				Verification: ac.GetVerification(),
+			})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b VerificationEntry) int {
+		return priorityRank(a.Requirement.Priority) - priorityRank(b.Requirement.Priority)
+	})
+	return entries
+}
+
+// priorityRank orders priorities most urgent first, unknown ones last.
+func priorityRank(p schema.Priority) int {
+	if i := slices.Index(schema.Priorities, p); i >= 0 {
+		return i
+	}
+	return len(schema.Priorities)
+}
+
+// criterionOutcome is the part of a criterion a verifier checks.
+func criterionOutcome(ac schema.AcceptanceCriterion) string {
+	switch c := ac.(type) {
+	case *schema.BehavioralCriterion:
+		return c.Then
+	case *schema.AssertionCriterion:
+		return c.Statement
+	case *schema.MetricCriterion:
+		return c.Expression()
+	case *schema.ScenarioOutlineCriterion:
+		return fmt.Sprintf("%s (%d examples)", c.Then, len(c.Examples.Rows))
+	default:
+		return ac.GetType()
+	}
+}
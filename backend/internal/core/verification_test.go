@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationMatrix(t *testing.T) {
+	spec := criteriaSpec()
+	spec.Requirements[0].Priority = schema.PriorityLow
+	spec.Requirements[1].Priority = schema.PriorityCritical
+	spec.Requirements[1].AcceptanceCriteria[0].(*schema.AssertionCriterion).Verification = schema.Verification{
+		Method: schema.VerificationInspection, Ref: "search-review.md",
+	}
+
+	entries := VerificationMatrix(spec)
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.CriterionID)
+	}
+	assert.Equal(t, []string{"AC-3", "AC-4", "AC-1", "AC-2"}, ids, "critical requirement first, spec order within")
+	assert.True(t, entries[0].Verified())
+	assert.False(t, entries[1].Verified())
+	assert.Equal(t, "p95 latency < 200 ms", entries[1].Outcome)
+	assert.Equal(t, "the response is <status> (2 examples)", entries[3].Outcome)
+}
@@ -0,0 +1,154 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+
+	"xdd/internal/core"
+	"xdd/pkg/schema"
+)
+
+// CheckResult is the outcome of one deterministic expectation.
+type CheckResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"` // What was observed, when it failed
+}
+
+// proposal is the part of a session state the checks look at.
+type proposal struct {
+	added     []schema.Requirement
+	removed   map[string]bool
+	bump      string
+	conflicts int
+	questions int
+}
+
+func newProposal(state *core.SessionState) proposal {
+	p := proposal{
+		removed:   make(map[string]bool),
+		conflicts: len(state.Conflicts),
+		questions: len(state.PendingAmbiguities),
+	}
+	for _, event := range state.PendingChangelog {
+		switch e := event.(type) {
+		case *schema.RequirementAdded:
+			p.added = append(p.added, e.Requirement)
+		case *schema.RequirementDeleted:
+			p.removed[e.RequirementID] = true
+		case *schema.VersionBumped:
+			p.bump = e.BumpType
+		}
+	}
+	return p
+}
+
+// Check scores state, the result of running s.Prompt, against s.Expect.
+// Checks are returned in a stable order: clarification, added, removed,
+// kept, mentions, bump, conflicts.
+func Check(s *Scenario, state *core.SessionState) []CheckResult {
+	p := newProposal(state)
+	exp := s.Expect
+	var results []CheckResult
+
+	if exp.Clarification {
+		results = append(results, CheckResult{
+			Name:   "asks for clarification",
+			Passed: p.questions > 0,
+			Detail: "proposed changes without asking",
+		})
+	}
+
+	for _, add := range exp.Added {
+		count := 0
+		for _, req := range p.added {
+			if add.Category == "" || schema.CategoryWithin(strings.ToUpper(req.Category), strings.ToUpper(add.Category)) {
+				count++
+			}
+		}
+		passed := count >= add.Min && (add.Max == nil || count <= *add.Max)
+		results = append(results, CheckResult{
+			Name:   addedName(add),
+			Passed: passed,
+			Detail: fmt.Sprintf("added %d", count),
+		})
+	}
+
+	for _, id := range exp.Removed {
+		results = append(results, CheckResult{
+			Name:   "removes " + id,
+			Passed: p.removed[id],
+			Detail: "not removed",
+		})
+	}
+
+	for _, id := range exp.Kept {
+		results = append(results, CheckResult{
+			Name:   "keeps " + id,
+			Passed: !p.removed[id],
+			Detail: "removed",
+		})
+	}
+
+	for _, term := range exp.Mentions {
+		results = append(results, CheckResult{
+			Name:   fmt.Sprintf("mentions %q", term),
+			Passed: mentions(p.added, term),
+			Detail: "no added requirement mentions it",
+		})
+	}
+
+	if exp.Bump != "" {
+		results = append(results, CheckResult{
+			Name:   exp.Bump + " bump",
+			Passed: p.bump == exp.Bump,
+			Detail: fmt.Sprintf("bump was %q", p.bump),
+		})
+	}
+
+	if exp.MaxConflicts != nil {
+		results = append(results, CheckResult{
+			Name:   fmt.Sprintf("at most %d conflict(s)", *exp.MaxConflicts),
+			Passed: p.conflicts <= *exp.MaxConflicts,
+			Detail: fmt.Sprintf("%d conflict(s)", p.conflicts),
+		})
+	}
+
+	// Details explain failures only
+	for i := range results {
+		if results[i].Passed {
+			results[i].Detail = ""
+		} else if p.questions > 0 && !exp.Clarification {
+			results[i].Detail += "; asked for clarification instead"
+		}
+	}
+	return results
+}
+
+func addedName(add AddedExpectation) string {
+	what := "requirement(s)"
+	if add.Category != "" {
+		what = add.Category + " " + what
+	}
+	switch {
+	case add.Max == nil:
+		return fmt.Sprintf("adds >= %d %s", add.Min, what)
+	case *add.Max == add.Min:
+		return fmt.Sprintf("adds %d %s", add.Min, what)
+	default:
+		return fmt.Sprintf("adds %d-%d %s", add.Min, *add.Max, what)
+	}
+}
+
+// mentions reports whether any requirement's description or rationale
+// contains term, ignoring case.
+func mentions(requirements []schema.Requirement, term string) bool {
+	term = strings.ToLower(term)
+	for _, req := range requirements {
+		if strings.Contains(strings.ToLower(req.Description), term) ||
+			strings.Contains(strings.ToLower(req.Rationale), term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package eval
+
+import (
+	"testing"
+
+	"xdd/internal/core"
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func proposedState() *core.SessionState {
+	state := core.NewSessionState()
+	state.PendingChangelog = []schema.ChangelogEvent{
+		&schema.RequirementAdded{Requirement: schema.Requirement{
+			ID: "REQ-AUTH-1", Category: "AUTH", Description: "When a user submits a password, the system shall verify it",
+		}},
+		&schema.RequirementAdded{Requirement: schema.Requirement{
+			ID: "REQ-AUTH-2", Category: "AUTH", Description: "The system shall lock accounts after 5 failures",
+			Rationale: "Limits brute force attacks",
+		}},
+		&schema.RequirementDeleted{RequirementID: "REQ-OLD"},
+		&schema.VersionBumped{OldVersion: "1.0.0", NewVersion: "2.0.0", BumpType: "major"},
+	}
+	state.Conflicts = []tasks.Conflict{{Severity: "minor"}}
+	return state
+}
+
+func TestCheck(t *testing.T) {
+	one, two := 1, 2
+	s := &Scenario{Expect: Expectations{
+		Added: []AddedExpectation{
+			{Category: "auth", Min: 2},
+			{Category: "TASKS", Min: 1},
+			{Min: 1, Max: &one},
+		},
+		Removed:      []string{"REQ-OLD", "REQ-OTHER"},
+		Kept:         []string{"REQ-OTHER", "REQ-OLD"},
+		Mentions:     []string{"Brute Force", "passkey"},
+		Bump:         "major",
+		MaxConflicts: &two,
+	}}
+
+	results := Check(s, proposedState())
+
+	assert.Equal(t, []CheckResult{
+		{Name: "adds >= 2 auth requirement(s)", Passed: true},
+		{Name: "adds >= 1 TASKS requirement(s)", Detail: "added 0"},
+		{Name: "adds 1 requirement(s)", Detail: "added 2"},
+		{Name: "removes REQ-OLD", Passed: true},
+		{Name: "removes REQ-OTHER", Detail: "not removed"},
+		{Name: "keeps REQ-OTHER", Passed: true},
+		{Name: "keeps REQ-OLD", Detail: "removed"},
+		{Name: `mentions "Brute Force"`, Passed: true},
+		{Name: `mentions "passkey"`, Detail: "no added requirement mentions it"},
+		{Name: "major bump", Passed: true},
+		{Name: "at most 2 conflict(s)", Passed: true},
+	}, results)
+}
+
+func TestCheck_Clarification(t *testing.T) {
+	asked := core.NewSessionState()
+	asked.PendingAmbiguities = []core.Ambiguity{{Clarification: "Which export?"}}
+
+	s := &Scenario{Expect: Expectations{Clarification: true}}
+	assert.Equal(t, []CheckResult{{Name: "asks for clarification", Passed: true}}, Check(s, asked))
+	assert.Equal(t, []CheckResult{{Name: "asks for clarification", Detail: "proposed changes without asking"}},
+		Check(s, proposedState()))
+
+	// Questions where a proposal was expected explain the failures
+	s = &Scenario{Expect: Expectations{Bump: "minor"}}
+	assert.Equal(t, []CheckResult{{Name: "minor bump", Detail: `bump was ""; asked for clarification instead`}},
+		Check(s, asked))
+}
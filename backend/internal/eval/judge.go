@@ -0,0 +1,150 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"xdd/internal/core"
+	"xdd/internal/llm"
+	"xdd/internal/llm/tasks"
+	"xdd/pkg/schema"
+)
+
+// JudgeTask names judge calls for model routing, caching and logs.
+const JudgeTask = tasks.TaskEvalJudge
+
+// PassingScore is the lowest judge score (out of 5) that counts as a pass.
+const PassingScore = 4
+
+// Verdict is a judge's grade for one rubric.
+type Verdict struct {
+	Rubric    string `json:"rubric"`
+	Score     int    `json:"score"` // 1-5
+	Reasoning string `json:"reasoning"`
+}
+
+// Passed reports whether the score reaches PassingScore.
+func (v Verdict) Passed() bool {
+	return v.Score >= PassingScore
+}
+
+// Judge grades a proposal against a free-text rubric.
+type Judge interface {
+	Grade(ctx context.Context, s *Scenario, rubric, proposal string) (*Verdict, error)
+}
+
+// LLMJudge grades with a model, independent of the model under test.
+type LLMJudge struct {
+	client *llm.Client
+	model  string // "" uses the route for JudgeTask, or the default model
+}
+
+// NewLLMJudge creates a judge that calls model through client.
+func NewLLMJudge(client *llm.Client, model string) *LLMJudge {
+	return &LLMJudge{client: client, model: model}
+}
+
+// judgeOutput is the structured response expected from the judge model.
+type judgeOutput struct {
+	Score     int    `json:"score"`
+	Reasoning string `json:"reasoning"`
+}
+
+// Grade asks the model to score the proposal against rubric.
+func (j *LLMJudge) Grade(ctx context.Context, s *Scenario, rubric, proposal string) (*Verdict, error) {
+	out, err := llm.GenerateStructured[judgeOutput](
+		j.client,
+		llm.WithTask(ctx, JudgeTask),
+		j.model,
+		judgePrompt(s, rubric, proposal),
+		validateJudge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("judge: %w", err)
+	}
+	return &Verdict{Rubric: rubric, Score: out.Score, Reasoning: out.Reasoning}, nil
+}
+
+func validateJudge(out *judgeOutput) error {
+	if out.Score < 1 || out.Score > 5 {
+		return fmt.Errorf("score must be 1-5, got %d", out.Score)
+	}
+	if strings.TrimSpace(out.Reasoning) == "" {
+		return fmt.Errorf("reasoning is required")
+	}
+	return nil
+}
+
+func judgePrompt(s *Scenario, rubric, proposal string) string {
+	return fmt.Sprintf(`You are grading the output of a requirements engineering assistant.
+
+The user asked:
+%s
+
+The assistant proposed:
+%s
+
+Grade the proposal against this rubric:
+%s
+
+Score from 1 (fails the rubric) to 5 (fully satisfies it). Judge only the rubric, not style.
+
+Return ONLY valid JSON with this structure:
+{"score": 1-5, "reasoning": "one or two sentences"}`, s.Prompt, proposal, rubric)
+}
+
+// describeProposal renders a session state for the judge.
+func describeProposal(state *core.SessionState) string {
+	if len(state.PendingAmbiguities) > 0 {
+		lines := []string{"Clarifying questions instead of changes:"}
+		for _, amb := range state.PendingAmbiguities {
+			lines = append(lines, "- "+amb.Clarification)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	var lines []string
+	for _, event := range state.PendingChangelog {
+		switch e := event.(type) {
+		case *schema.RequirementAdded:
+			req := e.Requirement
+			lines = append(lines, fmt.Sprintf("Add %s [%s, %s, %s]: %s", req.ID, req.Category, req.Type, req.Priority, req.Description))
+			lines = append(lines, "  Rationale: "+req.Rationale)
+			for _, ac := range req.AcceptanceCriteria {
+				lines = append(lines, "  Criterion: "+describeCriterion(ac))
+			}
+		case *schema.RequirementDeleted:
+			lines = append(lines, fmt.Sprintf("Remove %s: %s", e.RequirementID, e.Requirement.Description))
+		case *schema.ProjectMetadataUpdated:
+			lines = append(lines, fmt.Sprintf("Project: %s - %s", e.NewMetadata.Name, e.NewMetadata.Description))
+		case *schema.VersionBumped:
+			lines = append(lines, fmt.Sprintf("Version %s -> %s (%s)", e.OldVersion, e.NewVersion, e.BumpType))
+		}
+	}
+	if len(lines) == 0 {
+		return "(no changes)"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describeCriterion renders an acceptance criterion as a single line.
+func describeCriterion(ac schema.AcceptanceCriterion) string {
+	var line string
+	switch c := ac.(type) {
+	case *schema.BehavioralCriterion:
+		line = fmt.Sprintf("Given %s, when %s, then %s", c.Given, c.When, c.Then)
+	case *schema.AssertionCriterion:
+		line = c.Statement
+	case *schema.MetricCriterion:
+		line = c.Expression()
+	case *schema.ScenarioOutlineCriterion:
+		line = fmt.Sprintf("Given %s, when %s, then %s (examples %s)", c.Given, c.When, c.Then, c.Examples)
+	default:
+		line = ac.GetType()
+	}
+	if v := ac.GetVerification(); v.Method != "" {
+		line += " [verified by " + v.String() + "]"
+	}
+	return line
+}
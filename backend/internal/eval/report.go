@@ -0,0 +1,201 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Report compares scenario results across variants.
+type Report struct {
+	Scenarios []string         `json:"scenarios"`
+	Variants  []VariantSummary `json:"variants"`
+	Results   []Result         `json:"results"`
+}
+
+// VariantSummary aggregates one variant's results.
+type VariantSummary struct {
+	Name           string        `json:"name"`
+	Model          string        `json:"model,omitempty"`
+	PromptVersion  string        `json:"prompt_version,omitempty"`
+	Passed         int           `json:"passed"`
+	Scenarios      int           `json:"scenarios"`
+	ChecksPassed   int           `json:"checks_passed"`
+	Checks         int           `json:"checks"`
+	Errors         int           `json:"errors"`
+	MeanJudgeScore float64       `json:"mean_judge_score,omitempty"` // Over every verdict; 0 when unjudged
+	Verdicts       int           `json:"verdicts"`
+	Duration       time.Duration `json:"duration_ns"`
+}
+
+func (r *Report) summarize() {
+	for i := range r.Variants {
+		sum := &r.Variants[i]
+		var judged int
+		for _, res := range r.Results {
+			if res.Variant != sum.Name {
+				continue
+			}
+			sum.Scenarios++
+			if res.Passed() {
+				sum.Passed++
+			}
+			if res.Err != "" {
+				sum.Errors++
+			}
+			sum.ChecksPassed += res.ChecksPassed()
+			sum.Checks += len(res.Checks)
+			for _, v := range res.Verdicts {
+				judged += v.Score
+				sum.Verdicts++
+			}
+			sum.Duration += res.Duration
+		}
+		if sum.Verdicts > 0 {
+			sum.MeanJudgeScore = float64(judged) / float64(sum.Verdicts)
+		}
+	}
+}
+
+// Result returns the result for a scenario under a variant, or nil.
+func (r *Report) Result(scenario, variant string) *Result {
+	for i := range r.Results {
+		if r.Results[i].Scenario == scenario && r.Results[i].Variant == variant {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// Failed reports whether any scenario failed under any variant.
+func (r *Report) Failed() bool {
+	for _, res := range r.Results {
+		if !res.Passed() {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the full report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes a scenario-by-variant comparison table followed by the
+// reason for every failure.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := []string{"SCENARIO"}
+	for _, v := range r.Variants {
+		header = append(header, v.Name)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, scenario := range r.Scenarios {
+		row := []string{scenario}
+		for _, v := range r.Variants {
+			row = append(row, r.cell(scenario, v.Name))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	summaryRows := []struct {
+		label string
+		value func(VariantSummary) string
+	}{
+		{"Passed", func(s VariantSummary) string { return fmt.Sprintf("%d/%d", s.Passed, s.Scenarios) }},
+		{"Checks", func(s VariantSummary) string {
+			return fmt.Sprintf("%d/%d (%s)", s.ChecksPassed, s.Checks, percent(s.ChecksPassed, s.Checks))
+		}},
+		{"Judge (mean /5)", func(s VariantSummary) string {
+			if s.Verdicts == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", s.MeanJudgeScore)
+		}},
+		{"Prompts", func(s VariantSummary) string { return orDash(s.PromptVersion) }},
+		{"Time", func(s VariantSummary) string { return s.Duration.Round(100 * time.Millisecond).String() }},
+	}
+	for _, row := range summaryRows {
+		cells := []string{row.label}
+		for _, v := range r.Variants {
+			cells = append(cells, row.value(v))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, res := range r.Results {
+		prefix := fmt.Sprintf("  %s [%s]: ", res.Scenario, res.Variant)
+		if res.Err != "" {
+			failures = append(failures, prefix+"error: "+res.Err)
+		}
+		for _, c := range res.Checks {
+			if !c.Passed {
+				failures = append(failures, prefix+c.Name+": "+c.Detail)
+			}
+		}
+		for _, v := range res.Verdicts {
+			if !v.Passed() {
+				failures = append(failures, fmt.Sprintf("%sjudge %d/5 on %q: %s", prefix, v.Score, v.Rubric, v.Reasoning))
+			}
+		}
+		for _, e := range res.JudgeErrors {
+			failures = append(failures, prefix+"judge error: "+e)
+		}
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(w, "\nFailures:\n%s\n", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// cell renders one scenario result: a pass mark, checks passed and the
+// mean judge score, e.g. "✓ 4/4 j4.5".
+func (r *Report) cell(scenario, variant string) string {
+	res := r.Result(scenario, variant)
+	if res == nil {
+		return "-"
+	}
+	if res.Err != "" {
+		return "✗ error"
+	}
+
+	mark := "✗"
+	if res.Passed() {
+		mark = "✓"
+	}
+	cell := fmt.Sprintf("%s %d/%d", mark, res.ChecksPassed(), len(res.Checks))
+	if len(res.Verdicts) > 0 {
+		total := 0
+		for _, v := range res.Verdicts {
+			total += v.Score
+		}
+		cell += fmt.Sprintf(" j%.1f", float64(total)/float64(len(res.Verdicts)))
+	}
+	return cell
+}
+
+func percent(n, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", 100*float64(n)/float64(total))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleReport() *Report {
+	report := &Report{
+		Scenarios: []string{"login", "export"},
+		Variants: []VariantSummary{
+			{Name: "model-a", PromptVersion: "abc123"},
+			{Name: "model-b"},
+		},
+		Results: []Result{
+			{Scenario: "login", Variant: "model-a", Duration: time.Second,
+				Checks:   []CheckResult{{Name: "minor bump", Passed: true}},
+				Verdicts: []Verdict{{Rubric: "testable", Score: 5}, {Rubric: "atomic", Score: 4}}},
+			{Scenario: "export", Variant: "model-a", Duration: time.Second,
+				Checks: []CheckResult{{Name: "removes REQ-X", Passed: true}}},
+			{Scenario: "login", Variant: "model-b", Duration: time.Second,
+				Checks:   []CheckResult{{Name: "minor bump", Detail: `bump was "major"`}},
+				Verdicts: []Verdict{{Rubric: "testable", Score: 2, Reasoning: "vague criteria"}}},
+			{Scenario: "export", Variant: "model-b", Err: "categorization task: timeout"},
+		},
+	}
+	report.summarize()
+	return report
+}
+
+func TestReport_WriteText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, sampleReport().WriteText(&buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "SCENARIO         model-a     model-b\n")
+	assert.Contains(t, out, "login            ✓ 1/1 j4.5  ✗ 0/1 j2.0\n")
+	assert.Contains(t, out, "export           ✓ 1/1       ✗ error\nPassed           2/2         0/2\n")
+	assert.Contains(t, out, "Prompts          abc123")
+	assert.Contains(t, out, `login [model-b]: minor bump: bump was "major"`)
+	assert.Contains(t, out, `login [model-b]: judge 2/5 on "testable": vague criteria`)
+	assert.Contains(t, out, "export [model-b]: error: categorization task: timeout")
+}
+
+func TestReport_Summaries(t *testing.T) {
+	report := sampleReport()
+	a, b := report.Variants[0], report.Variants[1]
+
+	assert.Equal(t, 2, a.Passed)
+	assert.Equal(t, 2, a.Checks)
+	assert.Equal(t, 4.5, a.MeanJudgeScore)
+	assert.Equal(t, 2*time.Second, a.Duration)
+
+	assert.Equal(t, 0, b.Passed)
+	assert.Equal(t, 1, b.Errors)
+	assert.Equal(t, 2.0, b.MeanJudgeScore)
+	assert.True(t, report.Failed())
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, sampleReport().WriteJSON(&buf))
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, sampleReport(), &decoded)
+}
@@ -0,0 +1,179 @@
+package eval
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"xdd/internal/core"
+	"xdd/internal/repository"
+	"xdd/internal/telemetry"
+	"xdd/internal/versioning"
+)
+
+// Variant is one configuration under test, typically a model paired with a
+// prompt set.
+type Variant struct {
+	Name          string // Label in reports, e.g. "anthropic/claude-3.5-sonnet@embedded"
+	Model         string
+	PromptVersion string // See PromptVersion; filled from the executor when empty
+	Executor      core.TaskExecutor
+}
+
+// PromptVersion condenses per-template hashes into one short hash, so runs
+// with identical prompts share a version.
+func PromptVersion(hashes map[string]string) string {
+	if len(hashes) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\n", name, hashes[name])
+	}
+	return hex.EncodeToString(h.Sum(nil)[:6])
+}
+
+// Runner runs scenarios through Orchestrator.ProcessPrompt and scores them.
+type Runner struct {
+	Judge      Judge // nil skips rubrics
+	Generation core.GenerationOptions
+	Versioning versioning.Policy
+}
+
+// Result is one scenario run under one variant.
+type Result struct {
+	Scenario    string        `json:"scenario"`
+	Variant     string        `json:"variant"`
+	Checks      []CheckResult `json:"checks"`
+	Verdicts    []Verdict     `json:"verdicts,omitempty"`
+	JudgeErrors []string      `json:"judge_errors,omitempty"`
+	Err         string        `json:"error,omitempty"` // Pipeline failure; nothing was scored
+	Duration    time.Duration `json:"duration_ns"`
+}
+
+// Passed reports whether the run succeeded and met every check and rubric.
+func (r Result) Passed() bool {
+	if r.Err != "" || len(r.JudgeErrors) > 0 {
+		return false
+	}
+	return r.ChecksPassed() == len(r.Checks) && r.VerdictsPassed() == len(r.Verdicts)
+}
+
+// ChecksPassed counts the deterministic checks that passed.
+func (r Result) ChecksPassed() int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// VerdictsPassed counts the rubrics graded at or above PassingScore.
+func (r Result) VerdictsPassed() int {
+	n := 0
+	for _, v := range r.Verdicts {
+		if v.Passed() {
+			n++
+		}
+	}
+	return n
+}
+
+// Run runs every scenario under every variant, variants in order.
+func (r *Runner) Run(ctx context.Context, scenarios []*Scenario, variants []Variant) *Report {
+	report := &Report{}
+	for _, s := range scenarios {
+		report.Scenarios = append(report.Scenarios, s.Name)
+	}
+
+	for _, v := range variants {
+		if v.PromptVersion == "" {
+			if hasher, ok := v.Executor.(core.PromptHasher); ok {
+				v.PromptVersion = PromptVersion(hasher.PromptHashes())
+			}
+		}
+		report.Variants = append(report.Variants, VariantSummary{
+			Name:          v.Name,
+			Model:         v.Model,
+			PromptVersion: v.PromptVersion,
+		})
+		for _, s := range scenarios {
+			report.Results = append(report.Results, r.RunScenario(ctx, s, v))
+		}
+	}
+
+	report.summarize()
+	return report
+}
+
+// RunScenario runs one scenario against a scratch copy of its spec.
+func (r *Runner) RunScenario(ctx context.Context, s *Scenario, v Variant) Result {
+	ctx, span := telemetry.Start(ctx, "eval.scenario",
+		attribute.String("xdd.eval.scenario", s.Name),
+		attribute.String("xdd.eval.variant", v.Name),
+	)
+	defer span.End()
+
+	result := Result{Scenario: s.Name, Variant: v.Name}
+	start := time.Now()
+
+	state, err := r.process(ctx, s, v)
+	if err != nil {
+		result.Err = err.Error()
+		result.Duration = time.Since(start)
+		telemetry.End(span, err)
+		return result
+	}
+	result.Checks = Check(s, state)
+
+	if r.Judge != nil && len(s.Rubrics) > 0 {
+		proposal := describeProposal(state)
+		for _, rubric := range s.Rubrics {
+			verdict, err := r.Judge.Grade(ctx, s, rubric, proposal)
+			if err != nil {
+				result.JudgeErrors = append(result.JudgeErrors, fmt.Sprintf("%s: %v", rubric, err))
+				continue
+			}
+			result.Verdicts = append(result.Verdicts, *verdict)
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+// process seeds a temporary repository with the scenario spec and runs the prompt.
+func (r *Runner) process(ctx context.Context, s *Scenario, v Variant) (*core.SessionState, error) {
+	dir, err := os.MkdirTemp("", "xdd-eval-*")
+	if err != nil {
+		return nil, fmt.Errorf("create scratch repository: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo := repository.NewRepository(dir)
+	if s.Spec != nil {
+		if err := repo.WriteSpecification(ctx, s.Spec); err != nil {
+			return nil, fmt.Errorf("seed specification: %w", err)
+		}
+	}
+
+	orch := core.NewOrchestrator(v.Executor, repo)
+	orch.SetGenerationOptions(r.Generation)
+	orch.SetVersionPolicy(r.Versioning)
+
+	return orch.ProcessPrompt(ctx, core.NewSessionState(), s.Prompt)
+}
@@ -0,0 +1,133 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"xdd/internal/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJudge scores rubrics from a table and records what it was shown.
+type fakeJudge struct {
+	mu        sync.Mutex
+	scores    map[string]int
+	err       error
+	proposals []string
+}
+
+func (j *fakeJudge) Grade(_ context.Context, _ *Scenario, rubric, proposal string) (*Verdict, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.proposals = append(j.proposals, proposal)
+	if j.err != nil {
+		return nil, j.err
+	}
+	return &Verdict{Rubric: rubric, Score: j.scores[rubric], Reasoning: "graded"}, nil
+}
+
+// hashingExecutor is a mock that reports prompt hashes like the real executor.
+type hashingExecutor struct {
+	*core.MockTaskExecutor
+	hashes map[string]string
+}
+
+func (e hashingExecutor) PromptHashes() map[string]string { return e.hashes }
+
+func loginScenario() *Scenario {
+	return &Scenario{
+		Name:    "login",
+		Prompt:  "Add login and task tracking",
+		Expect:  Expectations{Added: []AddedExpectation{{Category: "AUTH", Min: 1}}, Bump: "minor"},
+		Rubrics: []string{"criteria are testable", "requirements are atomic"},
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	judge := &fakeJudge{scores: map[string]int{"criteria are testable": 5, "requirements are atomic": 4}}
+	runner := &Runner{Judge: judge}
+
+	broken := core.NewMockTaskExecutor()
+	broken.CategorizationError = errors.New("model unavailable")
+
+	report := runner.Run(context.Background(), []*Scenario{loginScenario()}, []Variant{
+		{Name: "good", Model: "model-a", Executor: hashingExecutor{core.NewMockTaskExecutor(), map[string]string{"delta": "abc"}}},
+		{Name: "broken", Model: "model-b", Executor: broken},
+	})
+
+	require.Len(t, report.Results, 2)
+	good := report.Result("login", "good")
+	require.NotNil(t, good)
+	assert.Empty(t, good.Err)
+	assert.True(t, good.Passed())
+	assert.Equal(t, 2, good.ChecksPassed())
+	require.Len(t, good.Verdicts, 2)
+	assert.Equal(t, 5, good.Verdicts[0].Score)
+
+	// The judge sees the proposed requirements
+	require.NotEmpty(t, judge.proposals)
+	assert.Contains(t, judge.proposals[0], "Add REQ-AUTH-")
+	assert.Contains(t, judge.proposals[0], "Criterion: Given User is on login page")
+
+	bad := report.Result("login", "broken")
+	require.NotNil(t, bad)
+	assert.Contains(t, bad.Err, "model unavailable")
+	assert.False(t, bad.Passed())
+	assert.Empty(t, bad.Checks)
+
+	assert.Equal(t, VariantSummary{
+		Name: "good", Model: "model-a", PromptVersion: PromptVersion(map[string]string{"delta": "abc"}),
+		Passed: 1, Scenarios: 1, ChecksPassed: 2, Checks: 2, MeanJudgeScore: 4.5, Verdicts: 2,
+		Duration: good.Duration,
+	}, report.Variants[0])
+	assert.Equal(t, 1, report.Variants[1].Errors)
+	assert.Empty(t, report.Variants[1].PromptVersion)
+	assert.True(t, report.Failed())
+}
+
+func TestRunner_LowJudgeScoreFails(t *testing.T) {
+	runner := &Runner{Judge: &fakeJudge{scores: map[string]int{"criteria are testable": 2, "requirements are atomic": 5}}}
+	res := runner.RunScenario(context.Background(), loginScenario(), Variant{Name: "v", Executor: core.NewMockTaskExecutor()})
+
+	assert.Equal(t, 2, res.ChecksPassed())
+	assert.Equal(t, 1, res.VerdictsPassed())
+	assert.False(t, res.Passed())
+}
+
+func TestRunner_JudgeErrorsAreReported(t *testing.T) {
+	runner := &Runner{Judge: &fakeJudge{err: errors.New("rate limited")}}
+	res := runner.RunScenario(context.Background(), loginScenario(), Variant{Name: "v", Executor: core.NewMockTaskExecutor()})
+
+	require.Len(t, res.JudgeErrors, 2)
+	assert.True(t, strings.HasSuffix(res.JudgeErrors[0], "rate limited"))
+	assert.False(t, res.Passed())
+}
+
+func TestRunner_WithoutJudgeSkipsRubrics(t *testing.T) {
+	res := (&Runner{}).RunScenario(context.Background(), loginScenario(), Variant{Name: "v", Executor: core.NewMockTaskExecutor()})
+	assert.Empty(t, res.Verdicts)
+	assert.True(t, res.Passed())
+}
+
+func TestRunner_SeedsScenarioSpec(t *testing.T) {
+	scenarios, err := LoadScenarios("testdata/remove-export.yaml")
+	require.NoError(t, err)
+
+	// The offline executor removes requirements named by ID
+	res := (&Runner{}).RunScenario(context.Background(), scenarios[0], Variant{Name: "offline", Executor: core.NewHeuristicTaskExecutor()})
+	require.Empty(t, res.Err)
+	assert.True(t, res.Passed(), "%+v", res.Checks)
+}
+
+func TestPromptVersion(t *testing.T) {
+	a := PromptVersion(map[string]string{"delta": "1", "metadata": "2"})
+	assert.Len(t, a, 12)
+	assert.Equal(t, a, PromptVersion(map[string]string{"metadata": "2", "delta": "1"}))
+	assert.NotEqual(t, a, PromptVersion(map[string]string{"delta": "1", "metadata": "3"}))
+	assert.Empty(t, PromptVersion(nil))
+}
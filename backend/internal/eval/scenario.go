@@ -0,0 +1,179 @@
+// Package eval scores the pipeline against golden scenarios so prompt and
+// model changes can be compared before they ship.
+package eval
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"xdd/internal/versioning"
+	"xdd/pkg/schema"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultScenarioDir is the conventional location of a project's scenarios.
+const DefaultScenarioDir = ".xdd/eval"
+
+// Scenario is one golden case: a prompt run against an existing spec, with
+// the outcome it should produce.
+type Scenario struct {
+	Name        string                `yaml:"name"`
+	Description string                `yaml:"description,omitempty"`
+	Prompt      string                `yaml:"prompt"`
+	Spec        *schema.Specification `yaml:"spec,omitempty"` // nil starts a new project
+	Expect      Expectations          `yaml:"expect"`
+	Rubrics     []string              `yaml:"rubrics,omitempty"` // Graded by the judge, if any
+
+	Path string `yaml:"-"` // File the scenario was loaded from
+}
+
+// Expectations are the deterministic checks for a scenario. Every field is
+// optional; unset fields are not checked.
+type Expectations struct {
+	Added         []AddedExpectation `yaml:"added,omitempty"`
+	Removed       []string           `yaml:"removed,omitempty"`  // Requirement IDs that must be removed
+	Kept          []string           `yaml:"kept,omitempty"`     // Requirement IDs that must survive
+	Mentions      []string           `yaml:"mentions,omitempty"` // Terms some added requirement must contain
+	Bump          string             `yaml:"bump,omitempty"`
+	MaxConflicts  *int               `yaml:"max_conflicts,omitempty"`
+	Clarification bool               `yaml:"clarification,omitempty"` // Expect questions instead of a proposal
+}
+
+// AddedExpectation bounds how many requirements are added, overall or in
+// one category. A parent category counts its subcategories too.
+type AddedExpectation struct {
+	Category string `yaml:"category,omitempty"` // "" counts every category
+	Min      int    `yaml:"min,omitempty"`
+	Max      *int   `yaml:"max,omitempty"`
+}
+
+// Validate checks that the scenario can be run and scored.
+func (s *Scenario) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(s.Prompt) == "" {
+		return fmt.Errorf("prompt is required")
+	}
+	if s.Expect.isZero() && len(s.Rubrics) == 0 {
+		return fmt.Errorf("at least one expectation or rubric is required")
+	}
+
+	for i, add := range s.Expect.Added {
+		if add.Min < 0 {
+			return fmt.Errorf("expect.added[%d]: min must not be negative", i)
+		}
+		if add.Max != nil && *add.Max < add.Min {
+			return fmt.Errorf("expect.added[%d]: max %d is below min %d", i, *add.Max, add.Min)
+		}
+	}
+	switch versioning.BumpType(s.Expect.Bump) {
+	case "", versioning.BumpMajor, versioning.BumpMinor, versioning.BumpPatch:
+	default:
+		return fmt.Errorf("expect.bump must be %s|%s|%s, got %q",
+			versioning.BumpMajor, versioning.BumpMinor, versioning.BumpPatch, s.Expect.Bump)
+	}
+	if s.Expect.MaxConflicts != nil && *s.Expect.MaxConflicts < 0 {
+		return fmt.Errorf("expect.max_conflicts must not be negative")
+	}
+	if s.Expect.Clarification && (len(s.Expect.Added) > 0 || len(s.Expect.Removed) > 0 || s.Expect.Bump != "") {
+		return fmt.Errorf("expect.clarification cannot be combined with added, removed or bump")
+	}
+
+	for _, id := range append(slices.Clone(s.Expect.Removed), s.Expect.Kept...) {
+		if !s.hasRequirement(id) {
+			return fmt.Errorf("expectation references %s, which is not in the scenario spec", id)
+		}
+	}
+	return nil
+}
+
+func (e Expectations) isZero() bool {
+	return len(e.Added) == 0 && len(e.Removed) == 0 && len(e.Kept) == 0 &&
+		len(e.Mentions) == 0 && e.Bump == "" && e.MaxConflicts == nil && !e.Clarification
+}
+
+func (s *Scenario) hasRequirement(id string) bool {
+	if s.Spec == nil {
+		return false
+	}
+	for _, req := range s.Spec.Requirements {
+		if req.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadScenario reads and validates one scenario file. The name defaults to
+// the file name without its extension.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenario: %w", err)
+	}
+
+	var s Scenario
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse scenario %s: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	s.Path = path
+
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// LoadScenarios loads scenario files and every *.yml/*.yaml file in the
+// given directories. Directory entries load in name order; names must be
+// unique across the suite.
+func LoadScenarios(paths ...string) ([]*Scenario, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("load scenarios: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("load scenarios: %w", err)
+		}
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	scenarios := make([]*Scenario, 0, len(files))
+	seen := make(map[string]string, len(files))
+	for _, file := range files {
+		s, err := LoadScenario(file)
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := seen[s.Name]; ok {
+			return nil, fmt.Errorf("scenario name %q used by both %s and %s", s.Name, prev, file)
+		}
+		seen[s.Name] = file
+		scenarios = append(scenarios, s)
+	}
+
+	if len(scenarios) == 0 {
+		return nil, fmt.Errorf("no scenarios found in %s", strings.Join(paths, ", "))
+	}
+	return scenarios, nil
+}
@@ -0,0 +1,132 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeScenario(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadScenarios_Testdata(t *testing.T) {
+	scenarios, err := LoadScenarios("testdata")
+	require.NoError(t, err)
+	require.Len(t, scenarios, 2)
+
+	assert.Equal(t, "new-project", scenarios[0].Name)
+	assert.Nil(t, scenarios[0].Spec)
+	assert.Len(t, scenarios[0].Rubrics, 2)
+
+	remove := scenarios[1]
+	assert.Equal(t, "remove-export", remove.Name)
+	require.NotNil(t, remove.Spec)
+	assert.Len(t, remove.Spec.Requirements, 2)
+	assert.Len(t, remove.Spec.Requirements[0].AcceptanceCriteria, 1)
+	assert.Equal(t, []string{"REQ-EXPORT-a1b2c3d4e5"}, remove.Expect.Removed)
+	assert.Equal(t, filepath.Join("testdata", "remove-export.yaml"), remove.Path)
+}
+
+func TestLoadScenario_DefaultsNameToFile(t *testing.T) {
+	path := writeScenario(t, t.TempDir(), "login.yml", "prompt: Add login\nexpect:\n  bump: minor\n")
+
+	s, err := LoadScenario(path)
+	require.NoError(t, err)
+	assert.Equal(t, "login", s.Name)
+}
+
+func TestLoadScenarios_Errors(t *testing.T) {
+	t.Run("duplicate names", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScenario(t, dir, "a.yaml", "name: same\nprompt: Add login\nexpect:\n  bump: minor\n")
+		writeScenario(t, dir, "b.yaml", "name: same\nprompt: Add logout\nexpect:\n  bump: minor\n")
+
+		_, err := LoadScenarios(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `scenario name "same" used by both`)
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		_, err := LoadScenarios(t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no scenarios found")
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		_, err := LoadScenarios(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+func TestScenario_Validate(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		scenario Scenario
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			scenario: Scenario{Name: "s", Prompt: "Add login", Expect: Expectations{Bump: "minor"}},
+		},
+		{
+			name:     "rubric only",
+			scenario: Scenario{Name: "s", Prompt: "Add login", Rubrics: []string{"Criteria are testable"}},
+		},
+		{
+			name:     "missing prompt",
+			scenario: Scenario{Name: "s", Expect: Expectations{Bump: "minor"}},
+			wantErr:  "prompt is required",
+		},
+		{
+			name:     "nothing to score",
+			scenario: Scenario{Name: "s", Prompt: "Add login"},
+			wantErr:  "at least one expectation or rubric",
+		},
+		{
+			name:     "unknown bump",
+			scenario: Scenario{Name: "s", Prompt: "Add login", Expect: Expectations{Bump: "huge"}},
+			wantErr:  `expect.bump must be major|minor|patch, got "huge"`,
+		},
+		{
+			name: "max below min",
+			scenario: Scenario{Name: "s", Prompt: "Add login", Expect: Expectations{
+				Added: []AddedExpectation{{Min: 3, Max: intPtr(1)}},
+			}},
+			wantErr: "expect.added[0]: max 1 is below min 3",
+		},
+		{
+			name:     "unknown requirement",
+			scenario: Scenario{Name: "s", Prompt: "Drop export", Expect: Expectations{Removed: []string{"REQ-X"}}},
+			wantErr:  "references REQ-X, which is not in the scenario spec",
+		},
+		{
+			name: "clarification with additions",
+			scenario: Scenario{Name: "s", Prompt: "Change it", Expect: Expectations{
+				Clarification: true,
+				Bump:          "minor",
+			}},
+			wantErr: "clarification cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scenario.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
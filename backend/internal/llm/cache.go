@@ -0,0 +1,232 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheDir is the conventional location of the response cache inside a project.
+const DefaultCacheDir = ".xdd/cache/llm"
+
+// ResponseCache is a content-addressed disk cache of validated LLM outputs.
+// Entries are keyed by a hash of model, task, and prompt, so re-running the
+// same prompt against the same spec is served without calling the provider.
+type ResponseCache struct {
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+
+	mu     sync.Mutex // Serializes writes and pruning
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// CacheStats reports cache effectiveness since the cache was created.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// cacheEntry is the on-disk representation of a cached response.
+type cacheEntry struct {
+	Model     string          `json:"model"`
+	Task      string          `json:"task"`
+	CreatedAt time.Time       `json:"created_at"`
+	Output    json.RawMessage `json:"output"`
+}
+
+// NewResponseCache creates a cache rooted at dir, creating the directory if needed.
+// A zero ttl disables expiry and a zero maxBytes disables size-based pruning.
+func NewResponseCache(dir string, ttl time.Duration, maxBytes int64) (*ResponseCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create cache directory: %w", err)
+	}
+
+	return &ResponseCache{
+		dir:      dir,
+		ttl:      ttl,
+		maxBytes: maxBytes,
+	}, nil
+}
+
+// CacheKey returns the content address for a model/task/prompt combination.
+func CacheKey(model, task, prompt string) string {
+	h := sha256.New()
+	h.Write([]byte(model))
+	h.Write([]byte{0})
+	h.Write([]byte(task))
+	h.Write([]byte{0})
+	h.Write([]byte(prompt))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached output for key, or false if absent or expired.
+func (c *ResponseCache) Get(key string) (json.RawMessage, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl {
+		_ = os.Remove(c.path(key)) // Best effort, expired entry is ignored either way
+		return nil, false
+	}
+
+	return entry.Output, true
+}
+
+// Put stores output under key and prunes the cache back under its size limit.
+func (c *ResponseCache) Put(key, model, task string, output json.RawMessage) error {
+	data, err := json.Marshal(cacheEntry{
+		Model:     model,
+		Task:      task,
+		CreatedAt: time.Now(),
+		Output:    output,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal cache entry: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Write atomically (write to temp, then rename)
+	path := c.path(key)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath) // Best effort cleanup, ignore error
+		return fmt.Errorf("rename cache entry: %w", err)
+	}
+
+	return c.prune()
+}
+
+// Stats returns the hit/miss counters.
+func (c *ResponseCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
+
+// path returns the file path for a cache key.
+func (c *ResponseCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// prune removes the oldest entries until the cache fits within maxBytes.
+// Caller must hold c.mu.
+func (c *ResponseCache) prune() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("read cache directory: %w", err)
+	}
+
+	type fileInfo struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	files := make([]fileInfo, 0, len(entries))
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{
+			path:    filepath.Join(c.dir, entry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		total += info.Size()
+	}
+
+	if total <= c.maxBytes {
+		return nil
+	}
+
+	// Oldest first
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("evict cache entry: %w", err)
+		}
+		total -= f.size
+	}
+
+	return nil
+}
+
+// lookupCached decodes and validates a cached output, recording a hit or miss.
+// Entries that no longer pass validation are treated as misses.
+func lookupCached[T any](c *ResponseCache, key string, validate func(*T) error) (*T, bool) {
+	data, ok := c.Get(key)
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		slog.Warn("Discarding undecodable cache entry", "key", key, "error", err)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	if validate != nil {
+		if err := validate(&result); err != nil {
+			slog.Warn("Discarding cache entry that fails validation", "key", key, "error", err)
+			c.misses.Add(1)
+			return nil, false
+		}
+	}
+
+	c.hits.Add(1)
+	return &result, true
+}
+
+// storeCached writes a validated output to the cache. Failures are logged, not
+// returned, since a cache write must never fail an otherwise successful call.
+func storeCached[T any](c *ResponseCache, key, model, task string, result *T) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		slog.Warn("Failed to encode cache entry", "key", key, "error", err)
+		return
+	}
+	if err := c.Put(key, model, task, data); err != nil {
+		slog.Warn("Failed to write cache entry", "key", key, "error", err)
+	}
+}
@@ -0,0 +1,207 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	base := CacheKey("model-a", "metadata", "prompt")
+
+	if base != CacheKey("model-a", "metadata", "prompt") {
+		t.Error("expected identical inputs to produce identical keys")
+	}
+	if base == CacheKey("model-b", "metadata", "prompt") {
+		t.Error("expected model to be part of the key")
+	}
+	if base == CacheKey("model-a", "delta", "prompt") {
+		t.Error("expected task to be part of the key")
+	}
+	if base == CacheKey("model-a", "metadata", "other prompt") {
+		t.Error("expected prompt to be part of the key")
+	}
+}
+
+func TestResponseCache_GetPut(t *testing.T) {
+	cache, err := NewResponseCache(t.TempDir(), time.Hour, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	key := CacheKey("model", "task", "prompt")
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	if err := cache.Put(key, "model", "task", json.RawMessage(`{"name":"Alice"}`)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("expected hit after put")
+	}
+	if string(data) != `{"name":"Alice"}` {
+		t.Errorf("unexpected cached output: %s", data)
+	}
+}
+
+func TestResponseCache_TTLExpiry(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewResponseCache(dir, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Write an entry that is already older than the TTL
+	key := CacheKey("model", "task", "prompt")
+	stale, _ := json.Marshal(cacheEntry{
+		Model:     "model",
+		Task:      "task",
+		CreatedAt: time.Now().Add(-2 * time.Minute),
+		Output:    json.RawMessage(`{}`),
+	})
+	if err := os.WriteFile(filepath.Join(dir, key+".json"), stale, 0644); err != nil {
+		t.Fatalf("write stale entry: %v", err)
+	}
+
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected expired entry to be a miss")
+	}
+	if _, err := os.Stat(filepath.Join(dir, key+".json")); !os.IsNotExist(err) {
+		t.Error("expected expired entry to be removed")
+	}
+}
+
+func TestResponseCache_SizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewResponseCache(dir, 0, 400)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	output := json.RawMessage(`{"padding":"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"}`)
+	first := CacheKey("model", "task", "first")
+	if err := cache.Put(first, "model", "task", output); err != nil {
+		t.Fatalf("put first: %v", err)
+	}
+
+	// Make the first entry unambiguously the oldest
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, first+".json"), old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	for _, prompt := range []string{"second", "third"} {
+		if err := cache.Put(CacheKey("model", "task", prompt), "model", "task", output); err != nil {
+			t.Fatalf("put %s: %v", prompt, err)
+		}
+	}
+
+	if _, ok := cache.Get(first); ok {
+		t.Error("expected oldest entry to be evicted")
+	}
+	if _, ok := cache.Get(CacheKey("model", "task", "third")); !ok {
+		t.Error("expected newest entry to survive pruning")
+	}
+}
+
+func TestGenerateStructured_Cache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"content":"{\"name\": \"Alice\", \"age\": 25}"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		BaseURL:      server.URL,
+		DefaultModel: "test-model",
+		CacheDir:     t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx := WithTask(context.Background(), "metadata")
+	for i := 0; i < 2; i++ {
+		result, err := GenerateStructured[TestOutput](client, ctx, "", "Generate a person", nil)
+		if err != nil {
+			t.Fatalf("call %d: expected no error, got %v", i+1, err)
+		}
+		if result.Name != "Alice" {
+			t.Errorf("call %d: expected name Alice, got %s", i+1, result.Name)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 provider call, got %d", calls)
+	}
+
+	stats := client.CacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %+v", stats)
+	}
+
+	// A different task must not share the cached response
+	if _, err := GenerateStructured[TestOutput](client, WithTask(context.Background(), "delta"), "", "Generate a person", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 provider calls, got %d", calls)
+	}
+
+	// Cached entries that fail validation are ignored
+	_, err = GenerateStructured[TestOutput](client, ctx, "", "Generate a person", func(o *TestOutput) error {
+		if calls < 3 {
+			return errors.New("rejected by validator")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected stale cache entry to be bypassed, got %d calls", calls)
+	}
+}
+
+func TestGenerateStructured_NoCacheDir(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"choices":[{"message":{"content":"{\"name\": \"Bob\", \"age\": 30}"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		BaseURL:      server.URL,
+		DefaultModel: "test-model",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := GenerateStructured[TestOutput](client, context.Background(), "", "prompt", nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected every call to reach the provider, got %d", calls)
+	}
+	if stats := client.CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("expected zero stats without cache, got %+v", stats)
+	}
+}
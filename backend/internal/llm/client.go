@@ -4,18 +4,25 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"xdd/internal/telemetry"
 )
 
 // Client is the LLM client for interacting with OpenRouter.
 type Client struct {
-	config *Config
-	http   *http.Client
-	models map[string]ModelConfig
+	config  *Config
+	http    *http.Client
+	models  map[string]ModelConfig
+	cache   *ResponseCache // nil when caching is disabled
+	prompts *PromptSet
 }
 
 // NewClient creates a new LLM client.
@@ -26,15 +33,60 @@ func NewClient(config *Config) (*Client, error) {
 
 	config.SetDefaults()
 
+	var cache *ResponseCache
+	if config.CacheDir != "" {
+		var err error
+		cache, err = NewResponseCache(config.CacheDir, config.CacheTTL, config.CacheMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("create response cache: %w", err)
+		}
+	}
+
+	prompts, err := LoadPromptSet(config.PromptDir)
+	if err != nil {
+		return nil, fmt.Errorf("load prompt templates: %w", err)
+	}
+
 	return &Client{
 		config: config,
 		http: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: config.Transport,
 		},
-		models: DefaultModels(),
+		models:  config.KnownModels(),
+		cache:   cache,
+		prompts: prompts,
 	}, nil
 }
 
+// Prompts returns the client's prompt templates, including project overrides.
+func (c *Client) Prompts() *PromptSet {
+	return c.prompts
+}
+
+// CacheStats returns response cache hit/miss counts (zero when caching is disabled).
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.Stats()
+}
+
+// taskContextKey is the context key for the name of the task issuing a request.
+type taskContextKey struct{}
+
+// WithTask returns a context that attributes LLM calls to the named task.
+// The task name is part of the cache key and is included in logs.
+func WithTask(ctx context.Context, task string) context.Context {
+	return context.WithValue(ctx, taskContextKey{}, task)
+}
+
+// TaskFromContext returns the task name set by WithTask, or "" if none.
+func TaskFromContext(ctx context.Context) string {
+	task, _ := ctx.Value(taskContextKey{}).(string)
+	return task
+}
+
 // OpenRouterRequest represents a request to OpenRouter (OpenAI-compatible).
 type OpenRouterRequest struct {
 	Model    string          `json:"model"`
@@ -54,39 +106,145 @@ type OpenRouterResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage *OpenRouterUsage `json:"usage,omitempty"`
 	Error *struct {
 		Message string `json:"message"`
 		Code    string `json:"code"`
 	} `json:"error,omitempty"`
 }
 
+// OpenRouterUsage reports the tokens billed for a completion.
+type OpenRouterUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
 // GenerateStructured generates a structured output from the LLM with validation and retry
 // T is the type of the structured output
 // validate is an optional validation function that returns an error if the output is invalid.
+// When model is "", the model is chosen by the route for the context's task (see WithTask),
+// falling back through the route's fallbacks on provider errors.
 func GenerateStructured[T any](
 	client *Client,
 	ctx context.Context,
 	model string,
 	prompt string,
 	validate func(*T) error,
+) (result *T, err error) {
+	chain := client.modelChain(TaskFromContext(ctx), model)
+
+	ctx, span := telemetry.Start(ctx, "llm.GenerateStructured",
+		telemetry.AttrTask.String(TaskFromContext(ctx)),
+		telemetry.AttrFallbackModels.StringSlice(chain),
+	)
+	defer func() { telemetry.End(span, err) }()
+
+	var lastErr error
+	for i, m := range chain {
+		result, err := generateWithModel(client, ctx, m, prompt, validate)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if !isProviderError(err) || i == len(chain)-1 {
+			break
+		}
+		slog.Warn("LLM provider error, falling back to next model",
+			"task", TaskFromContext(ctx),
+			"failed_model", m,
+			"fallback_model", chain[i+1],
+			"error", err.Error(),
+		)
+	}
+
+	return nil, lastErr
+}
+
+// modelChain returns the models to try for a task, in order.
+// An explicit model always wins and disables fallback.
+func (c *Client) modelChain(task, model string) []string {
+	if model != "" {
+		return []string{model}
+	}
+	if route, ok := c.config.Routes[task]; ok {
+		return route.Chain()
+	}
+	return []string{c.config.DefaultModel}
+}
+
+// isProviderError reports whether err is a network or API failure worth
+// retrying on a different model (as opposed to bad output from the model).
+func isProviderError(err error) bool {
+	var llmErr *LLMError
+	if !errors.As(err, &llmErr) {
+		return false
+	}
+	return llmErr.Type == ErrorTypeNetwork || llmErr.Type == ErrorTypeAPI
+}
+
+// generateWithModel runs the validate-and-retry loop against a single model.
+func generateWithModel[T any](
+	client *Client,
+	ctx context.Context,
+	model string,
+	prompt string,
+	validate func(*T) error,
 ) (*T, error) {
-	if model == "" {
-		model = client.config.DefaultModel
+	task := TaskFromContext(ctx)
+	history := ConversationFromContext(ctx)
+
+	// Serve from cache when an identical request has already been validated
+	var cacheKey string
+	if client.cache != nil {
+		cacheKey = CacheKey(model, task, conversationKey(history)+prompt)
+		if result, ok := lookupCached(client.cache, cacheKey, validate); ok {
+			slog.Info("LLM cache hit",
+				"model", model,
+				"task", task,
+			)
+			_, span := telemetry.Start(ctx, "llm.attempt",
+				telemetry.AttrTask.String(task),
+				telemetry.AttrModel.String(model),
+				telemetry.AttrCacheHit.Bool(true),
+			)
+			span.End()
+			return result, nil
+		}
 	}
 
 	originalPrompt := prompt
 	var lastErr error
+	var retryReason string // Why the previous attempt failed; "" on the first
 
 	for attempt := 1; attempt <= client.config.MaxRetries; attempt++ {
+		attemptCtx, span := telemetry.Start(ctx, "llm.attempt",
+			telemetry.AttrTask.String(task),
+			telemetry.AttrModel.String(model),
+			telemetry.AttrAttempt.Int(attempt),
+			telemetry.AttrCacheHit.Bool(false),
+		)
+		if retryReason != "" {
+			span.SetAttributes(telemetry.AttrRetryReason.String(retryReason))
+		}
+
 		slog.Info("LLM generation attempt",
 			"attempt", attempt,
 			"model", model,
+			"task", task,
 			"prompt_length", len(prompt),
+			"history_messages", len(history),
 		)
 
-		result, err := callOpenRouter[T](client, ctx, model, prompt)
+		messages := buildMessages(history, prompt, client.contextWindow(model))
+		result, err := callOpenRouter[T](client, attemptCtx, model, messages)
 		if err != nil {
 			lastErr = err
+			telemetry.End(span, err)
+			if errors.Is(err, ErrFixtureNotRecorded) {
+				return nil, err
+			}
 			// Network/API errors are not retryable with modified prompt
 			if _, ok := err.(*LLMError); ok {
 				llmErr := err.(*LLMError)
@@ -95,6 +253,7 @@ func GenerateStructured[T any](
 				}
 			}
 			// Parse errors - retry with feedback
+			retryReason = "parse: " + err.Error()
 			prompt = fmt.Sprintf("%s\n\nPREVIOUS ATTEMPT FAILED:\nError: %v\n\nPlease return valid JSON matching the exact structure requested.", originalPrompt, err)
 			continue
 		}
@@ -103,6 +262,8 @@ func GenerateStructured[T any](
 		if validate != nil {
 			if err := validate(result); err != nil {
 				lastErr = NewValidationError(err.Error(), err)
+				telemetry.End(span, lastErr)
+				retryReason = "validation: " + err.Error()
 				slog.Warn("LLM output validation failed",
 					"attempt", attempt,
 					"error", err.Error(),
@@ -113,10 +274,16 @@ func GenerateStructured[T any](
 			}
 		}
 
+		span.End()
 		slog.Info("LLM generation succeeded",
 			"attempt", attempt,
 			"model", model,
+			"task", task,
 		)
+
+		if cacheKey != "" {
+			storeCached(client.cache, cacheKey, model, task, result)
+		}
 		return result, nil
 	}
 
@@ -124,13 +291,17 @@ func GenerateStructured[T any](
 }
 
 // callOpenRouter makes a single HTTP call to OpenRouter API.
-func callOpenRouter[T any](client *Client, ctx context.Context, model, prompt string) (*T, error) {
+func callOpenRouter[T any](client *Client, ctx context.Context, model string, messages []OpenRouterMsg) (_ *T, err error) {
+	ctx, span := telemetry.Start(ctx, "openrouter.chat_completions",
+		attribute.String("http.request.method", http.MethodPost),
+		telemetry.AttrModel.String(model),
+	)
+	defer func() { telemetry.End(span, err) }()
+
 	// Build request
 	reqBody := OpenRouterRequest{
-		Model: model,
-		Messages: []OpenRouterMsg{
-			{Role: "user", Content: prompt},
-		},
+		Model:    model,
+		Messages: messages,
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -154,6 +325,10 @@ func callOpenRouter[T any](client *Client, ctx context.Context, model, prompt st
 	duration := time.Since(start)
 
 	if err != nil {
+		// A replay miss is a test setup problem, not a flaky network
+		if errors.Is(err, ErrFixtureNotRecorded) {
+			return nil, err
+		}
 		slog.Error("OpenRouter HTTP request failed",
 			"error", err.Error(),
 			"duration", duration,
@@ -170,6 +345,7 @@ func callOpenRouter[T any](client *Client, ctx context.Context, model, prompt st
 		"status_code", resp.StatusCode,
 		"duration", duration,
 	)
+	span.SetAttributes(telemetry.AttrHTTPStatusCode.Int(resp.StatusCode))
 
 	// Handle non-200 status codes
 	if resp.StatusCode != http.StatusOK {
@@ -187,6 +363,13 @@ func callOpenRouter[T any](client *Client, ctx context.Context, model, prompt st
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
+	if usage := openrouterResp.Usage; usage != nil {
+		span.SetAttributes(
+			telemetry.AttrInputTokens.Int(usage.PromptTokens),
+			telemetry.AttrOutputTokens.Int(usage.CompletionTokens),
+		)
+	}
+
 	// Check for API error in response
 	if openrouterResp.Error != nil {
 		return nil, NewAPIError(0, openrouterResp.Error.Message)
@@ -391,3 +391,137 @@ func TestClient_GenerateStructured(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateStructured_Routing(t *testing.T) {
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OpenRouterRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		models = append(models, req.Model)
+
+		if req.Model == "anthropic/claude-3.5-sonnet" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("provider overloaded"))
+			return
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"{\"name\": \"Alice\", \"age\": 25}"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		BaseURL:      server.URL,
+		DefaultModel: "default-model",
+		MaxRetries:   1,
+		Routes: map[string]ModelRoute{
+			"metadata": {
+				Model:     "anthropic/claude-3.5-sonnet",
+				Fallbacks: []string{"google/gemini-2.5-flash"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Run("falls back on provider error", func(t *testing.T) {
+		models = nil
+		_, err := GenerateStructured[TestOutput](client, WithTask(context.Background(), "metadata"), "", "p", nil)
+		if err != nil {
+			t.Fatalf("expected fallback to succeed, got %v", err)
+		}
+		if len(models) != 2 || models[0] != "anthropic/claude-3.5-sonnet" || models[1] != "google/gemini-2.5-flash" {
+			t.Errorf("expected primary then fallback, got %v", models)
+		}
+	})
+
+	t.Run("unrouted task uses default model", func(t *testing.T) {
+		models = nil
+		if _, err := GenerateStructured[TestOutput](client, WithTask(context.Background(), "delta"), "", "p", nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(models) != 1 || models[0] != "default-model" {
+			t.Errorf("expected default model, got %v", models)
+		}
+	})
+
+	t.Run("built-in route applies to unconfigured task", func(t *testing.T) {
+		models = nil
+		if _, err := GenerateStructured[TestOutput](client, WithTask(context.Background(), "version_bump"), "", "p", nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(models) != 1 || models[0] != DefaultRoutes()["version_bump"].Model {
+			t.Errorf("expected built-in version_bump route, got %v", models)
+		}
+	})
+
+	t.Run("explicit model disables fallback", func(t *testing.T) {
+		models = nil
+		_, err := GenerateStructured[TestOutput](client, WithTask(context.Background(), "metadata"), "anthropic/claude-3.5-sonnet", "p", nil)
+		if err == nil {
+			t.Fatal("expected provider error")
+		}
+		if len(models) != 1 {
+			t.Errorf("expected a single attempt, got %v", models)
+		}
+	})
+
+	t.Run("validation failures do not fall back", func(t *testing.T) {
+		models = nil
+		_, err := GenerateStructured[TestOutput](client, WithTask(context.Background(), "delta"), "", "p", func(o *TestOutput) error {
+			return errors.New("always invalid")
+		})
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+		for _, m := range models {
+			if m != "default-model" {
+				t.Errorf("expected no fallback on validation error, got %v", models)
+			}
+		}
+	})
+}
+
+func TestConfig_SetDefaultsRoutes(t *testing.T) {
+	own := map[string]ModelRoute{"categorization": {Model: "anthropic/claude-3.5-sonnet"}}
+	cfg := &Config{Routes: own}
+	cfg.SetDefaults()
+
+	if got := cfg.Routes["categorization"].Model; got != "anthropic/claude-3.5-sonnet" {
+		t.Errorf("expected configured route to win, got %q", got)
+	}
+	if _, ok := cfg.Routes["version_bump"]; !ok {
+		t.Error("expected built-in version_bump route")
+	}
+	if len(own) != 1 {
+		t.Errorf("expected caller's routes untouched, got %v", own)
+	}
+}
+
+func TestConfig_ValidateRoutes(t *testing.T) {
+	base := Config{APIKey: "k", BaseURL: "u", DefaultModel: "m"}
+
+	valid := base
+	valid.Routes = map[string]ModelRoute{"metadata": {Model: "google/gemini-2.5-flash"}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected known model to validate, got %v", err)
+	}
+
+	unknown := base
+	unknown.Routes = map[string]ModelRoute{"metadata": {Model: "acme/unknown"}}
+	if err := unknown.Validate(); err == nil {
+		t.Error("expected unknown model to fail validation")
+	}
+
+	declared := unknown
+	declared.Models = []ModelConfig{{Name: "acme/unknown", ContextWindow: 8000}}
+	if err := declared.Validate(); err != nil {
+		t.Errorf("expected declared model to validate, got %v", err)
+	}
+
+	badFallback := base
+	badFallback.Routes = map[string]ModelRoute{"metadata": {Model: "google/gemini-2.5-flash", Fallbacks: []string{"acme/missing"}}}
+	if err := badFallback.Validate(); err == nil {
+		t.Error("expected unknown fallback to fail validation")
+	}
+}
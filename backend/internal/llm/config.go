@@ -2,6 +2,8 @@ package llm
 
 import (
 	"fmt"
+	"maps"
+	"net/http"
 	"time"
 )
 
@@ -25,6 +27,67 @@ type Config struct {
 	// MaxRetries is the maximum number of validation retries
 	// Default: 3
 	MaxRetries int
+
+	// CacheDir enables the on-disk response cache when set
+	// Example: .xdd/cache/llm (see DefaultCacheDir)
+	CacheDir string
+
+	// CacheTTL is how long a cached response stays valid
+	// Default: 24 hours
+	CacheTTL time.Duration
+
+	// CacheMaxBytes caps the total size of the cache directory
+	// Default: 50 MB
+	CacheMaxBytes int64
+
+	// Transport overrides the HTTP transport (e.g. a FixtureTransport)
+	// Default: http.DefaultTransport
+	Transport http.RoundTripper
+
+	// Routes maps task names to a primary model and ordered fallbacks
+	// Tasks without a route use DefaultModel
+	Routes map[string]ModelRoute
+
+	// Models declares models in addition to DefaultModels()
+	Models []ModelConfig
+
+	// ContextTopK caps how many existing requirements go into a prompt
+	// Default: 50 (see SelectRelevantRequirements)
+	ContextTopK int
+
+	// PromptDir holds per-project prompt template overrides (<task>.tmpl)
+	// Example: .xdd/prompts (see DefaultPromptDir)
+	PromptDir string
+}
+
+// ModelRoute selects the model for a task. Fallbacks are tried in order when
+// the primary model fails with a provider (network or API) error.
+type ModelRoute struct {
+	Model     string   `yaml:"model"`
+	Fallbacks []string `yaml:"fallbacks,omitempty"`
+}
+
+// Chain returns the primary model followed by its fallbacks.
+func (r ModelRoute) Chain() []string {
+	return append([]string{r.Model}, r.Fallbacks...)
+}
+
+// DefaultRoutes returns the built-in model routes, keyed by task name (see
+// tasks.TaskNames). NewClient applies them to tasks the config leaves
+// unset; tasks without a route use DefaultModel.
+func DefaultRoutes() map[string]ModelRoute {
+	return map[string]ModelRoute{
+		// Thinking model gives better category structure
+		"categorization": {
+			Model:     "google/gemini-2.0-flash-thinking-exp",
+			Fallbacks: []string{"anthropic/claude-3.5-sonnet"},
+		},
+		// Semver choice is a small judgement, a fast model is enough
+		"version_bump": {
+			Model:     "google/gemini-2.5-flash",
+			Fallbacks: []string{"anthropic/claude-3.5-sonnet"},
+		},
+	}
 }
 
 // Validate checks that required config fields are set.
@@ -41,9 +104,35 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DefaultModel is required")
 	}
 
+	return c.ValidateRoutes()
+}
+
+// ValidateRoutes checks that every routed model is in KnownModels().
+func (c *Config) ValidateRoutes() error {
+	known := c.KnownModels()
+	for task, route := range c.Routes {
+		if route.Model == "" {
+			return fmt.Errorf("route %s: model is required", task)
+		}
+		for _, model := range route.Chain() {
+			if _, ok := known[model]; !ok {
+				return fmt.Errorf("route %s: unknown model %q (declare it under models)", task, model)
+			}
+		}
+	}
+
 	return nil
 }
 
+// KnownModels returns DefaultModels() merged with the user-declared Models.
+func (c *Config) KnownModels() map[string]ModelConfig {
+	models := DefaultModels()
+	for _, m := range c.Models {
+		models[m.Name] = m
+	}
+	return models
+}
+
 // SetDefaults fills in default values for optional fields.
 func (c *Config) SetDefaults() {
 	if c.Timeout == 0 {
@@ -53,21 +142,45 @@ func (c *Config) SetDefaults() {
 	if c.MaxRetries == 0 {
 		c.MaxRetries = 3
 	}
+
+	if c.CacheTTL == 0 {
+		c.CacheTTL = 24 * time.Hour
+	}
+
+	if c.CacheMaxBytes == 0 {
+		c.CacheMaxBytes = 50 * 1024 * 1024
+	}
+
+	if c.ContextTopK == 0 {
+		c.ContextTopK = DefaultContextTopK
+	}
+
+	// Copy so a caller's route map is not modified
+	routes := maps.Clone(c.Routes)
+	if routes == nil {
+		routes = make(map[string]ModelRoute)
+	}
+	for task, route := range DefaultRoutes() {
+		if _, ok := routes[task]; !ok {
+			routes[task] = route
+		}
+	}
+	c.Routes = routes
 }
 
 // ModelConfig contains configuration for a specific model.
 type ModelConfig struct {
 	// Name is the OpenRouter model identifier
-	Name string
+	Name string `yaml:"name"`
 
 	// SupportsTools indicates if the model supports tool/function calling
-	SupportsTools bool
+	SupportsTools bool `yaml:"supports_tools"`
 
 	// ContextWindow is the maximum context size in tokens
-	ContextWindow int
+	ContextWindow int `yaml:"context_window"`
 
 	// Description is a human-readable description
-	Description string
+	Description string `yaml:"description"`
 }
 
 // DefaultModels returns the default model configurations.
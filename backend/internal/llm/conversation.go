@@ -0,0 +1,129 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// defaultContextWindow is assumed for models without a known ContextWindow.
+const defaultContextWindow = 32000
+
+// responseReserve is the token budget held back for the model's reply.
+const responseReserve = 4096
+
+// summaryLineLength caps each message's excerpt in a conversation summary.
+const summaryLineLength = 200
+
+type conversationContextKey struct{}
+
+// WithConversation returns a context carrying the prior turns of a session.
+// GenerateStructured sends them ahead of the prompt as a multi-message
+// conversation, summarizing the oldest turns when they exceed the model's
+// context window.
+func WithConversation(ctx context.Context, history []OpenRouterMsg) context.Context {
+	return context.WithValue(ctx, conversationContextKey{}, history)
+}
+
+// ConversationFromContext returns the history set by WithConversation, or nil.
+func ConversationFromContext(ctx context.Context) []OpenRouterMsg {
+	history, _ := ctx.Value(conversationContextKey{}).([]OpenRouterMsg)
+	return history
+}
+
+// EstimateTokens approximates the token count of text (about 4 characters per token).
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// contextWindow returns the context window of model, or a conservative default.
+func (c *Client) contextWindow(model string) int {
+	if m, ok := c.models[model]; ok && m.ContextWindow > 0 {
+		return m.ContextWindow
+	}
+	return defaultContextWindow
+}
+
+// buildMessages returns the conversation history followed by the prompt,
+// fitted to the context window.
+func buildMessages(history []OpenRouterMsg, prompt string, contextWindow int) []OpenRouterMsg {
+	budget := contextWindow - responseReserve - EstimateTokens(prompt)
+	messages := fitConversation(history, budget)
+	return append(messages, OpenRouterMsg{Role: "user", Content: prompt})
+}
+
+// fitConversation returns history unchanged if it fits in budget tokens.
+// Otherwise the most recent turns that fit are kept verbatim and everything
+// older is collapsed into a single summary message.
+func fitConversation(history []OpenRouterMsg, budget int) []OpenRouterMsg {
+	total := 0
+	for _, m := range history {
+		total += EstimateTokens(m.Content)
+	}
+	if total <= budget {
+		return history
+	}
+
+	// Keep newest turns while leaving room for the summary
+	summaryBudget := budget / 4
+	remaining := budget - summaryBudget
+	split := len(history)
+	for split > 0 {
+		cost := EstimateTokens(history[split-1].Content)
+		if cost > remaining {
+			break
+		}
+		remaining -= cost
+		split--
+	}
+
+	summary := summarizeConversation(history[:split], summaryBudget)
+	messages := make([]OpenRouterMsg, 0, len(history)-split+1)
+	if summary != "" {
+		messages = append(messages, OpenRouterMsg{Role: "system", Content: summary})
+	}
+	return append(messages, history[split:]...)
+}
+
+// summarizeConversation condenses turns into one excerpt per message, dropping
+// the oldest excerpts when the summary itself exceeds budget tokens.
+func summarizeConversation(turns []OpenRouterMsg, budget int) string {
+	if len(turns) == 0 || budget <= 0 {
+		return ""
+	}
+
+	const header = "Summary of earlier conversation (oldest turns condensed):"
+
+	lines := make([]string, 0, len(turns))
+	for _, m := range turns {
+		excerpt := strings.Join(strings.Fields(m.Content), " ")
+		if len(excerpt) > summaryLineLength {
+			excerpt = excerpt[:summaryLineLength-3] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", m.Role, excerpt))
+	}
+
+	used := EstimateTokens(header)
+	start := len(lines)
+	for start > 0 && used+EstimateTokens(lines[start-1]) <= budget {
+		used += EstimateTokens(lines[start-1])
+		start--
+	}
+	if start == len(lines) {
+		return ""
+	}
+
+	return header + "\n" + strings.Join(lines[start:], "\n")
+}
+
+// conversationKey flattens history into a string for cache keys.
+func conversationKey(history []OpenRouterMsg) string {
+	var b strings.Builder
+	for _, m := range history {
+		b.WriteString(m.Role)
+		b.WriteByte(0)
+		b.WriteString(m.Content)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFitConversation_FitsUnchanged(t *testing.T) {
+	history := []OpenRouterMsg{
+		{Role: "user", Content: "Build a todo app"},
+		{Role: "assistant", Content: "Proposed changes: ..."},
+	}
+
+	fitted := fitConversation(history, 1000)
+	if len(fitted) != 2 || fitted[0] != history[0] || fitted[1] != history[1] {
+		t.Errorf("expected history unchanged, got %+v", fitted)
+	}
+}
+
+func TestFitConversation_SummarizesOldest(t *testing.T) {
+	var history []OpenRouterMsg
+	for i := 0; i < 20; i++ {
+		history = append(history,
+			OpenRouterMsg{Role: "user", Content: "feedback " + strings.Repeat("x", 400)},
+			OpenRouterMsg{Role: "assistant", Content: "proposal " + strings.Repeat("y", 400)},
+		)
+	}
+	history = append(history, OpenRouterMsg{Role: "user", Content: "latest feedback"})
+
+	budget := 1000
+	fitted := fitConversation(history, budget)
+
+	total := 0
+	for _, m := range fitted {
+		total += EstimateTokens(m.Content)
+	}
+	if total > budget {
+		t.Errorf("expected fitted history within %d tokens, got %d", budget, total)
+	}
+
+	if fitted[0].Role != "system" || !strings.HasPrefix(fitted[0].Content, "Summary of earlier conversation") {
+		t.Errorf("expected leading summary message, got %+v", fitted[0])
+	}
+	if last := fitted[len(fitted)-1]; last.Content != "latest feedback" {
+		t.Errorf("expected newest turn kept verbatim, got %q", last.Content)
+	}
+}
+
+func TestGenerateStructured_SendsConversation(t *testing.T) {
+	var received OpenRouterRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"choices":[{"message":{"content":"{\"name\": \"Alice\", \"age\": 25}"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIKey: "k", BaseURL: server.URL, DefaultModel: "m"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx := WithConversation(context.Background(), []OpenRouterMsg{
+		{Role: "user", Content: "Build a chat app"},
+		{Role: "assistant", Content: "Proposed changes: add CHAT requirements"},
+	})
+	if _, err := GenerateStructured[TestOutput](client, ctx, "", "Make it end-to-end encrypted", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(received.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %+v", received.Messages)
+	}
+	if received.Messages[1].Role != "assistant" || received.Messages[2].Content != "Make it end-to-end encrypted" {
+		t.Errorf("expected history followed by prompt, got %+v", received.Messages)
+	}
+}
@@ -7,24 +7,43 @@ import (
 	"testing"
 )
 
-// TestE2E_OpenRouter performs an end-to-end test with real OpenRouter API
-// This test is skipped by default - run with: go test -tags=live.
-func TestE2E_OpenRouter(t *testing.T) {
-	// Skip unless explicitly requested
-	if os.Getenv("RUN_E2E_TESTS") != "true" {
-		t.Skip("E2E test skipped - set RUN_E2E_TESTS=true to run")
+// e2eFixturesDir holds recorded OpenRouter exchanges, relative to this package.
+const e2eFixturesDir = "testdata/fixtures"
+
+// newE2EClient creates a client for E2E tests according to LLM_FIXTURE_MODE.
+// live and record call OpenRouter and require RUN_E2E_TESTS=true plus an API key;
+// replay serves recorded fixtures and runs offline.
+func newE2EClient(t *testing.T) *Client {
+	t.Helper()
+
+	mode, err := FixtureModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	apiKey := os.Getenv("OPENROUTER_API_KEY")
-	if apiKey == "" {
-		t.Fatal("OPENROUTER_API_KEY not set")
+	if mode == FixtureModeReplay {
+		apiKey = "replay-no-key-needed"
+	} else {
+		// Skip unless explicitly requested
+		if os.Getenv("RUN_E2E_TESTS") != "true" {
+			t.Skip("E2E test skipped - set RUN_E2E_TESTS=true or LLM_FIXTURE_MODE=replay to run")
+		}
+		if apiKey == "" {
+			t.Fatal("OPENROUTER_API_KEY not set")
+		}
+	}
+
+	transport, err := NewFixtureTransport(mode, e2eFixturesDir, nil, apiKey)
+	if err != nil {
+		t.Fatalf("Failed to create fixture transport: %v", err)
 	}
 
-	// Create client
 	config := &Config{
 		APIKey:       apiKey,
 		BaseURL:      "https://openrouter.ai/api/v1",
 		DefaultModel: "anthropic/claude-3.5-sonnet",
+		Transport:    transport,
 	}
 
 	client, err := NewClient(config)
@@ -32,6 +51,15 @@ func TestE2E_OpenRouter(t *testing.T) {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 
+	return client
+}
+
+// TestE2E_OpenRouter performs an end-to-end test with real OpenRouter API
+// This test is skipped by default - run with: RUN_E2E_TESTS=true, or offline
+// against recorded fixtures with LLM_FIXTURE_MODE=replay.
+func TestE2E_OpenRouter(t *testing.T) {
+	client := newE2EClient(t)
+
 	t.Run("Generate structured output with validation and retry", func(t *testing.T) {
 		type Person struct {
 			Name string `json:"name"`
@@ -147,25 +175,7 @@ func TestE2E_OpenRouter(t *testing.T) {
 
 // TestE2E_PromptBuilders validates that prompt builders work with real LLM.
 func TestE2E_PromptBuilders(t *testing.T) {
-	if os.Getenv("RUN_E2E_TESTS") != "true" {
-		t.Skip("E2E test skipped - set RUN_E2E_TESTS=true to run")
-	}
-
-	apiKey := os.Getenv("OPENROUTER_API_KEY")
-	if apiKey == "" {
-		t.Fatal("OPENROUTER_API_KEY not set")
-	}
-
-	config := &Config{
-		APIKey:       apiKey,
-		BaseURL:      "https://openrouter.ai/api/v1",
-		DefaultModel: "anthropic/claude-3.5-sonnet",
-	}
-
-	client, err := NewClient(config)
-	if err != nil {
-		t.Fatalf("Failed to create client: %v", err)
-	}
+	client := newE2EClient(t)
 
 	t.Run("Metadata prompt", func(t *testing.T) {
 		type MetadataOutput struct {
@@ -15,6 +15,9 @@ type Fixture struct {
 	Output    json.RawMessage `json:"output"`
 	Model     string          `json:"model"`
 	Timestamp time.Time       `json:"timestamp"`
+
+	// StatusCode is the recorded HTTP status (0 for hand-written fixtures)
+	StatusCode int `json:"status_code,omitempty"`
 }
 
 // UnmarshalInput unmarshals the fixture input into the specified type.
@@ -27,16 +30,23 @@ func (f *Fixture) UnmarshalOutput(v interface{}) error {
 	return json.Unmarshal(f.Output, v)
 }
 
+// fixturesDir is the fixture location relative to the backend module root.
+var fixturesDir = filepath.Join("internal", "llm", "testdata", "fixtures")
+
 // LoadFixture loads a fixture from the testdata directory.
 func LoadFixture(name string) (*Fixture, error) {
-	// Construct path to fixture file (must match record-fixtures.go path)
-	fixturePath := filepath.Join("internal", "llm", "testdata", "fixtures", name+".json")
+	return loadFixtureFrom(fixturesDir, name)
+}
+
+// loadFixtureFrom loads and validates the named fixture from dir.
+func loadFixtureFrom(dir, name string) (*Fixture, error) {
+	fixturePath := filepath.Join(dir, name+".json")
 
 	// Read fixture file
 	data, err := os.ReadFile(fixturePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("fixture not found: %s\n\nFixtures not recorded. Run:\n  OPENROUTER_API_KEY=sk-... go run scripts/record-fixtures/main.go", name)
+			return nil, fmt.Errorf("fixture not found: %s\n\nFixtures not recorded. Run the tests in record mode:\n  OPENROUTER_API_KEY=sk-... %s=%s go test ./internal/llm/ -run TestE2E", name, FixtureModeEnv, FixtureModeRecord)
 		}
 		return nil, fmt.Errorf("read fixture %s: %w", name, err)
 	}
@@ -66,6 +76,11 @@ func LoadFixture(name string) (*Fixture, error) {
 
 // SaveFixture saves a fixture to the testdata directory.
 func SaveFixture(name string, fixture *Fixture) error {
+	return saveFixtureTo(fixturesDir, name, fixture)
+}
+
+// saveFixtureTo validates and atomically writes the named fixture into dir.
+func saveFixtureTo(dir, name string, fixture *Fixture) error {
 	// Validate fixture has required fields before saving
 	if fixture.Name == "" {
 		return fmt.Errorf("fixture missing 'name' field")
@@ -80,9 +95,8 @@ func SaveFixture(name string, fixture *Fixture) error {
 		return fmt.Errorf("fixture missing 'output' field")
 	}
 
-	// Ensure testdata/fixtures directory exists (must match record-fixtures.go path)
-	fixturesDir := filepath.Join("internal", "llm", "testdata", "fixtures")
-	if err := os.MkdirAll(fixturesDir, 0755); err != nil {
+	// Ensure fixtures directory exists
+	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("create fixtures directory: %w", err)
 	}
 
@@ -93,7 +107,7 @@ func SaveFixture(name string, fixture *Fixture) error {
 	}
 
 	// Write fixture file atomically (write to temp, then rename)
-	fixturePath := filepath.Join(fixturesDir, name+".json")
+	fixturePath := filepath.Join(dir, name+".json")
 	tempPath := fixturePath + ".tmp"
 
 	if err := os.WriteFile(tempPath, data, 0644); err != nil {
@@ -77,7 +77,10 @@ func TestBuildRequirementsDeltaPrompt(t *testing.T) {
 			},
 		}
 
-		existingCategories := []string{"AUTH", "TASKS"}
+		existingCategories := []schema.Category{
+			{Name: "AUTH", Description: "Login, sessions and access control"},
+			{Name: "TASKS"},
+		}
 
 		prompt := BuildRequirementsDeltaPrompt(
 			existingReqs,
@@ -89,8 +92,8 @@ func TestBuildRequirementsDeltaPrompt(t *testing.T) {
 			t.Error("prompt should contain existing requirement IDs")
 		}
 
-		if !strings.Contains(prompt, "AUTH, TASKS") {
-			t.Error("prompt should list existing categories")
+		if !strings.Contains(prompt, "- AUTH: Login, sessions and access control\n- TASKS\n") {
+			t.Error("prompt should list existing categories with their descriptions")
 		}
 
 		if !strings.Contains(prompt, "Add OAuth support") {
@@ -117,7 +120,7 @@ func TestBuildRequirementsDeltaPrompt(t *testing.T) {
 	t.Run("empty existing requirements", func(t *testing.T) {
 		prompt := BuildRequirementsDeltaPrompt(
 			[]schema.Requirement{},
-			[]string{},
+			[]schema.Category{},
 			"Build a user authentication system",
 		)
 
@@ -125,7 +128,7 @@ func TestBuildRequirementsDeltaPrompt(t *testing.T) {
 			t.Error("prompt should not show existing requirements section when empty")
 		}
 
-		if strings.Contains(prompt, "EXISTING CATEGORIES:") {
+		if strings.Contains(prompt, "EXISTING CATEGORIES") {
 			t.Error("prompt should not show existing categories when empty")
 		}
 	})
@@ -143,6 +146,7 @@ func TestBuildCategorizationPrompt(t *testing.T) {
 		"TaskMaster",
 		"A collaborative task manager",
 		briefs,
+		nil,
 	)
 
 	if !strings.Contains(prompt, "TaskMaster") {
@@ -163,13 +167,31 @@ func TestBuildCategorizationPrompt(t *testing.T) {
 		t.Error("prompt should specify UPPERCASE category names")
 	}
 
-	if !strings.Contains(prompt, "3-8 categories") {
+	if !strings.Contains(prompt, "3-8 top-level categories") {
 		t.Error("prompt should suggest category count range")
 	}
 
+	if !strings.Contains(prompt, "AUTH/SSO") {
+		t.Error("prompt should explain nested category paths")
+	}
+
 	if !strings.Contains(prompt, "requirement_mapping") {
 		t.Error("prompt should include requirement_mapping field")
 	}
+
+	if strings.Contains(prompt, "EXISTING CATEGORIES") {
+		t.Error("prompt should not show existing categories for a new project")
+	}
+
+	prompt = BuildCategorizationPrompt(
+		"TaskMaster",
+		"A collaborative task manager",
+		briefs,
+		[]schema.Category{{Name: "AUTH", Description: "Login, sessions and access control"}},
+	)
+	if !strings.Contains(prompt, "- AUTH: Login, sessions and access control") {
+		t.Error("prompt should list existing categories with their descriptions")
+	}
 }
 
 func TestBuildRequirementGenerationPrompt(t *testing.T) {
@@ -236,6 +258,9 @@ func TestBuildVersionBumpPrompt(t *testing.T) {
 
 	prompt := BuildVersionBumpPrompt(
 		"0.1.0",
+		"0.2.0",
+		"minor",
+		[]string{"2 requirement(s) added: minor"},
 		2,
 		0,
 		false,
@@ -264,16 +289,12 @@ func TestBuildVersionBumpPrompt(t *testing.T) {
 		}
 	}
 
-	if !strings.Contains(prompt, "MAJOR") {
-		t.Error("prompt should explain MAJOR bump")
-	}
-
-	if !strings.Contains(prompt, "MINOR") {
-		t.Error("prompt should explain MINOR bump")
+	if !strings.Contains(prompt, "NEW VERSION: 0.2.0") || !strings.Contains(prompt, "BUMP TYPE: minor") {
+		t.Error("prompt should state the computed bump")
 	}
 
-	if !strings.Contains(prompt, "PATCH") {
-		t.Error("prompt should explain PATCH bump")
+	if !strings.Contains(prompt, "- 2 requirement(s) added: minor") {
+		t.Error("prompt should list the policy rules applied")
 	}
 
 	if !strings.Contains(prompt, "new_version") {
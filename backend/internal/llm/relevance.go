@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"xdd/pkg/schema"
+)
+
+// DefaultContextTopK is the default maximum number of existing requirements
+// included in a prompt.
+const DefaultContextTopK = 50
+
+// BM25 tuning constants (standard values).
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// stopwords are dropped before scoring; they match nearly every requirement.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "shall": true, "should": true, "system": true,
+	"that": true, "the": true, "to": true, "when": true, "while": true, "where": true,
+	"will": true, "with": true, "user": true, "must": true, "can": true,
+}
+
+// SelectRelevantRequirements picks the existing requirements worth sending
+// to the model for query. Requirements whose ID or alias appears in query
+// are always included. The rest are ranked with BM25 over description, rationale and
+// category, and the best are kept while they fit in topK and tokenBudget.
+// When everything fits, requirements is returned unchanged. Results keep
+// their original order.
+func SelectRelevantRequirements(requirements []schema.Requirement, query string, topK, tokenBudget int) []schema.Requirement {
+	if topK <= 0 {
+		topK = DefaultContextTopK
+	}
+
+	total := 0
+	for _, req := range requirements {
+		total += requirementTokens(req)
+	}
+	if len(requirements) <= topK && total <= tokenBudget {
+		return requirements
+	}
+
+	// Explicit mentions (by ID or alias) always go in, budget or not
+	selected := make(map[int]bool)
+	used := 0
+	for i, req := range requirements {
+		if req.MentionedIn(query) {
+			selected[i] = true
+			used += requirementTokens(req)
+		}
+	}
+
+	scores := bm25Scores(requirements, query)
+	ranked := make([]int, 0, len(requirements))
+	for i := range requirements {
+		if !selected[i] && scores[i] > 0 {
+			ranked = append(ranked, i)
+		}
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return scores[ranked[a]] > scores[ranked[b]]
+	})
+
+	for _, i := range ranked {
+		if len(selected) >= topK {
+			break
+		}
+		cost := requirementTokens(requirements[i])
+		if used+cost > tokenBudget {
+			continue // A shorter, lower-ranked requirement may still fit
+		}
+		selected[i] = true
+		used += cost
+	}
+
+	result := make([]schema.Requirement, 0, len(selected))
+	for i, req := range requirements {
+		if selected[i] {
+			result = append(result, req)
+		}
+	}
+	return result
+}
+
+// RelevantRequirements selects the requirements to include in a task's prompt.
+// The token budget is half the smallest context window in the task's model
+// chain, less the reply reserve.
+func (c *Client) RelevantRequirements(task string, requirements []schema.Requirement, query string) []schema.Requirement {
+	window := 0
+	for _, model := range c.modelChain(task, "") {
+		if w := c.contextWindow(model); window == 0 || w < window {
+			window = w
+		}
+	}
+
+	budget := window/2 - responseReserve
+	return SelectRelevantRequirements(requirements, query, c.config.ContextTopK, budget)
+}
+
+// requirementTokens estimates a requirement's cost as a prompt line.
+func requirementTokens(req schema.Requirement) int {
+	return EstimateTokens(req.ID+req.Category+req.Description) + 4
+}
+
+// bm25Scores scores each requirement against query.
+func bm25Scores(requirements []schema.Requirement, query string) []float64 {
+	docs := make([][]string, len(requirements))
+	docFreq := make(map[string]int)
+	totalLen := 0
+	for i, req := range requirements {
+		docs[i] = tokenize(req.Description + " " + req.Rationale + " " + req.Category)
+		totalLen += len(docs[i])
+
+		seen := make(map[string]bool)
+		for _, term := range docs[i] {
+			if !seen[term] {
+				seen[term] = true
+				docFreq[term]++
+			}
+		}
+	}
+
+	scores := make([]float64, len(requirements))
+	if len(requirements) == 0 || totalLen == 0 {
+		return scores
+	}
+	avgLen := float64(totalLen) / float64(len(requirements))
+	n := float64(len(requirements))
+
+	// Unique terms in a fixed order keep float sums, and so rankings, deterministic
+	queryTerms := tokenize(query)
+	sort.Strings(queryTerms)
+	queryTerms = slices.Compact(queryTerms)
+
+	for i, doc := range docs {
+		termFreq := make(map[string]int)
+		for _, term := range doc {
+			termFreq[term]++
+		}
+
+		docLen := float64(len(doc))
+		for _, term := range queryTerms {
+			tf := float64(termFreq[term])
+			if tf == 0 {
+				continue
+			}
+			df := float64(docFreq[term])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			scores[i] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLen/avgLen))
+		}
+	}
+
+	return scores
+}
+
+// tokenize lowercases text and splits it into alphanumeric terms, dropping stopwords.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := fields[:0]
+	for _, f := range fields {
+		if len(f) > 1 && !stopwords[f] {
+			terms = append(terms, f)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,113 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"xdd/pkg/schema"
+)
+
+func relevanceFixture() []schema.Requirement {
+	reqs := []schema.Requirement{
+		{ID: "REQ-AUTH-login00001", Category: "AUTH", Description: "When a user submits credentials, the system shall verify the password"},
+		{ID: "REQ-AUTH-oauth00002", Category: "AUTH", Description: "Where OAuth is configured, the system shall offer Google sign-in"},
+		{ID: "REQ-BILL-invoice003", Category: "BILLING", Description: "When a month ends, the system shall email an invoice"},
+		{ID: "REQ-TASK-create0004", Category: "TASKS", Description: "When a user creates a task, the system shall store its due date"},
+	}
+	for i := 0; i < 40; i++ {
+		reqs = append(reqs, schema.Requirement{
+			ID:          fmt.Sprintf("REQ-MISC-filler%04d", i),
+			Category:    "MISC",
+			Description: fmt.Sprintf("The system shall render dashboard widget number %d", i),
+		})
+	}
+	return reqs
+}
+
+func ids(reqs []schema.Requirement) []string {
+	out := make([]string, 0, len(reqs))
+	for _, r := range reqs {
+		out = append(out, r.ID)
+	}
+	return out
+}
+
+func TestSelectRelevantRequirements_AllFit(t *testing.T) {
+	reqs := relevanceFixture()[:4]
+	got := SelectRelevantRequirements(reqs, "anything", 10, 100000)
+	if len(got) != len(reqs) {
+		t.Errorf("expected all requirements when they fit, got %v", ids(got))
+	}
+}
+
+func TestSelectRelevantRequirements_RanksByRelevance(t *testing.T) {
+	got := SelectRelevantRequirements(relevanceFixture(), "Add GitHub OAuth sign-in alongside password credentials", 2, 100000)
+
+	if strings.Join(ids(got), ",") != "REQ-AUTH-login00001,REQ-AUTH-oauth00002" {
+		t.Errorf("expected the two AUTH requirements, got %v", ids(got))
+	}
+}
+
+func TestSelectRelevantRequirements_ExplicitIDs(t *testing.T) {
+	// The invoice requirement shares no terms with the query but is named by ID
+	query := "Replace req-bill-invoice003 with OAuth sign-in"
+	got := SelectRelevantRequirements(relevanceFixture(), query, 1, 10)
+
+	found := false
+	for _, r := range got {
+		if r.ID == "REQ-BILL-invoice003" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected explicitly named requirement despite budget, got %v", ids(got))
+	}
+}
+
+func TestSelectRelevantRequirements_TokenBudget(t *testing.T) {
+	reqs := relevanceFixture()
+	budget := requirementTokens(reqs[4]) * 3
+
+	got := SelectRelevantRequirements(reqs, "dashboard widget", 50, budget)
+
+	used := 0
+	for _, r := range got {
+		used += requirementTokens(r)
+	}
+	if used > budget {
+		t.Errorf("expected selection within %d tokens, used %d", budget, used)
+	}
+	if len(got) == 0 {
+		t.Error("expected some widgets to fit")
+	}
+}
+
+func TestSelectRelevantRequirements_KeepsOriginalOrder(t *testing.T) {
+	got := SelectRelevantRequirements(relevanceFixture(), "task due date and invoice email", 2, 100000)
+
+	if strings.Join(ids(got), ",") != "REQ-BILL-invoice003,REQ-TASK-create0004" {
+		t.Errorf("expected spec order, got %v", ids(got))
+	}
+}
+
+func TestClient_RelevantRequirements_UsesSmallestWindow(t *testing.T) {
+	client, err := NewClient(&Config{
+		APIKey:       "k",
+		BaseURL:      "u",
+		DefaultModel: "m",
+		Models:       []ModelConfig{{Name: "tiny/model", ContextWindow: 8200}},
+		Routes: map[string]ModelRoute{
+			"delta": {Model: "google/gemini-2.5-flash", Fallbacks: []string{"tiny/model"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Budget is 8200/2 - 4096 = 4 tokens: only explicit IDs survive
+	got := client.RelevantRequirements("delta", relevanceFixture(), "dashboard widget REQ-TASK-create0004")
+	if len(got) != 1 || got[0].ID != "REQ-TASK-create0004" {
+		t.Errorf("expected only the named requirement, got %v", ids(got))
+	}
+}
@@ -15,11 +15,15 @@ func ExecuteCategorizationTask(
 	input *CategorizationInput,
 ) (*CategorizationOutput, error) {
 	// Build prompt
-	prompt := llm.BuildCategorizationPrompt(
-		input.ProjectName,
-		input.ProjectDescription,
-		input.AllRequirementBriefs,
-	)
+	prompt, err := client.Prompts().Render(llm.PromptCategorization, llm.CategorizationPromptData{
+		ProjectName:        input.ProjectName,
+		ProjectDescription: input.ProjectDescription,
+		RequirementBriefs:  input.AllRequirementBriefs,
+		ExistingCategories: input.ExistingCategories,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Validation function
 	validate := func(output *CategorizationOutput) error {
@@ -29,9 +33,8 @@ func ExecuteCategorizationTask(
 
 		// Validate categories
 		for i, cat := range output.Categories {
-			if len(cat.Name) < schema.CategoryNameMin || len(cat.Name) > schema.CategoryNameMax {
-				return fmt.Errorf("categories[%d]: name must be %d-%d chars, got %d",
-					i, schema.CategoryNameMin, schema.CategoryNameMax, len(cat.Name))
+			if err := schema.ValidateCategoryPath(cat.Name); err != nil {
+				return fmt.Errorf("categories[%d]: %w", i, err)
 			}
 			if cat.Description == "" {
 				return fmt.Errorf("categories[%d]: description is required", i)
@@ -65,11 +68,11 @@ func ExecuteCategorizationTask(
 		return nil
 	}
 
-	// Call LLM with thinking model for better reasoning
+	// Call LLM with retry (llm.DefaultRoutes sends this to a thinking model)
 	result, err := llm.GenerateStructured[CategorizationOutput](
 		client,
-		ctx,
-		"google/gemini-2.0-flash-thinking-exp", // Use thinking model
+		llm.WithTask(ctx, TaskCategorization),
+		"", // Use routed model
 		prompt,
 		validate,
 	)
@@ -65,6 +65,46 @@ func TestCategorizationValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "nested categories",
+			input: &CategorizationInput{
+				AllRequirementBriefs: []string{"SAML login", "Password login"},
+			},
+			output: &CategorizationOutput{
+				Categories: []struct {
+					Name        string `json:"name"`
+					Description string `json:"description"`
+					Count       int    `json:"count"`
+				}{
+					{Name: "AUTH/SSO", Description: "Single sign-on", Count: 1},
+					{Name: "AUTH/PASSWORD", Description: "Password login", Count: 1},
+				},
+				RequirementMapping: map[string]string{
+					"SAML login":     "AUTH/SSO",
+					"Password login": "AUTH/PASSWORD",
+				},
+				Reasoning: "Login split by mechanism",
+			},
+			wantErr: false,
+		},
+		{
+			name: "category nested too deep",
+			input: &CategorizationInput{
+				AllRequirementBriefs: []string{"SAML login"},
+			},
+			output: &CategorizationOutput{
+				Categories: []struct {
+					Name        string `json:"name"`
+					Description string `json:"description"`
+					Count       int    `json:"count"`
+				}{
+					{Name: "A/B/C/D/E", Description: "Too deep", Count: 1},
+				},
+				RequirementMapping: map[string]string{"SAML login": "A/B/C/D/E"},
+				Reasoning:          "Test",
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing requirement mapping",
 			input: &CategorizationInput{
@@ -113,7 +153,7 @@ func validateCategorization(input *CategorizationInput, output *CategorizationOu
 	}
 
 	for i, cat := range output.Categories {
-		if len(cat.Name) < schema.CategoryNameMin || len(cat.Name) > schema.CategoryNameMax {
+		if schema.ValidateCategoryPath(cat.Name) != nil {
 			return assert.AnError
 		}
 		if cat.Description == "" {
@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"xdd/internal/llm"
+)
+
+// ExecuteConflictTask compares new requirements against the relevant
+// existing ones and reports contradicting pairs.
+func ExecuteConflictTask(
+	client *llm.Client,
+	ctx context.Context,
+	input *ConflictInput,
+) (*ConflictOutput, error) {
+	// Build prompt
+	descriptions := make([]string, 0, len(input.NewRequirements))
+	for _, req := range input.NewRequirements {
+		descriptions = append(descriptions, req.Description)
+	}
+	relevant := client.RelevantRequirements(TaskConflicts, input.ExistingRequirements, strings.Join(descriptions, " "))
+
+	prompt, err := client.Prompts().Render(llm.PromptConflicts, llm.ConflictsPromptData{
+		NewRequirements:      input.NewRequirements,
+		ExistingRequirements: relevant,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Call LLM with retry
+	result, err := llm.GenerateStructured[ConflictOutput](
+		client,
+		llm.WithTask(ctx, TaskConflicts),
+		"", // Use routed model
+		prompt,
+		func(output *ConflictOutput) error {
+			return validateConflicts(input, output)
+		},
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("conflict analysis task failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// validateConflicts checks each conflict names two known requirements, at
+// least one of them new.
+func validateConflicts(input *ConflictInput, output *ConflictOutput) error {
+	isNew := make(map[string]bool, len(input.NewRequirements))
+	for _, req := range input.NewRequirements {
+		isNew[req.ID] = true
+	}
+	known := make(map[string]bool, len(input.ExistingRequirements))
+	for _, req := range input.ExistingRequirements {
+		known[req.ID] = true
+	}
+
+	validSeverity := map[string]bool{
+		ConflictCritical: true,
+		ConflictMajor:    true,
+		ConflictMinor:    true,
+	}
+
+	for i, c := range output.Conflicts {
+		for _, id := range []string{c.RequirementA, c.RequirementB} {
+			if !isNew[id] && !known[id] {
+				return fmt.Errorf("conflicts[%d]: unknown requirement '%s'", i, id)
+			}
+		}
+		if c.RequirementA == c.RequirementB {
+			return fmt.Errorf("conflicts[%d]: a requirement cannot conflict with itself", i)
+		}
+		if !isNew[c.RequirementA] && !isNew[c.RequirementB] {
+			return fmt.Errorf("conflicts[%d]: at least one requirement must be new", i)
+		}
+		if !validSeverity[c.Severity] {
+			return fmt.Errorf("conflicts[%d]: severity must be 'critical', 'major', or 'minor', got '%s'", i, c.Severity)
+		}
+		if c.Explanation == "" {
+			return fmt.Errorf("conflicts[%d]: explanation is required", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"xdd/pkg/schema"
+)
+
+func TestConflictValidation(t *testing.T) {
+	input := &ConflictInput{
+		NewRequirements:      []schema.Requirement{{ID: "REQ-AUTH-new"}, {ID: "REQ-AUTH-new2"}},
+		ExistingRequirements: []schema.Requirement{{ID: "REQ-AUTH-old"}, {ID: "REQ-AUTH-old2"}},
+	}
+	conflict := func(a, b, severity, explanation string) *ConflictOutput {
+		return &ConflictOutput{Conflicts: []Conflict{{
+			RequirementA: a, RequirementB: b, Severity: severity, Explanation: explanation,
+		}}}
+	}
+
+	tests := []struct {
+		name    string
+		output  *ConflictOutput
+		wantErr bool
+	}{
+		{name: "no conflicts", output: &ConflictOutput{}},
+		{name: "new vs existing", output: conflict("REQ-AUTH-new", "REQ-AUTH-old", ConflictCritical, "15 minutes vs 24 hours")},
+		{name: "new vs new", output: conflict("REQ-AUTH-new", "REQ-AUTH-new2", ConflictMinor, "Inconsistent units")},
+		{name: "existing vs existing", output: conflict("REQ-AUTH-old", "REQ-AUTH-old2", ConflictMajor, "Old conflict"), wantErr: true},
+		{name: "unknown requirement", output: conflict("REQ-AUTH-new", "REQ-AUTH-ghost", ConflictMajor, "x"), wantErr: true},
+		{name: "self conflict", output: conflict("REQ-AUTH-new", "REQ-AUTH-new", ConflictMajor, "x"), wantErr: true},
+		{name: "invalid severity", output: conflict("REQ-AUTH-new", "REQ-AUTH-old", "blocker", "x"), wantErr: true},
+		{name: "missing explanation", output: conflict("REQ-AUTH-new", "REQ-AUTH-old", ConflictMinor, ""), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConflicts(input, tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
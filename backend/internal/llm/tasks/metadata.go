@@ -15,7 +15,13 @@ func ExecuteMetadataTask(
 	input *MetadataInput,
 ) (*MetadataOutput, error) {
 	// Build prompt
-	prompt := llm.BuildMetadataPrompt(input.Existing, input.UpdateRequest)
+	prompt, err := client.Prompts().Render(llm.PromptMetadata, llm.MetadataPromptData{
+		Existing:      input.Existing,
+		UpdateRequest: input.UpdateRequest,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Validation function
 	validate := func(output *MetadataOutput) error {
@@ -36,8 +42,8 @@ func ExecuteMetadataTask(
 	// Call LLM with retry
 	result, err := llm.GenerateStructured[MetadataOutput](
 		client,
-		ctx,
-		"", // Use default model from config
+		llm.WithTask(ctx, TaskMetadata),
+		"", // Use routed model
 		prompt,
 		validate,
 	)
@@ -15,97 +15,142 @@ func ExecuteRequirementGenTask(
 	input *RequirementGenInput,
 ) (*RequirementGenOutput, error) {
 	// Build prompt
-	prompt := llm.BuildRequirementGenerationPrompt(
-		input.Category,
-		input.EARSType,
-		input.BriefDescription,
-		input.EstimatedPriority,
-		input.Context.ProjectName,
-		input.Context.ProjectDescription,
+	relevant := client.RelevantRequirements(
+		TaskRequirementGen,
 		input.Context.ExistingRequirements,
-		input.Context.UpdateRequest,
+		input.BriefDescription+" "+input.Context.UpdateRequest,
 	)
+	prompt, err := client.Prompts().Render(llm.PromptRequirementGen, llm.RequirementGenPromptData{
+		Category:             input.Category,
+		EARSType:             input.EARSType,
+		BriefDescription:     input.BriefDescription,
+		EstimatedPriority:    input.EstimatedPriority,
+		ProjectName:          input.Context.ProjectName,
+		ProjectDescription:   input.Context.ProjectDescription,
+		ExistingRequirements: relevant,
+		UpdateRequest:        input.Context.UpdateRequest,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// Validation function
-	validate := func(output *RequirementGenOutput) error {
-		// Validate description
-		if len(output.Description) < schema.RequirementDescriptionMin ||
-			len(output.Description) > schema.RequirementDescriptionMax {
-			return fmt.Errorf("description must be %d-%d chars, got %d",
-				schema.RequirementDescriptionMin, schema.RequirementDescriptionMax, len(output.Description))
-		}
+	// Call LLM with retry
+	result, err := llm.GenerateStructured[RequirementGenOutput](
+		client,
+		llm.WithTask(ctx, TaskRequirementGen),
+		"", // Use routed model
+		prompt,
+		validateRequirementGen,
+	)
 
-		// Validate rationale
-		if len(output.Rationale) < schema.RequirementRationaleMin ||
-			len(output.Rationale) > schema.RequirementRationaleMax {
-			return fmt.Errorf("rationale must be %d-%d chars, got %d",
-				schema.RequirementRationaleMin, schema.RequirementRationaleMax, len(output.Rationale))
-		}
+	if err != nil {
+		return nil, fmt.Errorf("requirement generation task failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// validateRequirementGen checks the generated requirement against schema limits.
+func validateRequirementGen(output *RequirementGenOutput) error {
+	// Validate description
+	if len(output.Description) < schema.RequirementDescriptionMin ||
+		len(output.Description) > schema.RequirementDescriptionMax {
+		return fmt.Errorf("description must be %d-%d chars, got %d",
+			schema.RequirementDescriptionMin, schema.RequirementDescriptionMax, len(output.Description))
+	}
+
+	// Validate rationale
+	if len(output.Rationale) < schema.RequirementRationaleMin ||
+		len(output.Rationale) > schema.RequirementRationaleMax {
+		return fmt.Errorf("rationale must be %d-%d chars, got %d",
+			schema.RequirementRationaleMin, schema.RequirementRationaleMax, len(output.Rationale))
+	}
+
+	// Validate acceptance criteria
+	if len(output.AcceptanceCriteria) < schema.AcceptanceCriterionMin ||
+		len(output.AcceptanceCriteria) > schema.AcceptanceCriterionMax {
+		return fmt.Errorf("acceptance_criteria must have %d-%d items, got %d",
+			schema.AcceptanceCriterionMin, schema.AcceptanceCriterionMax, len(output.AcceptanceCriteria))
+	}
 
-		// Validate acceptance criteria
-		if len(output.AcceptanceCriteria) < schema.AcceptanceCriterionMin ||
-			len(output.AcceptanceCriteria) > schema.AcceptanceCriterionMax {
-			return fmt.Errorf("acceptance_criteria must have %d-%d items, got %d",
-				schema.AcceptanceCriterionMin, schema.AcceptanceCriterionMax, len(output.AcceptanceCriteria))
+	// Validate each acceptance criterion
+	for i, ac := range output.AcceptanceCriteria {
+		switch ac.Type {
+		case "behavioral", "assertion", "metric", "scenario_outline":
+		default:
+			return fmt.Errorf("acceptance_criteria[%d]: type must be 'behavioral', 'assertion', 'metric' or 'scenario_outline', got '%s'", i, ac.Type)
 		}
 
-		// Validate each acceptance criterion
-		for i, ac := range output.AcceptanceCriteria {
-			if ac.Type != "behavioral" && ac.Type != "assertion" {
-				return fmt.Errorf("acceptance_criteria[%d]: type must be 'behavioral' or 'assertion', got '%s'", i, ac.Type)
+		if ac.Type == "behavioral" {
+			if ac.Given == "" || ac.When == "" || ac.Then == "" {
+				return fmt.Errorf("acceptance_criteria[%d]: behavioral type requires given, when, and then", i)
 			}
-
-			if ac.Type == "behavioral" {
-				if ac.Given == "" || ac.When == "" || ac.Then == "" {
-					return fmt.Errorf("acceptance_criteria[%d]: behavioral type requires given, when, and then", i)
-				}
-				if len(ac.Given) > schema.GivenWhenThenMax {
-					return fmt.Errorf("acceptance_criteria[%d]: given exceeds %d chars", i, schema.GivenWhenThenMax)
-				}
-				if len(ac.When) > schema.GivenWhenThenMax {
-					return fmt.Errorf("acceptance_criteria[%d]: when exceeds %d chars", i, schema.GivenWhenThenMax)
-				}
-				if len(ac.Then) > schema.GivenWhenThenMax {
-					return fmt.Errorf("acceptance_criteria[%d]: then exceeds %d chars", i, schema.GivenWhenThenMax)
-				}
+			if len(ac.Given) > schema.GivenWhenThenMax {
+				return fmt.Errorf("acceptance_criteria[%d]: given exceeds %d chars", i, schema.GivenWhenThenMax)
+			}
+			if len(ac.When) > schema.GivenWhenThenMax {
+				return fmt.Errorf("acceptance_criteria[%d]: when exceeds %d chars", i, schema.GivenWhenThenMax)
+			}
+			if len(ac.Then) > schema.GivenWhenThenMax {
+				return fmt.Errorf("acceptance_criteria[%d]: then exceeds %d chars", i, schema.GivenWhenThenMax)
 			}
+		}
 
-			if ac.Type == "assertion" {
-				if ac.Statement == "" {
-					return fmt.Errorf("acceptance_criteria[%d]: assertion type requires statement", i)
-				}
-				if len(ac.Statement) > schema.AssertionStatementMax {
-					return fmt.Errorf("acceptance_criteria[%d]: statement exceeds %d chars", i, schema.AssertionStatementMax)
-				}
+		if ac.Type == "assertion" {
+			if ac.Statement == "" {
+				return fmt.Errorf("acceptance_criteria[%d]: assertion type requires statement", i)
+			}
+			if len(ac.Statement) > schema.AssertionStatementMax {
+				return fmt.Errorf("acceptance_criteria[%d]: statement exceeds %d chars", i, schema.AssertionStatementMax)
 			}
 		}
 
-		// Validate priority
-		validPriority := map[string]bool{
-			"critical": true,
-			"high":     true,
-			"medium":   true,
-			"low":      true,
+		if ac.Type == "metric" {
+			if err := schema.ValidateMetricCriterion(&schema.MetricCriterion{
+				Type:       ac.Type,
+				Measure:    ac.Measure,
+				Comparator: ac.Comparator,
+				Threshold:  ac.Threshold,
+				Unit:       ac.Unit,
+				Conditions: ac.Conditions,
+			}); err != nil {
+				return fmt.Errorf("acceptance_criteria[%d]: %w", i, err)
+			}
 		}
-		if !validPriority[output.Priority] {
-			return fmt.Errorf("invalid priority '%s', must be critical|high|medium|low", output.Priority)
+
+		if ac.Type == "scenario_outline" {
+			if ac.Examples == nil {
+				return fmt.Errorf("acceptance_criteria[%d]: scenario_outline type requires examples", i)
+			}
+			if err := schema.ValidateScenarioOutlineCriterion(&schema.ScenarioOutlineCriterion{
+				Type:     ac.Type,
+				Given:    ac.Given,
+				When:     ac.When,
+				Then:     ac.Then,
+				Examples: *ac.Examples,
+			}); err != nil {
+				return fmt.Errorf("acceptance_criteria[%d]: %w", i, err)
+			}
 		}
 
-		return nil
+		if err := schema.ValidateVerification(schema.Verification{
+			Method: schema.VerificationMethod(ac.VerificationMethod),
+			Ref:    ac.VerificationRef,
+		}); err != nil {
+			return fmt.Errorf("acceptance_criteria[%d]: %w", i, err)
+		}
 	}
 
-	// Call LLM with retry
-	result, err := llm.GenerateStructured[RequirementGenOutput](
-		client,
-		ctx,
-		"", // Use default model
-		prompt,
-		validate,
-	)
-
-	if err != nil {
-		return nil, fmt.Errorf("requirement generation task failed: %w", err)
+	// Validate priority
+	validPriority := map[string]bool{
+		"critical": true,
+		"high":     true,
+		"medium":   true,
+		"low":      true,
+	}
+	if !validPriority[output.Priority] {
+		return fmt.Errorf("invalid priority '%s', must be critical|high|medium|low", output.Priority)
 	}
 
-	return result, nil
+	return nil
 }
@@ -3,8 +3,10 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"xdd/internal/llm"
+	"xdd/pkg/schema"
 )
 
 // AmbiguousModificationError is returned when user input is ambiguous.
@@ -25,12 +27,22 @@ func ExecuteRequirementsDeltaTask(
 	ctx context.Context,
 	input *RequirementsDeltaInput,
 ) (*RequirementsDeltaOutput, error) {
-	// Build prompt
-	prompt := llm.BuildRequirementsDeltaPrompt(
-		input.ExistingRequirements,
-		input.ExistingCategories,
-		input.UpdateRequest,
-	)
+	// Build prompt from the requirements relevant to the request
+	// (IDs chosen while resolving ambiguities count as named explicitly)
+	query := input.UpdateRequest
+	for _, res := range input.Resolutions {
+		query += " " + strings.Join(res.TargetIDs, " ")
+	}
+	relevant := client.RelevantRequirements(TaskRequirementsDelta, input.ExistingRequirements, query)
+	prompt, err := client.Prompts().Render(llm.PromptRequirementsDelta, llm.RequirementsDeltaPromptData{
+		ExistingRequirements: relevant,
+		ExistingCategories:   input.ExistingCategories,
+		UpdateRequest:        input.UpdateRequest,
+		Resolutions:          input.Resolutions,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Validation function
 	validate := func(output *RequirementsDeltaOutput) error {
@@ -46,8 +58,8 @@ func ExecuteRequirementsDeltaTask(
 
 		// Validate additions
 		for i, add := range output.ToAdd {
-			if add.Category == "" {
-				return fmt.Errorf("to_add[%d]: category is required", i)
+			if err := schema.ValidateCategoryPath(add.Category); err != nil {
+				return fmt.Errorf("to_add[%d]: %w", i, err)
 			}
 			if add.BriefDescription == "" {
 				return fmt.Errorf("to_add[%d]: brief_description is required", i)
@@ -93,8 +105,8 @@ func ExecuteRequirementsDeltaTask(
 	// Call LLM with retry
 	result, err := llm.GenerateStructured[RequirementsDeltaOutput](
 		client,
-		ctx,
-		"", // Use default model
+		llm.WithTask(ctx, TaskRequirementsDelta),
+		"", // Use routed model
 		prompt,
 		validate,
 	)
@@ -0,0 +1,107 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"xdd/internal/llm"
+	"xdd/pkg/schema"
+)
+
+// ExecuteReviewTask reviews a requirement against the ISO/IEC/IEEE 29148
+// quality attributes, returning scored findings with suggested rewrites.
+func ExecuteReviewTask(
+	client *llm.Client,
+	ctx context.Context,
+	input *ReviewInput,
+) (*ReviewOutput, error) {
+	req := input.Requirement
+	criteria := make([]string, 0, len(req.AcceptanceCriteria))
+	for _, ac := range req.AcceptanceCriteria {
+		criteria = append(criteria, describeCriterion(ac))
+	}
+
+	// Build prompt
+	prompt, err := client.Prompts().Render(llm.PromptReview, llm.ReviewPromptData{
+		ID:                 req.ID,
+		EARSType:           string(req.Type),
+		Category:           req.Category,
+		Description:        req.Description,
+		Rationale:          req.Rationale,
+		AcceptanceCriteria: criteria,
+		Attributes:         QualityAttributes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Call LLM with retry
+	result, err := llm.GenerateStructured[ReviewOutput](
+		client,
+		llm.WithTask(ctx, TaskReview),
+		"", // Use routed model
+		prompt,
+		validateReview,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("review task failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// validateReview checks scores are in range and findings name known attributes.
+func validateReview(output *ReviewOutput) error {
+	if output.Score < 0 || output.Score > 100 {
+		return fmt.Errorf("score must be 0-100, got %d", output.Score)
+	}
+
+	validSeverity := map[string]bool{
+		"high":   true,
+		"medium": true,
+		"low":    true,
+	}
+
+	for i, f := range output.Findings {
+		if !slices.Contains(QualityAttributes(), f.Attribute) {
+			return fmt.Errorf("findings[%d]: attribute must be one of %v, got '%s'", i, QualityAttributes(), f.Attribute)
+		}
+		if !validSeverity[f.Severity] {
+			return fmt.Errorf("findings[%d]: severity must be 'high', 'medium', or 'low', got '%s'", i, f.Severity)
+		}
+		if f.Score < 1 || f.Score > 5 {
+			return fmt.Errorf("findings[%d]: score must be 1-5, got %d", i, f.Score)
+		}
+		if f.Issue == "" {
+			return fmt.Errorf("findings[%d]: issue is required", i)
+		}
+		if f.SuggestedRewrite == "" {
+			return fmt.Errorf("findings[%d]: suggested_rewrite is required", i)
+		}
+	}
+
+	return nil
+}
+
+// describeCriterion renders an acceptance criterion as a single line.
+func describeCriterion(ac schema.AcceptanceCriterion) string {
+	var line string
+	switch c := ac.(type) {
+	case *schema.BehavioralCriterion:
+		line = fmt.Sprintf("Given %s, when %s, then %s", c.Given, c.When, c.Then)
+	case *schema.AssertionCriterion:
+		line = c.Statement
+	case *schema.MetricCriterion:
+		line = c.Expression()
+	case *schema.ScenarioOutlineCriterion:
+		line = fmt.Sprintf("Given %s, when %s, then %s (examples %s)", c.Given, c.When, c.Then, c.Examples)
+	default:
+		line = ac.GetType()
+	}
+	if v := ac.GetVerification(); v.Method != "" {
+		line += " [verified by " + v.String() + "]"
+	}
+	return line
+}
@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"xdd/pkg/schema"
+)
+
+func TestReviewValidation(t *testing.T) {
+	finding := func(mutate func(*ReviewFinding)) ReviewFinding {
+		f := ReviewFinding{
+			Attribute:        QualityUnambiguous,
+			Severity:         "high",
+			Score:            2,
+			Issue:            `"fast" is not measurable`,
+			Excerpt:          "fast",
+			SuggestedRewrite: "When the user searches, the system shall return results within 500 ms",
+		}
+		if mutate != nil {
+			mutate(&f)
+		}
+		return f
+	}
+
+	tests := []struct {
+		name    string
+		output  *ReviewOutput
+		wantErr bool
+	}{
+		{name: "no findings", output: &ReviewOutput{Score: 95, Summary: "Well formed"}},
+		{name: "valid finding", output: &ReviewOutput{Score: 60, Findings: []ReviewFinding{finding(nil)}}},
+		{name: "score out of range", output: &ReviewOutput{Score: 120}, wantErr: true},
+		{
+			name:    "unknown attribute",
+			output:  &ReviewOutput{Score: 60, Findings: []ReviewFinding{finding(func(f *ReviewFinding) { f.Attribute = "testable" })}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid severity",
+			output:  &ReviewOutput{Score: 60, Findings: []ReviewFinding{finding(func(f *ReviewFinding) { f.Severity = "critical" })}},
+			wantErr: true,
+		},
+		{
+			name:    "finding score out of range",
+			output:  &ReviewOutput{Score: 60, Findings: []ReviewFinding{finding(func(f *ReviewFinding) { f.Score = 0 })}},
+			wantErr: true,
+		},
+		{
+			name:    "missing rewrite",
+			output:  &ReviewOutput{Score: 60, Findings: []ReviewFinding{finding(func(f *ReviewFinding) { f.SuggestedRewrite = "" })}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateReview(tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDescribeCriterion(t *testing.T) {
+	assert.Equal(t, "Given a cart, when checking out, then an order is created",
+		describeCriterion(&schema.BehavioralCriterion{Given: "a cart", When: "checking out", Then: "an order is created"}))
+	assert.Equal(t, "Orders are persisted",
+		describeCriterion(&schema.AssertionCriterion{Statement: "Orders are persisted"}))
+	assert.Equal(t, "p95 latency < 200 ms under 1k rps",
+		describeCriterion(&schema.MetricCriterion{Measure: "p95 latency", Comparator: "<", Threshold: 200, Unit: "ms", Conditions: "under 1k rps"}))
+	assert.Equal(t, "Given role <role>, when opening /admin, then status is <status> (examples role | status: admin | 200; guest | 403)",
+		describeCriterion(&schema.ScenarioOutlineCriterion{Given: "role <role>", When: "opening /admin", Then: "status is <status>",
+			Examples: schema.ExamplesTable{Columns: []string{"role", "status"}, Rows: [][]string{{"admin", "200"}, {"guest", "403"}}}}))
+	assert.Equal(t, "Orders are persisted [verified by inspection: schema review]",
+		describeCriterion(&schema.AssertionCriterion{Statement: "Orders are persisted",
+			Verification: schema.Verification{Method: schema.VerificationInspection, Ref: "schema review"}}))
+}
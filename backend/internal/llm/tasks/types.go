@@ -1,9 +1,44 @@
 package tasks
 
 import (
+	"xdd/internal/llm"
 	"xdd/pkg/schema"
 )
 
+// Task names identify each pipeline step in cache keys, model routes and logs.
+const (
+	TaskMetadata          = "metadata"
+	TaskRequirementsDelta = "delta"
+	TaskCategorization    = "categorization"
+	TaskRequirementGen    = "requirement_gen"
+	TaskReview            = "review"
+	TaskConflicts         = "conflicts"
+	TaskVersionBump       = "version_bump"
+)
+
+// TaskNames lists every pipeline task in execution order.
+func TaskNames() []string {
+	return []string{
+		TaskMetadata,
+		TaskRequirementsDelta,
+		TaskCategorization,
+		TaskRequirementGen,
+		TaskReview,
+		TaskConflicts,
+		TaskVersionBump,
+	}
+}
+
+// TaskEvalJudge names the eval harness's judge calls. It is not a pipeline
+// step but can be routed like one.
+const TaskEvalJudge = "eval_judge"
+
+// RoutableTaskNames lists every task a project may route: the pipeline
+// tasks, then the eval judge.
+func RoutableTaskNames() []string {
+	return append(TaskNames(), TaskEvalJudge)
+}
+
 // Metadata Task Types
 
 // MetadataInput is the input for metadata generation/update task.
@@ -28,9 +63,10 @@ type MetadataOutput struct {
 
 // RequirementsDeltaInput is the input for requirements delta analysis.
 type RequirementsDeltaInput struct {
-	ExistingRequirements []schema.Requirement `json:"existing_requirements"`
-	ExistingCategories   []string             `json:"existing_categories"`
-	UpdateRequest        string               `json:"update_request"`
+	ExistingRequirements []schema.Requirement    `json:"existing_requirements"`
+	ExistingCategories   []schema.Category       `json:"existing_categories"`
+	UpdateRequest        string                  `json:"update_request"`
+	Resolutions          []llm.ResolvedAmbiguity `json:"resolutions,omitempty"`
 }
 
 // RequirementsDeltaOutput is the output from requirements delta task.
@@ -58,9 +94,10 @@ type RequirementsDeltaOutput struct {
 
 // CategorizationInput is the input for categorization task.
 type CategorizationInput struct {
-	ProjectName          string   `json:"project_name"`
-	ProjectDescription   string   `json:"project_description"`
-	AllRequirementBriefs []string `json:"all_requirement_briefs"`
+	ProjectName          string            `json:"project_name"`
+	ProjectDescription   string            `json:"project_description"`
+	AllRequirementBriefs []string          `json:"all_requirement_briefs"`
+	ExistingCategories   []schema.Category `json:"existing_categories,omitempty"`
 }
 
 // CategorizationOutput is the output from categorization task.
@@ -104,18 +141,113 @@ type RequirementGenOutput struct {
 // AcceptanceCriterionJSON represents an acceptance criterion in JSON format
 // This intermediate type handles polymorphic deserialization from LLM.
 type AcceptanceCriterionJSON struct {
-	Type      string `json:"type"` // "behavioral" or "assertion"
-	Given     string `json:"given,omitempty"`
-	When      string `json:"when,omitempty"`
-	Then      string `json:"then,omitempty"`
-	Statement string `json:"statement,omitempty"`
+	Type       string  `json:"type"` // "behavioral", "assertion", "metric" or "scenario_outline"
+	Given      string  `json:"given,omitempty"`
+	When       string  `json:"when,omitempty"`
+	Then       string  `json:"then,omitempty"`
+	Statement  string  `json:"statement,omitempty"`
+	Measure    string  `json:"measure,omitempty"`
+	Comparator string  `json:"comparator,omitempty"`
+	Threshold  float64 `json:"threshold,omitempty"`
+	Unit       string  `json:"unit,omitempty"`
+	Conditions string  `json:"conditions,omitempty"`
+
+	Examples *schema.ExamplesTable `json:"examples,omitempty"` // scenario_outline only
+
+	VerificationMethod string `json:"verification_method,omitempty"` // test, inspection, analysis or demonstration
+	VerificationRef    string `json:"verification_ref,omitempty"`    // Artefact that verifies it, e.g. a test file
+}
+
+// Review Task Types
+
+// Quality attributes of a well-formed individual requirement (ISO/IEC/IEEE 29148, 5.2.5).
+const (
+	QualityNecessary   = "necessary"
+	QualityAppropriate = "appropriate"
+	QualityUnambiguous = "unambiguous"
+	QualityComplete    = "complete"
+	QualitySingular    = "singular"
+	QualityFeasible    = "feasible"
+	QualityVerifiable  = "verifiable"
+	QualityCorrect     = "correct"
+	QualityConforming  = "conforming"
+)
+
+// QualityAttributes lists the attributes a review scores against.
+func QualityAttributes() []string {
+	return []string{
+		QualityNecessary,
+		QualityAppropriate,
+		QualityUnambiguous,
+		QualityComplete,
+		QualitySingular,
+		QualityFeasible,
+		QualityVerifiable,
+		QualityCorrect,
+		QualityConforming,
+	}
+}
+
+// ReviewInput is the input for the requirement quality review task.
+type ReviewInput struct {
+	Requirement schema.Requirement `json:"requirement"`
+}
+
+// ReviewOutput is the output from the review task.
+type ReviewOutput struct {
+	Score    int             `json:"score"` // Overall quality, 0-100
+	Findings []ReviewFinding `json:"findings"`
+	Summary  string          `json:"summary"`
+}
+
+// ReviewFinding is one quality problem with a suggested fix.
+type ReviewFinding struct {
+	Attribute        string `json:"attribute"` // One of QualityAttributes()
+	Severity         string `json:"severity"`  // "high"|"medium"|"low"
+	Score            int    `json:"score"`     // How well the attribute is met, 1 (poor) to 5 (good)
+	Issue            string `json:"issue"`
+	Excerpt          string `json:"excerpt,omitempty"` // Offending phrase, e.g. "fast"
+	SuggestedRewrite string `json:"suggested_rewrite"`
+}
+
+// Conflict Analysis Task Types
+
+// Conflict severities, most severe first. Critical conflicts block commit.
+const (
+	ConflictCritical = "critical"
+	ConflictMajor    = "major"
+	ConflictMinor    = "minor"
+)
+
+// ConflictInput is the input for the conflict analysis task.
+type ConflictInput struct {
+	NewRequirements      []schema.Requirement `json:"new_requirements"`
+	ExistingRequirements []schema.Requirement `json:"existing_requirements"`
+}
+
+// ConflictOutput is the output from the conflict analysis task.
+type ConflictOutput struct {
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// Conflict is a pair of requirements that cannot both be satisfied.
+// At least one side is a new requirement.
+type Conflict struct {
+	RequirementA string `json:"requirement_a"`
+	RequirementB string `json:"requirement_b"`
+	Severity     string `json:"severity"` // "critical"|"major"|"minor"
+	Explanation  string `json:"explanation"`
 }
 
 // Version Bump Task Types
 
 // VersionBumpInput is the input for version bump decision task.
+// NewVersion and BumpType are computed by rules; the model only explains them.
 type VersionBumpInput struct {
 	CurrentVersion     string         `json:"current_version"`
+	NewVersion         string         `json:"new_version"`
+	BumpType           string         `json:"bump_type"`
+	Rules              []string       `json:"rules"` // Policy rules that decided the bump
 	Changes            VersionChanges `json:"changes"`
 	ChangeDescriptions []string       `json:"change_descriptions"`
 }
@@ -128,6 +260,7 @@ type VersionChanges struct {
 }
 
 // VersionBumpOutput is the output from version bump task.
+// NewVersion and BumpType must echo the computed bump.
 type VersionBumpOutput struct {
 	NewVersion string `json:"new_version"`
 	BumpType   string `json:"bump_type"` // "major"|"minor"|"patch"
@@ -10,53 +10,36 @@ import (
 
 var semverRegex = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
 
-// ExecuteVersionBumpTask determines appropriate semantic version bump.
+// ExecuteVersionBumpTask explains a version bump computed by the versioning
+// rules. The model only writes the reasoning; a proposal that differs from
+// input.NewVersion or input.BumpType is rejected.
 func ExecuteVersionBumpTask(
 	client *llm.Client,
 	ctx context.Context,
 	input *VersionBumpInput,
 ) (*VersionBumpOutput, error) {
 	// Build prompt
-	prompt := llm.BuildVersionBumpPrompt(
-		input.CurrentVersion,
-		input.Changes.RequirementsAdded,
-		input.Changes.RequirementsRemoved,
-		input.Changes.MetadataChanged,
-		input.ChangeDescriptions,
-	)
-
-	// Validation function
-	validate := func(output *VersionBumpOutput) error {
-		// Validate new version format
-		if !semverRegex.MatchString(output.NewVersion) {
-			return fmt.Errorf("new_version must be valid semver (X.Y.Z), got '%s'", output.NewVersion)
-		}
-
-		// Validate bump type
-		validBumpType := map[string]bool{
-			"major": true,
-			"minor": true,
-			"patch": true,
-		}
-		if !validBumpType[output.BumpType] {
-			return fmt.Errorf("bump_type must be 'major', 'minor', or 'patch', got '%s'", output.BumpType)
-		}
-
-		// Validate reasoning
-		if output.Reasoning == "" {
-			return fmt.Errorf("reasoning is required")
-		}
-
-		return nil
+	prompt, err := client.Prompts().Render(llm.PromptVersionBump, llm.VersionBumpPromptData{
+		CurrentVersion:      input.CurrentVersion,
+		NewVersion:          input.NewVersion,
+		BumpType:            input.BumpType,
+		Rules:               input.Rules,
+		RequirementsAdded:   input.Changes.RequirementsAdded,
+		RequirementsRemoved: input.Changes.RequirementsRemoved,
+		MetadataChanged:     input.Changes.MetadataChanged,
+		ChangeDescriptions:  input.ChangeDescriptions,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Call LLM with retry
 	result, err := llm.GenerateStructured[VersionBumpOutput](
 		client,
-		ctx,
-		"", // Use default model
+		llm.WithTask(ctx, TaskVersionBump),
+		"", // Use routed model
 		prompt,
-		validate,
+		func(output *VersionBumpOutput) error { return validateVersionBump(input, output) },
 	)
 
 	if err != nil {
@@ -65,3 +48,37 @@ func ExecuteVersionBumpTask(
 
 	return result, nil
 }
+
+// validateVersionBump checks the output is well formed and matches the
+// computed bump.
+func validateVersionBump(input *VersionBumpInput, output *VersionBumpOutput) error {
+	// Validate new version format
+	if !semverRegex.MatchString(output.NewVersion) {
+		return fmt.Errorf("new_version must be valid semver (X.Y.Z), got '%s'", output.NewVersion)
+	}
+
+	// Validate bump type
+	validBumpType := map[string]bool{
+		"major": true,
+		"minor": true,
+		"patch": true,
+	}
+	if !validBumpType[output.BumpType] {
+		return fmt.Errorf("bump_type must be 'major', 'minor', or 'patch', got '%s'", output.BumpType)
+	}
+
+	// The rules decide the version; the model may not override them
+	if output.BumpType != input.BumpType {
+		return fmt.Errorf("bump_type must be '%s' as computed from the changes, got '%s'", input.BumpType, output.BumpType)
+	}
+	if output.NewVersion != input.NewVersion {
+		return fmt.Errorf("new_version must be '%s' as computed from the changes, got '%s'", input.NewVersion, output.NewVersion)
+	}
+
+	// Validate reasoning
+	if output.Reasoning == "" {
+		return fmt.Errorf("reasoning is required")
+	}
+
+	return nil
+}
@@ -8,101 +8,81 @@ import (
 
 // Test validation logic for version bump output.
 func TestVersionBumpValidation(t *testing.T) {
+	input := &VersionBumpInput{
+		CurrentVersion: "1.4.0",
+		NewVersion:     "1.5.0",
+		BumpType:       "minor",
+	}
+
 	tests := []struct {
 		name    string
 		output  *VersionBumpOutput
-		wantErr bool
+		wantErr string
 	}{
 		{
-			name: "valid major bump",
+			name: "matches computed bump",
 			output: &VersionBumpOutput{
-				NewVersion: "2.0.0",
-				BumpType:   "major",
-				Reasoning:  "Breaking changes due to removed requirements",
+				NewVersion: "1.5.0",
+				BumpType:   "minor",
+				Reasoning:  "New features added",
 			},
-			wantErr: false,
 		},
 		{
-			name: "valid minor bump",
+			name: "invalid semver format",
 			output: &VersionBumpOutput{
-				NewVersion: "1.5.0",
+				NewVersion: "v1.5.0",
 				BumpType:   "minor",
-				Reasoning:  "New features added",
+				Reasoning:  "Test",
 			},
-			wantErr: false,
+			wantErr: "valid semver",
 		},
 		{
-			name: "valid patch bump",
+			name: "invalid bump type",
 			output: &VersionBumpOutput{
-				NewVersion: "1.0.1",
-				BumpType:   "patch",
-				Reasoning:  "Metadata and clarifications only",
+				NewVersion: "1.5.0",
+				BumpType:   "breaking",
+				Reasoning:  "Test",
 			},
-			wantErr: false,
+			wantErr: "bump_type must be 'major', 'minor', or 'patch'",
 		},
 		{
-			name: "invalid semver format",
+			name: "different bump type",
 			output: &VersionBumpOutput{
-				NewVersion: "v2.0.0",
+				NewVersion: "2.0.0",
 				BumpType:   "major",
-				Reasoning:  "Test",
+				Reasoning:  "Breaking changes",
 			},
-			wantErr: true,
+			wantErr: "bump_type must be 'minor'",
 		},
 		{
-			name: "invalid bump type",
+			name: "version lower than current",
 			output: &VersionBumpOutput{
-				NewVersion: "1.0.0",
-				BumpType:   "breaking",
-				Reasoning:  "Test",
+				NewVersion: "1.3.0",
+				BumpType:   "minor",
+				Reasoning:  "New features added",
 			},
-			wantErr: true,
+			wantErr: "new_version must be '1.5.0'",
 		},
 		{
 			name: "missing reasoning",
 			output: &VersionBumpOutput{
-				NewVersion: "1.0.0",
-				BumpType:   "patch",
+				NewVersion: "1.5.0",
+				BumpType:   "minor",
 				Reasoning:  "",
 			},
-			wantErr: true,
+			wantErr: "reasoning is required",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Use same validation as in ExecuteVersionBumpTask
-			err := validateVersionBump(tt.output)
+			err := validateVersionBump(input, tt.output)
 
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
 			} else {
 				assert.NoError(t, err)
 			}
 		})
 	}
 }
-
-// Helper function extracted for testing.
-func validateVersionBump(output *VersionBumpOutput) error {
-	if !semverRegex.MatchString(output.NewVersion) {
-		return assert.AnError
-	}
-
-	validBumpType := map[string]bool{
-		"major": true,
-		"minor": true,
-		"patch": true,
-	}
-	if !validBumpType[output.BumpType] {
-		return assert.AnError
-	}
-
-	if output.Reasoning == "" {
-		return assert.AnError
-	}
-
-	return nil
-}
-
-// TODO: Replace with fixture-based tests once recording script is ready
@@ -0,0 +1,282 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"xdd/pkg/schema"
+)
+
+// DefaultPromptDir is the conventional location of per-project prompt overrides.
+const DefaultPromptDir = ".xdd/prompts"
+
+// Prompt template names. Each matches a pipeline task name and a
+// templates/<name>.tmpl file; an override lives at <PromptDir>/<name>.tmpl.
+const (
+	PromptMetadata          = "metadata"
+	PromptRequirementsDelta = "delta"
+	PromptCategorization    = "categorization"
+	PromptRequirementGen    = "requirement_gen"
+	PromptReview            = "review"
+	PromptConflicts         = "conflicts"
+	PromptVersionBump       = "version_bump"
+)
+
+// PromptOriginEmbedded marks a template compiled into the binary.
+const PromptOriginEmbedded = "embedded"
+
+//go:embed templates/*.tmpl
+var embeddedTemplates embed.FS
+
+// MetadataPromptData is the data contract for the metadata template.
+// Existing is nil for a new project.
+type MetadataPromptData struct {
+	Existing      *schema.ProjectMetadata
+	UpdateRequest string
+}
+
+// RequirementsDeltaPromptData is the data contract for the delta template.
+type RequirementsDeltaPromptData struct {
+	ExistingRequirements []schema.Requirement
+	ExistingCategories   []schema.Category
+	UpdateRequest        string
+	Resolutions          []ResolvedAmbiguity
+}
+
+// ResolvedAmbiguity is the user's answer to a clarification question.
+// TargetIDs holds the chosen requirements; Answer holds free-text answers.
+type ResolvedAmbiguity struct {
+	Question  string   `json:"question"`
+	TargetIDs []string `json:"target_ids,omitempty"`
+	Answer    string   `json:"answer,omitempty"`
+}
+
+// CategorizationPromptData is the data contract for the categorization template.
+// ExistingCategories are the project's categories so far, with descriptions.
+type CategorizationPromptData struct {
+	ProjectName        string
+	ProjectDescription string
+	RequirementBriefs  []string
+	ExistingCategories []schema.Category
+}
+
+// RequirementGenPromptData is the data contract for the requirement_gen template.
+type RequirementGenPromptData struct {
+	Category             string
+	EARSType             string
+	BriefDescription     string
+	EstimatedPriority    string
+	ProjectName          string
+	ProjectDescription   string
+	ExistingRequirements []schema.Requirement
+	UpdateRequest        string
+}
+
+// ReviewPromptData is the data contract for the review template.
+// AcceptanceCriteria are pre-rendered one per line.
+type ReviewPromptData struct {
+	ID                 string
+	EARSType           string
+	Category           string
+	Description        string
+	Rationale          string
+	AcceptanceCriteria []string
+	Attributes         []string
+}
+
+// ConflictsPromptData is the data contract for the conflicts template.
+type ConflictsPromptData struct {
+	NewRequirements      []schema.Requirement
+	ExistingRequirements []schema.Requirement
+}
+
+// VersionBumpPromptData is the data contract for the version_bump template.
+// NewVersion and BumpType are already decided; the model writes the reasoning.
+type VersionBumpPromptData struct {
+	CurrentVersion      string
+	NewVersion          string
+	BumpType            string
+	Rules               []string
+	RequirementsAdded   int
+	RequirementsRemoved int
+	MetadataChanged     bool
+	ChangeDescriptions  []string
+}
+
+// promptContracts maps each prompt to a zero value of its data contract,
+// used to check overrides at load time.
+var promptContracts = map[string]any{
+	PromptMetadata:          MetadataPromptData{},
+	PromptRequirementsDelta: RequirementsDeltaPromptData{},
+	PromptCategorization:    CategorizationPromptData{},
+	PromptRequirementGen:    RequirementGenPromptData{},
+	PromptReview:            ReviewPromptData{},
+	PromptConflicts:         ConflictsPromptData{},
+	PromptVersionBump:       VersionBumpPromptData{},
+}
+
+// promptFuncs are available to every template.
+var promptFuncs = template.FuncMap{
+	"join":             strings.Join,
+	"inc":              func(i int) int { return i + 1 },
+	"earsDecisionTree": func() string { return EARSDecisionTree },
+}
+
+// PromptNames returns all prompt template names in pipeline order.
+func PromptNames() []string {
+	return []string{
+		PromptMetadata,
+		PromptRequirementsDelta,
+		PromptCategorization,
+		PromptRequirementGen,
+		PromptReview,
+		PromptConflicts,
+		PromptVersionBump,
+	}
+}
+
+// PromptSet holds the parsed prompt templates for a project.
+type PromptSet struct {
+	templates map[string]*template.Template
+	hashes    map[string]string
+	origins   map[string]string
+}
+
+// LoadPromptSet parses the embedded templates, replacing any that have an
+// override file <overrideDir>/<name>.tmpl. An empty or missing overrideDir
+// uses the embedded templates only. Unknown override files are rejected so
+// typos don't silently fall back to the defaults.
+func LoadPromptSet(overrideDir string) (*PromptSet, error) {
+	set := &PromptSet{
+		templates: make(map[string]*template.Template),
+		hashes:    make(map[string]string),
+		origins:   make(map[string]string),
+	}
+
+	for _, name := range PromptNames() {
+		src, err := embeddedTemplates.ReadFile("templates/" + name + ".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("read embedded prompt %s: %w", name, err)
+		}
+		if err := set.add(name, string(src), PromptOriginEmbedded); err != nil {
+			return nil, err
+		}
+	}
+
+	if overrideDir == "" {
+		return set, nil
+	}
+
+	entries, err := os.ReadDir(overrideDir)
+	if os.IsNotExist(err) {
+		return set, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read prompt overrides: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tmpl" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		if !slices.Contains(PromptNames(), name) {
+			return nil, fmt.Errorf("prompt override %s: unknown prompt %q, must be one of %v",
+				entry.Name(), name, PromptNames())
+		}
+
+		path := filepath.Join(overrideDir, entry.Name())
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read prompt override %s: %w", path, err)
+		}
+		if err := set.add(name, string(src), path); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+// add parses and registers a template, checking it against its data contract.
+func (p *PromptSet) add(name, src, origin string) error {
+	// Editors add a final newline; prompts should not end with one
+	src = strings.TrimSuffix(src, "\n")
+
+	tmpl, err := template.New(name).Funcs(promptFuncs).Option("missingkey=error").Parse(src)
+	if err != nil {
+		return fmt.Errorf("parse prompt %s (%s): %w", name, origin, err)
+	}
+
+	// Executing against the zero contract catches references to unknown fields
+	if err := tmpl.Execute(io.Discard, promptContracts[name]); err != nil {
+		return fmt.Errorf("prompt %s (%s) does not match its data contract: %w", name, origin, err)
+	}
+
+	sum := sha256.Sum256([]byte(src))
+	p.templates[name] = tmpl
+	p.hashes[name] = hex.EncodeToString(sum[:8])
+	p.origins[name] = origin
+	return nil
+}
+
+// Render executes the named template with data.
+func (p *PromptSet) Render(name string, data any) (string, error) {
+	tmpl, ok := p.templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt %q", name)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render prompt %s: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// Hash returns a short content hash of the named template's source.
+func (p *PromptSet) Hash(name string) string {
+	return p.hashes[name]
+}
+
+// Hashes returns the content hash of every template, keyed by name.
+func (p *PromptSet) Hashes() map[string]string {
+	hashes := make(map[string]string, len(p.hashes))
+	for name, hash := range p.hashes {
+		hashes[name] = hash
+	}
+	return hashes
+}
+
+// Origin returns where the named template came from: PromptOriginEmbedded or an override path.
+func (p *PromptSet) Origin(name string) string {
+	return p.origins[name]
+}
+
+// defaultPromptSet holds the embedded templates used by the Build*Prompt helpers.
+var defaultPromptSet = mustLoadPromptSet()
+
+func mustLoadPromptSet() *PromptSet {
+	set, err := LoadPromptSet("")
+	if err != nil {
+		panic(err) // Embedded templates are covered by tests
+	}
+	return set
+}
+
+// mustRender renders an embedded template.
+func mustRender(name string, data any) string {
+	out, err := defaultPromptSet.Render(name, data)
+	if err != nil {
+		panic(err) // Embedded templates are covered by tests
+	}
+	return out
+}
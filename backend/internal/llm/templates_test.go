@@ -0,0 +1,138 @@
+package llm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPromptSet_Embedded(t *testing.T) {
+	set, err := LoadPromptSet("")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range PromptNames() {
+		if set.Origin(name) != PromptOriginEmbedded {
+			t.Errorf("%s: expected embedded origin, got %q", name, set.Origin(name))
+		}
+		if len(set.Hash(name)) != 16 {
+			t.Errorf("%s: expected 16-char hash, got %q", name, set.Hash(name))
+		}
+	}
+
+	// Missing override directory is not an error
+	if _, err := LoadPromptSet(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("expected missing override dir to be ignored, got %v", err)
+	}
+}
+
+func TestLoadPromptSet_Override(t *testing.T) {
+	dir := t.TempDir()
+	override := "Name the project for {{.UpdateRequest}} using our ACME vocabulary.\n"
+	if err := os.WriteFile(filepath.Join(dir, "metadata.tmpl"), []byte(override), 0644); err != nil {
+		t.Fatalf("write override: %v", err)
+	}
+
+	set, err := LoadPromptSet(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := set.Render(PromptMetadata, MetadataPromptData{UpdateRequest: "a chat app"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "Name the project for a chat app using our ACME vocabulary." {
+		t.Errorf("unexpected override output: %q", out)
+	}
+
+	if set.Origin(PromptMetadata) != filepath.Join(dir, "metadata.tmpl") {
+		t.Errorf("expected override origin, got %q", set.Origin(PromptMetadata))
+	}
+	if set.Hash(PromptMetadata) == defaultPromptSet.Hash(PromptMetadata) {
+		t.Error("expected override to change the template hash")
+	}
+	if set.Hash(PromptVersionBump) != defaultPromptSet.Hash(PromptVersionBump) {
+		t.Error("expected untouched templates to keep their hash")
+	}
+}
+
+func TestLoadPromptSet_InvalidOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		errMsg  string
+	}{
+		{"unknown prompt", "summary.tmpl", "hi", "unknown prompt"},
+		{"parse error", "delta.tmpl", "{{if .UpdateRequest}", "parse prompt"},
+		{"unknown field", "categorization.tmpl", "{{.ProjectOwner}}", "data contract"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("write override: %v", err)
+			}
+
+			_, err := LoadPromptSet(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestClient_PromptDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "version_bump.tmpl"), []byte("Bump {{.CurrentVersion}}"), 0644); err != nil {
+		t.Fatalf("write override: %v", err)
+	}
+
+	client, err := NewClient(&Config{APIKey: "k", BaseURL: "u", DefaultModel: "m", PromptDir: dir})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := client.Prompts().Render(PromptVersionBump, VersionBumpPromptData{CurrentVersion: "1.2.3"})
+	if err != nil || out != "Bump 1.2.3" {
+		t.Errorf("expected override to render, got %q (%v)", out, err)
+	}
+}
+
+func TestRender_DeltaResolutions(t *testing.T) {
+	set, err := LoadPromptSet("")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data := RequirementsDeltaPromptData{UpdateRequest: "Update the login requirement"}
+	without, err := set.Render(PromptRequirementsDelta, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(without, "RESOLVED AMBIGUITIES") {
+		t.Error("expected no resolutions section when there are none")
+	}
+
+	data.Resolutions = []ResolvedAmbiguity{
+		{Question: "Which login requirement?", TargetIDs: []string{"REQ-AUTH-abc123", "REQ-AUTH-def456"}},
+		{Question: "Keep the old flow?", Answer: "no, replace it"},
+	}
+	with, err := set.Render(PromptRequirementsDelta, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"RESOLVED AMBIGUITIES",
+		"- Which login requirement? -> REQ-AUTH-abc123, REQ-AUTH-def456",
+		"- Keep the old flow? -> no, replace it",
+	} {
+		if !strings.Contains(with, want) {
+			t.Errorf("expected prompt to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"xdd/internal/telemetry"
+	"xdd/internal/telemetry/telemetrytest"
+)
+
+// spanAttr returns the named attribute of a recorded span.
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func spansNamed(spans tracetest.SpanStubs, name string) []tracetest.SpanStub {
+	var named []tracetest.SpanStub
+	for _, s := range spans {
+		if s.Name == name {
+			named = append(named, s)
+		}
+	}
+	return named
+}
+
+func TestGenerateStructured_Tracing(t *testing.T) {
+	exporter, restore := telemetrytest.UseInMemoryExporter()
+	defer restore()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		age := -5 // Invalid on the first attempt
+		if attempts > 1 {
+			age = 30
+		}
+		fmt.Fprintf(w, `{"choices":[{"message":{"content":"{\"name\":\"Bob\",\"age\":%d}"}}],`+
+			`"usage":{"prompt_tokens":120,"completion_tokens":15,"total_tokens":135}}`, age)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		BaseURL:      server.URL,
+		DefaultModel: "test-model",
+		Timeout:      5 * time.Second,
+		MaxRetries:   3,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = GenerateStructured[TestOutput](client, WithTask(context.Background(), "metadata"), "", "Generate a person",
+		func(o *TestOutput) error {
+			if o.Age <= 0 {
+				return errors.New("age must be positive")
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("GenerateStructured: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+
+	generate := spansNamed(spans, "llm.GenerateStructured")
+	if len(generate) != 1 {
+		t.Fatalf("expected 1 GenerateStructured span, got %d", len(generate))
+	}
+	if v, _ := spanAttr(generate[0], telemetry.AttrTask); v.AsString() != "metadata" {
+		t.Errorf("expected task metadata, got %q", v.AsString())
+	}
+
+	attemptSpans := spansNamed(spans, "llm.attempt")
+	if len(attemptSpans) != 2 {
+		t.Fatalf("expected 2 attempt spans, got %d", len(attemptSpans))
+	}
+	first, second := attemptSpans[0], attemptSpans[1]
+	if first.Status.Code != codes.Error {
+		t.Errorf("expected failed first attempt, got status %v", first.Status.Code)
+	}
+	if _, ok := spanAttr(first, telemetry.AttrRetryReason); ok {
+		t.Error("first attempt should have no retry reason")
+	}
+	if v, _ := spanAttr(second, telemetry.AttrAttempt); v.AsInt64() != 2 {
+		t.Errorf("expected attempt 2, got %d", v.AsInt64())
+	}
+	if v, _ := spanAttr(second, telemetry.AttrRetryReason); v.AsString() != "validation: age must be positive" {
+		t.Errorf("unexpected retry reason %q", v.AsString())
+	}
+	for _, s := range attemptSpans {
+		if s.Parent.SpanID() != generate[0].SpanContext.SpanID() {
+			t.Errorf("attempt span not a child of GenerateStructured")
+		}
+	}
+
+	calls := spansNamed(spans, "openrouter.chat_completions")
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 HTTP spans, got %d", len(calls))
+	}
+	call := calls[1]
+	if call.Parent.SpanID() != second.SpanContext.SpanID() {
+		t.Error("HTTP span not a child of its attempt")
+	}
+	if v, _ := spanAttr(call, telemetry.AttrModel); v.AsString() != "test-model" {
+		t.Errorf("expected model test-model, got %q", v.AsString())
+	}
+	if v, _ := spanAttr(call, telemetry.AttrInputTokens); v.AsInt64() != 120 {
+		t.Errorf("expected 120 input tokens, got %d", v.AsInt64())
+	}
+	if v, _ := spanAttr(call, telemetry.AttrOutputTokens); v.AsInt64() != 15 {
+		t.Errorf("expected 15 output tokens, got %d", v.AsInt64())
+	}
+	if v, _ := spanAttr(call, telemetry.AttrHTTPStatusCode); v.AsInt64() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", v.AsInt64())
+	}
+}
+
+func TestGenerateStructured_TracesProviderError(t *testing.T) {
+	exporter, restore := telemetrytest.UseInMemoryExporter()
+	defer restore()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		BaseURL:      server.URL,
+		DefaultModel: "test-model",
+		Timeout:      5 * time.Second,
+		MaxRetries:   3,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := GenerateStructured[TestOutput](client, context.Background(), "", "Generate a person", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	calls := spansNamed(exporter.GetSpans(), "openrouter.chat_completions")
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 HTTP span, got %d", len(calls))
+	}
+	if calls[0].Status.Code != codes.Error {
+		t.Errorf("expected error status, got %v", calls[0].Status.Code)
+	}
+	if v, _ := spanAttr(calls[0], telemetry.AttrHTTPStatusCode); v.AsInt64() != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", v.AsInt64())
+	}
+}
@@ -0,0 +1,239 @@
+package llm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Fixture modes for FixtureTransport.
+const (
+	FixtureModeLive   = "live"   // Pass requests through untouched
+	FixtureModeRecord = "record" // Pass requests through and save each exchange as a fixture
+	FixtureModeReplay = "replay" // Serve responses from fixtures, never touching the network
+)
+
+// FixtureModeEnv is the environment variable that selects the fixture mode.
+const FixtureModeEnv = "LLM_FIXTURE_MODE"
+
+// redactedValue replaces secrets in recorded fixtures.
+const redactedValue = "[REDACTED]"
+
+// ErrFixtureNotRecorded is returned in replay mode when no fixture matches a request.
+var ErrFixtureNotRecorded = errors.New("fixture replay miss")
+
+// apiKeyPattern matches OpenRouter/OpenAI-style secret keys.
+var apiKeyPattern = regexp.MustCompile(`sk-[A-Za-z0-9_-]{10,}`)
+
+// FixtureTransport is an http.RoundTripper that records and replays LLM
+// exchanges, so tests can run offline against real recorded model output.
+// Requests are matched by their normalized JSON body.
+type FixtureTransport struct {
+	mode    string
+	dir     string
+	base    http.RoundTripper
+	secrets []string
+}
+
+// NewFixtureTransport creates a transport in the given mode, storing fixtures in dir.
+// base is used for live and record modes (nil means http.DefaultTransport).
+// secrets are additional literal values redacted from recorded fixtures.
+func NewFixtureTransport(mode, dir string, base http.RoundTripper, secrets ...string) (*FixtureTransport, error) {
+	switch mode {
+	case FixtureModeLive, FixtureModeRecord, FixtureModeReplay:
+		// Valid
+	default:
+		return nil, fmt.Errorf("invalid fixture mode %q, must be %s|%s|%s",
+			mode, FixtureModeLive, FixtureModeRecord, FixtureModeReplay)
+	}
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &FixtureTransport{
+		mode:    mode,
+		dir:     dir,
+		base:    base,
+		secrets: secrets,
+	}, nil
+}
+
+// FixtureModeFromEnv returns the mode selected by LLM_FIXTURE_MODE (default: live).
+func FixtureModeFromEnv() (string, error) {
+	mode := os.Getenv(FixtureModeEnv)
+	if mode == "" {
+		return FixtureModeLive, nil
+	}
+
+	switch mode {
+	case FixtureModeLive, FixtureModeRecord, FixtureModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%s=%q is invalid, must be %s|%s|%s",
+			FixtureModeEnv, mode, FixtureModeLive, FixtureModeRecord, FixtureModeReplay)
+	}
+}
+
+// Mode returns the transport's fixture mode.
+func (t *FixtureTransport) Mode() string {
+	return t.mode
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == FixtureModeLive {
+		return t.base.RoundTrip(req)
+	}
+
+	// Read the body so it can be hashed and, when recording, replayed upstream
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		if err := req.Body.Close(); err != nil {
+			return nil, fmt.Errorf("close request body: %w", err)
+		}
+	}
+
+	normalized, err := normalizeJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("normalize request body: %w", err)
+	}
+	name := fixtureName(normalized)
+
+	if t.mode == FixtureModeReplay {
+		return t.replay(req, name, normalized)
+	}
+	return t.record(req, name, body, normalized)
+}
+
+// replay serves a recorded response, failing loudly when none exists.
+func (t *FixtureTransport) replay(req *http.Request, name string, normalized []byte) (*http.Response, error) {
+	fixture, err := loadFixtureFrom(t.dir, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s %s (fixture %s, model %s): %v\n\nRequest body:\n%s",
+			ErrFixtureNotRecorded, req.Method, req.URL.Path, name, requestModel(normalized), err, normalized)
+	}
+
+	output := []byte(fixture.Output)
+
+	// Non-JSON response bodies are stored as JSON strings
+	var text string
+	if json.Unmarshal(output, &text) == nil {
+		output = []byte(text)
+	}
+
+	status := fixture.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(output)),
+		ContentLength: int64(len(output)),
+		Request:       req,
+	}, nil
+}
+
+// record forwards the request upstream and saves the exchange as a fixture.
+func (t *FixtureTransport) record(req *http.Request, name string, body, normalized []byte) (*http.Response, error) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	output := t.redact(respBody)
+	if !json.Valid(output) {
+		output, err = json.Marshal(string(output))
+		if err != nil {
+			return nil, fmt.Errorf("encode response body: %w", err)
+		}
+	}
+
+	fixture := &Fixture{
+		Name:       name,
+		Input:      t.redact(normalized),
+		Output:     output,
+		Model:      requestModel(normalized),
+		Timestamp:  time.Now().UTC(),
+		StatusCode: resp.StatusCode,
+	}
+	if err := saveFixtureTo(t.dir, name, fixture); err != nil {
+		return nil, fmt.Errorf("record fixture: %w", err)
+	}
+
+	return resp, nil
+}
+
+// redact removes API keys and configured secrets from recorded data.
+func (t *FixtureTransport) redact(data []byte) []byte {
+	out := apiKeyPattern.ReplaceAll(data, []byte(redactedValue))
+	for _, secret := range t.secrets {
+		if secret != "" {
+			out = bytes.ReplaceAll(out, []byte(secret), []byte(redactedValue))
+		}
+	}
+	return out
+}
+
+// normalizeJSON re-encodes a JSON body with sorted keys and no insignificant
+// whitespace, so semantically identical requests produce identical bytes.
+func normalizeJSON(body []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return []byte("null"), nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// fixtureName derives the fixture file name from a normalized request body.
+func fixtureName(normalized []byte) string {
+	sum := sha256.Sum256(normalized)
+	return "http-" + hex.EncodeToString(sum[:8])
+}
+
+// requestModel extracts the model field from a normalized request body.
+func requestModel(normalized []byte) string {
+	var req struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(normalized, &req); err != nil || strings.TrimSpace(req.Model) == "" {
+		return "unknown"
+	}
+	return req.Model
+}
@@ -0,0 +1,196 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFixtureTransport_InvalidMode(t *testing.T) {
+	if _, err := NewFixtureTransport("bogus", t.TempDir(), nil); err == nil {
+		t.Fatal("expected error for invalid mode")
+	}
+}
+
+func TestFixtureModeFromEnv(t *testing.T) {
+	t.Setenv(FixtureModeEnv, "")
+	mode, err := FixtureModeFromEnv()
+	if err != nil || mode != FixtureModeLive {
+		t.Errorf("expected default live mode, got %q (%v)", mode, err)
+	}
+
+	t.Setenv(FixtureModeEnv, FixtureModeReplay)
+	mode, err = FixtureModeFromEnv()
+	if err != nil || mode != FixtureModeReplay {
+		t.Errorf("expected replay mode, got %q (%v)", mode, err)
+	}
+
+	t.Setenv(FixtureModeEnv, "offline")
+	if _, err := FixtureModeFromEnv(); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	a, err := normalizeJSON([]byte(`{"model": "m", "messages": [{"role":"user","content":"hi"}]}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	b, err := normalizeJSON([]byte("{\n  \"messages\": [{\"content\": \"hi\", \"role\": \"user\"}],\n  \"model\": \"m\"\n}"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if string(a) != string(b) {
+		t.Errorf("expected equivalent bodies to normalize identically:\n%s\n%s", a, b)
+	}
+	if fixtureName(a) != fixtureName(b) {
+		t.Error("expected equivalent bodies to share a fixture name")
+	}
+}
+
+func TestFixtureTransport_RecordThenReplay(t *testing.T) {
+	const secret = "sk-or-v1-supersecretkey1234567890"
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.Header.Get("Authorization"); got != "Bearer "+secret {
+			t.Errorf("expected auth header to reach upstream, got %q", got)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"{\"name\": \"Alice\", \"age\": 25}"}}]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	// Record against the live server
+	recorder, err := NewFixtureTransport(FixtureModeRecord, dir, nil, secret)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	client, err := NewClient(&Config{
+		APIKey:       secret,
+		BaseURL:      server.URL,
+		DefaultModel: "test-model",
+		Transport:    recorder,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	prompt := "Generate a person (key " + secret + ")"
+	recorded, err := GenerateStructured[TestOutput](client, context.Background(), "", prompt, nil)
+	if err != nil {
+		t.Fatalf("record: expected no error, got %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected exactly one fixture, got %d (%v)", len(entries), err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if strings.Contains(string(data), secret) {
+		t.Error("expected secret to be redacted from fixture")
+	}
+	if !strings.Contains(string(data), redactedValue) {
+		t.Error("expected redaction marker in fixture")
+	}
+
+	// Replay with the server gone
+	server.Close()
+	replayer, err := NewFixtureTransport(FixtureModeReplay, dir, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	client, err = NewClient(&Config{
+		APIKey:       secret,
+		BaseURL:      server.URL,
+		DefaultModel: "test-model",
+		Transport:    replayer,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	replayed, err := GenerateStructured[TestOutput](client, context.Background(), "", prompt, nil)
+	if err != nil {
+		t.Fatalf("replay: expected no error, got %v", err)
+	}
+	if *replayed != *recorded {
+		t.Errorf("expected replayed output %+v to match recorded %+v", replayed, recorded)
+	}
+	if calls != 1 {
+		t.Errorf("expected replay not to reach upstream, got %d calls", calls)
+	}
+}
+
+func TestFixtureTransport_ReplayMiss(t *testing.T) {
+	replayer, err := NewFixtureTransport(FixtureModeReplay, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		BaseURL:      "http://127.0.0.1:1",
+		DefaultModel: "test-model",
+		Transport:    replayer,
+		Models:       []ModelConfig{{Name: "primary-model"}, {Name: "fallback-model"}},
+		Routes:       map[string]ModelRoute{"replay": {Model: "primary-model", Fallbacks: []string{"fallback-model"}}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = GenerateStructured[TestOutput](client, WithTask(context.Background(), "replay"), "", "unrecorded prompt", nil)
+	if err == nil {
+		t.Fatal("expected replay miss to fail")
+	}
+	if !errors.Is(err, ErrFixtureNotRecorded) {
+		t.Errorf("expected replay miss error, got %v", err)
+	}
+	// A miss is neither a network error nor a reason to try the fallback
+	var llmErr *LLMError
+	if errors.As(err, &llmErr) {
+		t.Errorf("expected replay miss not to be wrapped as %s error", llmErr.Type)
+	}
+	if !strings.Contains(err.Error(), "model primary-model") {
+		t.Errorf("expected the miss for the primary model, got %v", err)
+	}
+}
+
+func TestFixtureTransport_ReplayRecordedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("rate limited"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, _ := NewFixtureTransport(FixtureModeRecord, dir, nil)
+	client, _ := NewClient(&Config{APIKey: "k", BaseURL: server.URL, DefaultModel: "m", Transport: recorder})
+	if _, err := GenerateStructured[TestOutput](client, context.Background(), "", "p", nil); err == nil {
+		t.Fatal("expected API error while recording")
+	}
+
+	replayer, _ := NewFixtureTransport(FixtureModeReplay, dir, nil)
+	client, _ = NewClient(&Config{APIKey: "k", BaseURL: server.URL, DefaultModel: "m", Transport: replayer})
+	_, err := GenerateStructured[TestOutput](client, context.Background(), "", "p", nil)
+
+	llmErr, ok := err.(*LLMError)
+	if !ok {
+		t.Fatalf("expected *LLMError, got %T (%v)", err, err)
+	}
+	if llmErr.Code != http.StatusTooManyRequests || !strings.Contains(llmErr.Message, "rate limited") {
+		t.Errorf("expected replayed 429 with body, got %v", llmErr)
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"xdd/pkg/schema"
@@ -15,16 +16,17 @@ func ReplayEvents(spec *schema.Specification, events []schema.ChangelogEvent) (*
 		return nil, fmt.Errorf("spec cannot be nil")
 	}
 
-	// Sort events by timestamp to ensure deterministic replay
+	// Sort events by timestamp to ensure deterministic replay; ties keep
+	// changelog order
 	sortedEvents := make([]schema.ChangelogEvent, len(events))
 	copy(sortedEvents, events)
-	sort.Slice(sortedEvents, func(i, j int) bool {
+	sort.SliceStable(sortedEvents, func(i, j int) bool {
 		return sortedEvents[i].Timestamp().Before(sortedEvents[j].Timestamp())
 	})
 
 	// Apply each event in order
 	for _, event := range sortedEvents {
-		if err := applyEvent(spec, event); err != nil {
+		if err := ApplyEvent(spec, event); err != nil {
 			return nil, fmt.Errorf("apply event %s: %w", event.EventID(), err)
 		}
 	}
@@ -32,8 +34,9 @@ func ReplayEvents(spec *schema.Specification, events []schema.ChangelogEvent) (*
 	return spec, nil
 }
 
-// applyEvent applies a single event to the specification.
-func applyEvent(spec *schema.Specification, event schema.ChangelogEvent) error {
+// ApplyEvent applies a single event to the specification with the same
+// checks as replay, so callers committing new events cannot diverge from it.
+func ApplyEvent(spec *schema.Specification, event schema.ChangelogEvent) error {
 	switch e := event.(type) {
 	case *schema.RequirementAdded:
 		return applyRequirementAdded(spec, e)
@@ -49,10 +52,28 @@ func applyEvent(spec *schema.Specification, event schema.ChangelogEvent) error {
 		return applyCategoryDeleted(spec, e)
 	case *schema.CategoryRenamed:
 		return applyCategoryRenamed(spec, e)
+	case *schema.CategoryMerged:
+		return spec.MergeCategory(e.Source, e.Target, e.RequirementIDs)
+	case *schema.RequirementLinkAdded:
+		return spec.AddLink(e.RequirementID, e.Link)
+	case *schema.RequirementLinkRemoved:
+		return spec.RemoveLink(e.RequirementID, e.Link)
+	case *schema.RequirementStatusChanged:
+		return applyRequirementStatusChanged(spec, e)
+	case *schema.RequirementTagsChanged:
+		return spec.ChangeTags(e.RequirementID, e.Added, e.Removed)
+	case *schema.RequirementFieldSet:
+		_, err := spec.SetField(e.RequirementID, e.Name, e.Value)
+		return err
+	case *schema.AcceptanceCriterionVerificationSet:
+		_, err := spec.SetVerification(e.RequirementID, e.CriterionID, e.Verification)
+		return err
 	case *schema.ProjectMetadataUpdated:
 		return applyProjectMetadataUpdated(spec, e)
 	case *schema.VersionBumped:
 		return applyVersionBumped(spec, e)
+	case *schema.ConflictOverridden:
+		return nil // Audit record only
 	default:
 		return fmt.Errorf("unknown event type: %T", event)
 	}
@@ -66,11 +87,23 @@ func applyRequirementAdded(spec *schema.Specification, event *schema.Requirement
 		}
 	}
 
-	spec.Requirements = append(spec.Requirements, event.Requirement)
+	// Aliases follow replay order, so every clone derives the same ones
+	req := event.Requirement
+	req.Links = nil
+	if req.Status == "" {
+		req.Status = schema.StatusDraft
+	}
+	spec.AssignAlias(&req)
+	spec.Requirements = append(spec.Requirements, req)
+
+	// Add category (and any parent categories) if not exists
+	spec.EnsureCategory(event.Requirement.Category, event.Timestamp_)
 
-	// Add category if not exists
-	if !containsString(spec.Categories, event.Requirement.Category) {
-		spec.Categories = append(spec.Categories, event.Requirement.Category)
+	// Links the requirement was added with get the same checks as link events
+	for _, link := range event.Requirement.Links {
+		if err := spec.AddLink(req.ID, link); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -92,16 +125,27 @@ func applyRequirementDeleted(spec *schema.Specification, event *schema.Requireme
 		return fmt.Errorf("requirement %s not found", event.RequirementID)
 	}
 
+	// Links to the requirement must be removed first so none dangle
 	spec.Requirements = newReqs
-
-	// Remove category if no more requirements use it
-	if !categoryInUse(spec.Requirements, event.Requirement.Category) {
-		spec.Categories = removeString(spec.Categories, event.Requirement.Category)
+	if inbound := spec.LinksTo(event.RequirementID); len(inbound) > 0 {
+		return fmt.Errorf("requirement %s is still linked from %s (%s)", event.RequirementID, inbound[0].Source, inbound[0].Type)
 	}
 
+	// Remove category (and parents left empty) if no more requirements use it
+	spec.PruneCategory(event.Requirement.Category)
+
 	return nil
 }
 
+func applyRequirementStatusChanged(spec *schema.Specification, event *schema.RequirementStatusChanged) error {
+	// The recorded starting status must match, so replay agrees with the lifecycle the actor saw
+	if req := spec.FindRequirement(event.RequirementID); req != nil && event.From != "" && req.CurrentStatus() != event.From {
+		return fmt.Errorf("requirement %s is %s, not %s", event.RequirementID, req.CurrentStatus(), event.From)
+	}
+	_, err := spec.ChangeStatus(event.RequirementID, event.To)
+	return err
+}
+
 func applyAcceptanceCriterionAdded(spec *schema.Specification, event *schema.AcceptanceCriterionAdded) error {
 	// Find the requirement
 	for i := range spec.Requirements {
@@ -152,45 +196,24 @@ func applyAcceptanceCriterionDeleted(spec *schema.Specification, event *schema.A
 }
 
 func applyCategoryAdded(spec *schema.Specification, event *schema.CategoryAdded) error {
-	if containsString(spec.Categories, event.Name) {
-		return fmt.Errorf("category %s already exists", event.Name)
-	}
-
-	spec.Categories = append(spec.Categories, event.Name)
-	return nil
+	return spec.AddCategory(event.Category())
 }
 
 func applyCategoryDeleted(spec *schema.Specification, event *schema.CategoryDeleted) error {
-	if !containsString(spec.Categories, event.Name) {
-		return fmt.Errorf("category %s not found", event.Name)
+	if spec.CategoryInUse(event.Name) {
+		return fmt.Errorf("category %s is still in use", event.Name)
+	}
+	if children := spec.Subcategories(event.Name); len(children) > 0 {
+		return fmt.Errorf("category %s still has subcategories: %s", event.Name, strings.Join(children, ", "))
 	}
 
-	spec.Categories = removeString(spec.Categories, event.Name)
+	// Deleting its last requirement already dropped the category
+	spec.RemoveCategory(event.Name)
 	return nil
 }
 
 func applyCategoryRenamed(spec *schema.Specification, event *schema.CategoryRenamed) error {
-	found := false
-	for i, cat := range spec.Categories {
-		if cat == event.OldName {
-			spec.Categories[i] = event.NewName
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		return fmt.Errorf("category %s not found", event.OldName)
-	}
-
-	// Update all requirements using this category
-	for i := range spec.Requirements {
-		if spec.Requirements[i].Category == event.OldName {
-			spec.Requirements[i].Category = event.NewName
-		}
-	}
-
-	return nil
+	return spec.RenameCategory(event.OldName, event.NewName, event.RequirementIDs)
 }
 
 func applyProjectMetadataUpdated(spec *schema.Specification, event *schema.ProjectMetadataUpdated) error {
@@ -203,36 +226,6 @@ func applyVersionBumped(spec *schema.Specification, event *schema.VersionBumped)
 	return nil
 }
 
-// Helper functions
-
-func containsString(slice []string, str string) bool {
-	for _, s := range slice {
-		if s == str {
-			return true
-		}
-	}
-	return false
-}
-
-func removeString(slice []string, str string) []string {
-	result := make([]string, 0, len(slice))
-	for _, s := range slice {
-		if s != str {
-			result = append(result, s)
-		}
-	}
-	return result
-}
-
-func categoryInUse(requirements []schema.Requirement, category string) bool {
-	for _, req := range requirements {
-		if req.Category == category {
-			return true
-		}
-	}
-	return false
-}
-
 // ReplayEventsFromMaps converts raw map events (from YAML) to typed events and replays them.
 func ReplayEventsFromMaps(spec *schema.Specification, eventMaps []map[string]interface{}) (*schema.Specification, error) {
 	events := make([]schema.ChangelogEvent, 0, len(eventMaps))
@@ -313,10 +306,14 @@ func mapToEvent(eventMap map[string]interface{}) (schema.ChangelogEvent, error)
 
 	case "CategoryAdded":
 		name, _ := eventMap["name"].(string)
+		description, _ := eventMap["description"].(string)
+		owner, _ := eventMap["owner"].(string)
 		return &schema.CategoryAdded{
-			EventID_:   eventID,
-			Name:       name,
-			Timestamp_: timestamp,
+			EventID_:    eventID,
+			Name:        name,
+			Description: description,
+			Owner:       owner,
+			Timestamp_:  timestamp,
 		}, nil
 
 	case "CategoryDeleted":
@@ -330,11 +327,112 @@ func mapToEvent(eventMap map[string]interface{}) (schema.ChangelogEvent, error)
 	case "CategoryRenamed":
 		oldName, _ := eventMap["old_name"].(string)
 		newName, _ := eventMap["new_name"].(string)
+		ids, err := mapToStringMap(eventMap["requirement_ids"])
+		if err != nil {
+			return nil, fmt.Errorf("parse requirement_ids: %w", err)
+		}
 		return &schema.CategoryRenamed{
-			EventID_:   eventID,
-			OldName:    oldName,
-			NewName:    newName,
-			Timestamp_: timestamp,
+			EventID_:       eventID,
+			OldName:        oldName,
+			NewName:        newName,
+			RequirementIDs: ids,
+			Timestamp_:     timestamp,
+		}, nil
+
+	case "CategoryMerged":
+		source, _ := eventMap["source"].(string)
+		target, _ := eventMap["target"].(string)
+		ids, err := mapToStringMap(eventMap["requirement_ids"])
+		if err != nil {
+			return nil, fmt.Errorf("parse requirement_ids: %w", err)
+		}
+		return &schema.CategoryMerged{
+			EventID_:       eventID,
+			Source:         source,
+			Target:         target,
+			RequirementIDs: ids,
+			Timestamp_:     timestamp,
+		}, nil
+
+	case "RequirementLinkAdded", "RequirementLinkRemoved":
+		reqID, _ := eventMap["requirement_id"].(string)
+		link, err := mapToLink(eventMap["link"])
+		if err != nil {
+			return nil, fmt.Errorf("parse link: %w", err)
+		}
+		if eventType == "RequirementLinkAdded" {
+			return &schema.RequirementLinkAdded{
+				EventID_:      eventID,
+				RequirementID: reqID,
+				Link:          link,
+				Timestamp_:    timestamp,
+			}, nil
+		}
+		return &schema.RequirementLinkRemoved{
+			EventID_:      eventID,
+			RequirementID: reqID,
+			Link:          link,
+			Timestamp_:    timestamp,
+		}, nil
+
+	case "RequirementStatusChanged":
+		reqID, _ := eventMap["requirement_id"].(string)
+		from, _ := eventMap["from"].(string)
+		to, _ := eventMap["to"].(string)
+		actor, _ := eventMap["actor"].(string)
+		comment, _ := eventMap["comment"].(string)
+		return &schema.RequirementStatusChanged{
+			EventID_:      eventID,
+			RequirementID: reqID,
+			From:          schema.Status(from),
+			To:            schema.Status(to),
+			Actor:         actor,
+			Comment:       comment,
+			Timestamp_:    timestamp,
+		}, nil
+
+	case "RequirementTagsChanged":
+		reqID, _ := eventMap["requirement_id"].(string)
+		added, err := mapToStringSlice(eventMap["added"])
+		if err != nil {
+			return nil, fmt.Errorf("parse added: %w", err)
+		}
+		removed, err := mapToStringSlice(eventMap["removed"])
+		if err != nil {
+			return nil, fmt.Errorf("parse removed: %w", err)
+		}
+		return &schema.RequirementTagsChanged{
+			EventID_:      eventID,
+			RequirementID: reqID,
+			Added:         added,
+			Removed:       removed,
+			Timestamp_:    timestamp,
+		}, nil
+
+	case "RequirementFieldSet":
+		reqID, _ := eventMap["requirement_id"].(string)
+		name, _ := eventMap["name"].(string)
+		value, _ := eventMap["value"].(string)
+		oldValue, _ := eventMap["old_value"].(string)
+		return &schema.RequirementFieldSet{
+			EventID_:      eventID,
+			RequirementID: reqID,
+			Name:          name,
+			Value:         value,
+			OldValue:      oldValue,
+			Timestamp_:    timestamp,
+		}, nil
+
+	case "AcceptanceCriterionVerificationSet":
+		reqID, _ := eventMap["requirement_id"].(string)
+		criterionID, _ := eventMap["criterion_id"].(string)
+		return &schema.AcceptanceCriterionVerificationSet{
+			EventID_:        eventID,
+			RequirementID:   reqID,
+			CriterionID:     criterionID,
+			Verification:    mapToVerification(eventMap["verification"]),
+			OldVerification: mapToVerification(eventMap["old_verification"]),
+			Timestamp_:      timestamp,
 		}, nil
 
 	case "ProjectMetadataUpdated":
@@ -367,6 +465,34 @@ func mapToEvent(eventMap map[string]interface{}) (schema.ChangelogEvent, error)
 			Timestamp_: timestamp,
 		}, nil
 
+	case "ConflictOverridden":
+		reason, _ := eventMap["reason"].(string)
+		conflicts := []schema.ConflictRecord{}
+		if list, ok := eventMap["conflicts"].([]interface{}); ok {
+			for _, item := range list {
+				c, ok := item.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("conflict is not a map")
+				}
+				a, _ := c["requirement_a"].(string)
+				b, _ := c["requirement_b"].(string)
+				severity, _ := c["severity"].(string)
+				explanation, _ := c["explanation"].(string)
+				conflicts = append(conflicts, schema.ConflictRecord{
+					RequirementA: a,
+					RequirementB: b,
+					Severity:     severity,
+					Explanation:  explanation,
+				})
+			}
+		}
+		return &schema.ConflictOverridden{
+			EventID_:   eventID,
+			Conflicts:  conflicts,
+			Reason:     reason,
+			Timestamp_: timestamp,
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("unknown event type: %s", eventType)
 	}
@@ -386,6 +512,7 @@ func mapToRequirement(data interface{}) (schema.Requirement, error) {
 	description, _ := reqMap["description"].(string)
 	rationale, _ := reqMap["rationale"].(string)
 	priority, _ := reqMap["priority"].(string)
+	status, _ := reqMap["status"].(string)
 	createdAt, _ := reqMap["created_at"].(time.Time)
 
 	// Parse acceptance criteria
@@ -400,6 +527,26 @@ func mapToRequirement(data interface{}) (schema.Requirement, error) {
 		}
 	}
 
+	tags, err := mapToStringSlice(reqMap["tags"])
+	if err != nil {
+		return schema.Requirement{}, fmt.Errorf("parse tags: %w", err)
+	}
+	fields, err := mapToStringMap(reqMap["fields"])
+	if err != nil {
+		return schema.Requirement{}, fmt.Errorf("parse fields: %w", err)
+	}
+
+	var links []schema.RequirementLink
+	if linkList, ok := reqMap["links"].([]interface{}); ok {
+		for _, linkData := range linkList {
+			link, err := mapToLink(linkData)
+			if err != nil {
+				return schema.Requirement{}, fmt.Errorf("parse link: %w", err)
+			}
+			links = append(links, link)
+		}
+	}
+
 	return schema.Requirement{
 		ID:                 id,
 		Type:               schema.EARSType(reqType),
@@ -408,10 +555,34 @@ func mapToRequirement(data interface{}) (schema.Requirement, error) {
 		Rationale:          rationale,
 		AcceptanceCriteria: criteria,
 		Priority:           schema.Priority(priority),
+		Status:             schema.Status(status),
+		Links:              links,
+		Tags:               tags,
+		Fields:             fields,
 		CreatedAt:          createdAt,
 	}, nil
 }
 
+func mapToLink(data interface{}) (schema.RequirementLink, error) {
+	linkMap, ok := data.(map[string]interface{})
+	if !ok {
+		return schema.RequirementLink{}, fmt.Errorf("link is not a map")
+	}
+
+	linkType, _ := linkMap["type"].(string)
+	target, _ := linkMap["target"].(string)
+	return schema.RequirementLink{Type: schema.LinkType(linkType), Target: target}, nil
+}
+
+// mapToVerification reads the verification_method and verification_ref
+// keys of m; a missing map is an empty verification.
+func mapToVerification(data interface{}) schema.Verification {
+	m, _ := data.(map[string]interface{})
+	method, _ := m["verification_method"].(string)
+	ref, _ := m["verification_ref"].(string)
+	return schema.Verification{Method: schema.VerificationMethod(method), Ref: ref}
+}
+
 func mapToAcceptanceCriterion(data interface{}) (schema.AcceptanceCriterion, error) {
 	acMap, ok := data.(map[string]interface{})
 	if !ok {
@@ -421,6 +592,7 @@ func mapToAcceptanceCriterion(data interface{}) (schema.AcceptanceCriterion, err
 	acType, _ := acMap["type"].(string)
 	id, _ := acMap["id"].(string)
 	createdAt, _ := acMap["created_at"].(time.Time)
+	verification := mapToVerification(acMap)
 
 	switch acType {
 	case "behavioral":
@@ -428,21 +600,69 @@ func mapToAcceptanceCriterion(data interface{}) (schema.AcceptanceCriterion, err
 		when, _ := acMap["when"].(string)
 		then, _ := acMap["then"].(string)
 		return &schema.BehavioralCriterion{
-			ID:        id,
-			Type:      acType,
-			Given:     given,
-			When:      when,
-			Then:      then,
-			CreatedAt: createdAt,
+			ID:           id,
+			Type:         acType,
+			Given:        given,
+			When:         when,
+			Then:         then,
+			Verification: verification,
+			CreatedAt:    createdAt,
 		}, nil
 
 	case "assertion":
 		statement, _ := acMap["statement"].(string)
 		return &schema.AssertionCriterion{
-			ID:        id,
-			Type:      acType,
-			Statement: statement,
-			CreatedAt: createdAt,
+			ID:           id,
+			Type:         acType,
+			Statement:    statement,
+			Verification: verification,
+			CreatedAt:    createdAt,
+		}, nil
+
+	case "metric":
+		measure, _ := acMap["measure"].(string)
+		comparator, _ := acMap["comparator"].(string)
+		unit, _ := acMap["unit"].(string)
+		conditions, _ := acMap["conditions"].(string)
+		// YAML decodes whole thresholds as ints
+		var threshold float64
+		switch v := acMap["threshold"].(type) {
+		case int:
+			threshold = float64(v)
+		case float64:
+			threshold = v
+		default:
+			return nil, fmt.Errorf("metric criterion %s: threshold is not a number", id)
+		}
+		return &schema.MetricCriterion{
+			ID:           id,
+			Type:         acType,
+			Measure:      measure,
+			Comparator:   comparator,
+			Threshold:    threshold,
+			Unit:         unit,
+			Conditions:   conditions,
+			Verification: verification,
+			CreatedAt:    createdAt,
+		}, nil
+
+	case "scenario_outline":
+		given, _ := acMap["given"].(string)
+		when, _ := acMap["when"].(string)
+		then, _ := acMap["then"].(string)
+		examples, err := mapToExamples(acMap["examples"])
+		if err != nil {
+			return nil, fmt.Errorf("scenario outline %s: examples: %w", id, err)
+		}
+		return &schema.ScenarioOutlineCriterion{
+			ID:           id,
+			Type:         acType,
+			Given:        given,
+			When:         when,
+			Then:         then,
+			Examples:     examples,
+			Verification: verification,
+			CreatedAt:    createdAt,
 		}, nil
 
 	default:
@@ -450,6 +670,37 @@ func mapToAcceptanceCriterion(data interface{}) (schema.AcceptanceCriterion, err
 	}
 }
 
+// mapToExamples converts a scenario outline's examples table. Cells
+// written as bare YAML numbers or booleans are kept as their text.
+func mapToExamples(data interface{}) (schema.ExamplesTable, error) {
+	exMap, ok := data.(map[string]interface{})
+	if !ok {
+		return schema.ExamplesTable{}, fmt.Errorf("not a map")
+	}
+	columns, err := mapToStringSlice(exMap["columns"])
+	if err != nil {
+		return schema.ExamplesTable{}, fmt.Errorf("columns: %w", err)
+	}
+	rawRows, ok := exMap["rows"].([]interface{})
+	if !ok && exMap["rows"] != nil {
+		return schema.ExamplesTable{}, fmt.Errorf("rows: not a list")
+	}
+
+	rows := make([][]string, 0, len(rawRows))
+	for i, raw := range rawRows {
+		cells, ok := raw.([]interface{})
+		if !ok {
+			return schema.ExamplesTable{}, fmt.Errorf("row %d is not a list", i+1)
+		}
+		row := make([]string, len(cells))
+		for j, cell := range cells {
+			row[j] = fmt.Sprint(cell)
+		}
+		rows = append(rows, row)
+	}
+	return schema.ExamplesTable{Columns: columns, Rows: rows}, nil
+}
+
 func mapToMetadata(data interface{}) (schema.ProjectMetadata, error) {
 	metaMap, ok := data.(map[string]interface{})
 	if !ok {
@@ -470,3 +721,47 @@ func mapToMetadata(data interface{}) (schema.ProjectMetadata, error) {
 		UpdatedAt:   updatedAt,
 	}, nil
 }
+
+// mapToStringMap converts an optional YAML mapping of strings, such as a
+// rename's requirement_ids. A missing value yields nil.
+func mapToStringMap(data interface{}) (map[string]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+	raw, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a map")
+	}
+
+	result := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value for %s is not a string", k)
+		}
+		result[k] = s
+	}
+	return result, nil
+}
+
+// mapToStringSlice converts an optional YAML sequence of strings, such as
+// a requirement's tags. A missing value yields nil.
+func mapToStringSlice(data interface{}) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+	raw, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a list")
+	}
+
+	result := make([]string, 0, len(raw))
+	for i, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("item %d is not a string", i)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
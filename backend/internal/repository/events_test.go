@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -18,7 +19,7 @@ func createBaseSpec() *schema.Specification {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 }
 
@@ -68,8 +69,8 @@ func TestApplyRequirementAdded(t *testing.T) {
 		t.Errorf("Expected 1 category, got %d", len(spec.Categories))
 	}
 
-	if spec.Categories[0] != "AUTH" {
-		t.Errorf("Expected category AUTH, got %s", spec.Categories[0])
+	if spec.Categories[0].Name != "AUTH" {
+		t.Errorf("Expected category AUTH, got %s", spec.Categories[0].Name)
 	}
 }
 
@@ -96,7 +97,7 @@ func TestApplyRequirementDeleted(t *testing.T) {
 	}
 
 	spec.Requirements = append(spec.Requirements, req)
-	spec.Categories = append(spec.Categories, "AUTH")
+	spec.Categories = append(spec.Categories, schema.Category{Name: "AUTH"})
 
 	// Now delete it
 	event := &schema.RequirementDeleted{
@@ -144,7 +145,7 @@ func TestApplyCategoryRenamed(t *testing.T) {
 	}
 
 	spec.Requirements = append(spec.Requirements, req)
-	spec.Categories = append(spec.Categories, "AUTH")
+	spec.Categories = append(spec.Categories, schema.Category{Name: "AUTH"})
 
 	// Rename category
 	event := &schema.CategoryRenamed{
@@ -159,8 +160,8 @@ func TestApplyCategoryRenamed(t *testing.T) {
 		t.Fatalf("Failed to apply CategoryRenamed: %v", err)
 	}
 
-	if spec.Categories[0] != "SECURITY" {
-		t.Errorf("Expected category SECURITY, got %s", spec.Categories[0])
+	if spec.Categories[0].Name != "SECURITY" {
+		t.Errorf("Expected category SECURITY, got %s", spec.Categories[0].Name)
 	}
 
 	if spec.Requirements[0].Category != "SECURITY" {
@@ -525,7 +526,7 @@ func TestReplayEventsErrorCases(t *testing.T) {
 				Requirements: []schema.Requirement{
 					{ID: "REQ-001", Category: "AUTH", AcceptanceCriteria: []schema.AcceptanceCriterion{}},
 				},
-				Categories: []string{"AUTH"},
+				Categories: []schema.Category{{Name: "AUTH"}},
 			},
 			event: &schema.RequirementAdded{
 				EventID_: "EVT-001",
@@ -542,7 +543,7 @@ func TestReplayEventsErrorCases(t *testing.T) {
 			name: "delete non-existent requirement",
 			spec: &schema.Specification{
 				Requirements: []schema.Requirement{},
-				Categories:   []string{},
+				Categories:   []schema.Category{},
 			},
 			event: &schema.RequirementDeleted{
 				EventID_:      "EVT-001",
@@ -560,7 +561,7 @@ func TestReplayEventsErrorCases(t *testing.T) {
 			if tt.spec == nil {
 				_, err = ReplayEvents(tt.spec, []schema.ChangelogEvent{tt.event})
 			} else {
-				err = applyEvent(tt.spec, tt.event)
+				err = ApplyEvent(tt.spec, tt.event)
 			}
 
 			if (err != nil) != tt.wantError {
@@ -569,3 +570,203 @@ func TestReplayEventsErrorCases(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyCategoryDeleted(t *testing.T) {
+	spec := createBaseSpec()
+	spec.Categories = []schema.Category{{Name: "AUTH"}, {Name: "API"}}
+	spec.Requirements = []schema.Requirement{{ID: "REQ-AUTH-abc123", Category: "AUTH"}}
+
+	err := applyCategoryDeleted(spec, &schema.CategoryDeleted{EventID_: "EVT-1", Name: "AUTH", Timestamp_: time.Now()})
+	if err == nil {
+		t.Error("Deleting a category still in use should fail")
+	}
+
+	err = applyCategoryDeleted(spec, &schema.CategoryDeleted{EventID_: "EVT-2", Name: "API", Timestamp_: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to apply CategoryDeleted: %v", err)
+	}
+	if len(spec.Categories) != 1 || spec.Categories[0].Name != "AUTH" {
+		t.Errorf("Expected only AUTH, got %v", spec.CategoryNames())
+	}
+
+	// Already removed when its last requirement was deleted
+	err = applyCategoryDeleted(spec, &schema.CategoryDeleted{EventID_: "EVT-3", Name: "API", Timestamp_: time.Now()})
+	if err != nil {
+		t.Errorf("Deleting an absent category should be a no-op, got %v", err)
+	}
+}
+
+func TestReplayNestedCategories(t *testing.T) {
+	now := time.Now()
+	requirement := func(id, category string) schema.Requirement {
+		return schema.Requirement{ID: id, Category: category, Description: "Nested requirement " + id}
+	}
+	events := []schema.ChangelogEvent{
+		&schema.CategoryAdded{EventID_: "EVT-1", Name: "AUTH/SSO", Description: "Single sign-on", Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: requirement("REQ-AUTH.SSO-aaa", "AUTH/SSO"), Timestamp_: now.Add(time.Millisecond)},
+		&schema.RequirementAdded{EventID_: "EVT-3", Requirement: requirement("REQ-API.REST-bbb", "API/REST"), Timestamp_: now.Add(2 * time.Millisecond)},
+	}
+
+	spec, err := ReplayEvents(createBaseSpec(), events)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	want := []string{"AUTH", "AUTH/SSO", "API", "API/REST"}
+	if got := spec.CategoryNames(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Categories = %v, want %v", got, want)
+	}
+
+	err = applyCategoryDeleted(spec, &schema.CategoryDeleted{EventID_: "EVT-4", Name: "API", Timestamp_: now})
+	if err == nil {
+		t.Error("Deleting a category with subcategories should fail")
+	}
+
+	// Deleting the last requirement prunes its empty parents too
+	err = applyRequirementDeleted(spec, &schema.RequirementDeleted{
+		EventID_:      "EVT-5",
+		RequirementID: "REQ-API.REST-bbb",
+		Requirement:   requirement("REQ-API.REST-bbb", "API/REST"),
+		Timestamp_:    now,
+	})
+	if err != nil {
+		t.Fatalf("Failed to apply RequirementDeleted: %v", err)
+	}
+	want = []string{"AUTH", "AUTH/SSO"}
+	if got := spec.CategoryNames(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Categories = %v, want %v", got, want)
+	}
+
+	err = applyCategoryAdded(spec, &schema.CategoryAdded{EventID_: "EVT-6", Name: "A/B/C/D/E", Timestamp_: now})
+	if err == nil {
+		t.Error("Adding a category nested too deep should fail")
+	}
+}
+
+func TestReplayAssignsAliases(t *testing.T) {
+	now := time.Now()
+	requirement := func(id, category string) schema.Requirement {
+		return schema.Requirement{ID: id, Category: category, Description: "Requirement " + id}
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: requirement("REQ-AUTH-aaa", "AUTH"), Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: requirement("REQ-AUTH-bbb", "AUTH"), Timestamp_: now.Add(time.Millisecond)},
+		&schema.RequirementAdded{EventID_: "EVT-3", Requirement: requirement("REQ-API-ccc", "API"), Timestamp_: now.Add(2 * time.Millisecond)},
+		&schema.RequirementDeleted{EventID_: "EVT-4", RequirementID: "REQ-AUTH-bbb", Requirement: requirement("REQ-AUTH-bbb", "AUTH"), Timestamp_: now.Add(3 * time.Millisecond)},
+		&schema.RequirementAdded{EventID_: "EVT-5", Requirement: requirement("REQ-AUTH-ddd", "AUTH"), Timestamp_: now.Add(4 * time.Millisecond)},
+	}
+
+	spec, err := ReplayEvents(createBaseSpec(), events)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	want := map[string]string{"REQ-AUTH-aaa": "AUTH-001", "REQ-API-ccc": "API-001", "REQ-AUTH-ddd": "AUTH-003"}
+	for _, req := range spec.Requirements {
+		if req.Alias != want[req.ID] {
+			t.Errorf("%s alias = %s, want %s (aliases of deleted requirements are not reused)", req.ID, req.Alias, want[req.ID])
+		}
+	}
+
+	// Events with equal timestamps keep changelog order
+	tied := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: requirement("REQ-AUTH-first", "AUTH"), Timestamp_: now},
+		&schema.RequirementAdded{EventID_: "EVT-2", Requirement: requirement("REQ-AUTH-second", "AUTH"), Timestamp_: now},
+	}
+	for i := 0; i < 10; i++ {
+		spec, err := ReplayEvents(createBaseSpec(), tied)
+		if err != nil {
+			t.Fatalf("Replay failed: %v", err)
+		}
+		if spec.Requirements[0].Alias != "AUTH-001" || spec.Requirements[1].Alias != "AUTH-002" {
+			t.Fatalf("Aliases not deterministic: %s, %s", spec.Requirements[0].Alias, spec.Requirements[1].Alias)
+		}
+	}
+}
+
+func TestReplayRequirementLinks(t *testing.T) {
+	now := time.Now()
+	at := func(n int) time.Time { return now.Add(time.Duration(n) * time.Millisecond) }
+	added := func(n int, id string) schema.ChangelogEvent {
+		return &schema.RequirementAdded{
+			EventID_:    "EVT-ADD-" + id,
+			Requirement: schema.Requirement{ID: id, Category: "AUTH", Description: "Requirement " + id},
+			Timestamp_:  at(n),
+		}
+	}
+	link := func(n int, source string, linkType schema.LinkType, target string) schema.ChangelogEvent {
+		return &schema.RequirementLinkAdded{
+			EventID_:      "EVT-LINK-" + source + "-" + target,
+			RequirementID: source,
+			Link:          schema.RequirementLink{Type: linkType, Target: target},
+			Timestamp_:    at(n),
+		}
+	}
+	base := []schema.ChangelogEvent{
+		added(0, "REQ-A"), added(1, "REQ-B"), added(2, "REQ-C"),
+		link(3, "REQ-A", schema.LinkDependsOn, "REQ-B"),
+		link(4, "REQ-B", schema.LinkDependsOn, "REQ-C"),
+	}
+
+	spec, err := ReplayEvents(createBaseSpec(), base)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if got := spec.LinksTo("REQ-B"); len(got) != 1 || got[0].Source != "REQ-A" {
+		t.Errorf("LinksTo(REQ-B) = %+v, want one link from REQ-A", got)
+	}
+
+	tests := []struct {
+		name  string
+		extra []schema.ChangelogEvent
+		err   string
+	}{
+		{
+			name:  "dependency cycle",
+			extra: []schema.ChangelogEvent{link(5, "REQ-C", schema.LinkDependsOn, "REQ-A")},
+			err:   "depends_on cycle: REQ-C -> REQ-A -> REQ-B -> REQ-C",
+		},
+		{
+			name:  "dangling target",
+			extra: []schema.ChangelogEvent{link(5, "REQ-A", schema.LinkRefines, "REQ-Z")},
+			err:   "link target REQ-Z not found",
+		},
+		{
+			name: "delete while linked",
+			extra: []schema.ChangelogEvent{&schema.RequirementDeleted{
+				EventID_: "EVT-DEL", RequirementID: "REQ-B", Requirement: schema.Requirement{ID: "REQ-B", Category: "AUTH"}, Timestamp_: at(5),
+			}},
+			err: "requirement REQ-B is still linked from REQ-A (depends_on)",
+		},
+		{
+			name:  "other link types may loop",
+			extra: []schema.ChangelogEvent{link(5, "REQ-C", schema.LinkRefines, "REQ-A")},
+		},
+		{
+			name: "delete after unlinking",
+			extra: []schema.ChangelogEvent{
+				&schema.RequirementLinkRemoved{
+					EventID_: "EVT-UNLINK", RequirementID: "REQ-A",
+					Link: schema.RequirementLink{Type: schema.LinkDependsOn, Target: "REQ-B"}, Timestamp_: at(5),
+				},
+				&schema.RequirementDeleted{
+					EventID_: "EVT-DEL", RequirementID: "REQ-B", Requirement: schema.Requirement{ID: "REQ-B", Category: "AUTH"}, Timestamp_: at(6),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := append(append([]schema.ChangelogEvent{}, base...), tt.extra...)
+			_, err := ReplayEvents(createBaseSpec(), events)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
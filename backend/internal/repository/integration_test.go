@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -49,7 +50,7 @@ func TestSnapshotAndReplayIntegration(t *testing.T) {
 				CreatedAt: now,
 			},
 		},
-		Categories: []string{"AUTH"},
+		Categories: []schema.Category{{Name: "AUTH"}},
 	}
 
 	// Write initial spec and create snapshot
@@ -61,7 +62,7 @@ func TestSnapshotAndReplayIntegration(t *testing.T) {
 		},
 	}
 
-	if err := repo.WriteSpecificationAndChangelog(initialSpec, events); err != nil {
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), initialSpec, events); err != nil {
 		t.Fatalf("Failed to write initial spec: %v", err)
 	}
 
@@ -100,7 +101,7 @@ func TestSnapshotAndReplayIntegration(t *testing.T) {
 		},
 	}
 
-	if err := repo.AppendChangelog(newEvents); err != nil {
+	if err := repo.AppendChangelog(context.Background(), newEvents); err != nil {
 		t.Fatalf("Failed to append new events: %v", err)
 	}
 
@@ -166,7 +167,7 @@ func TestSnapshotCreationAndReplay(t *testing.T) {
 			UpdatedAt:   now,
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	// Create 50 requirements to trigger snapshot
@@ -198,12 +199,12 @@ func TestSnapshotCreationAndReplay(t *testing.T) {
 		})
 	}
 
-	if !containsString(initialSpec.Categories, "TEST") {
-		initialSpec.Categories = append(initialSpec.Categories, "TEST")
+	if !initialSpec.HasCategory("TEST") {
+		initialSpec.Categories = append(initialSpec.Categories, schema.Category{Name: "TEST"})
 	}
 
 	// Write spec with 50 events
-	if err := repo.WriteSpecificationAndChangelog(initialSpec, events); err != nil {
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), initialSpec, events); err != nil {
 		t.Fatalf("Failed to write spec with 50 events: %v", err)
 	}
 
@@ -239,7 +240,7 @@ func TestSnapshotCreationAndReplay(t *testing.T) {
 	initialSpec.Metadata.UpdatedAt = now.Add(110 * time.Second)
 
 	// This should trigger snapshot creation (110 total events > 100 threshold)
-	if err := repo.WriteSpecificationAndChangelog(initialSpec, moreEvents); err != nil {
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), initialSpec, moreEvents); err != nil {
 		t.Fatalf("Failed to write spec with 110 events: %v", err)
 	}
 
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -167,11 +168,11 @@ func TestFullEventReplayValidation(t *testing.T) {
 				CreatedAt: now,
 			},
 		},
-		Categories: []string{"AUTH", "TASKS"},
+		Categories: []schema.Category{{Name: "AUTH"}, {Name: "TASKS"}},
 	}
 
 	// Write using WriteSpecificationAndChangelog
-	if err := repo.WriteSpecificationAndChangelog(expectedSpec, events); err != nil {
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), expectedSpec, events); err != nil {
 		t.Fatalf("Failed to write specification and changelog: %v", err)
 	}
 
@@ -210,7 +211,7 @@ func TestFullEventReplayValidation(t *testing.T) {
 	t.Logf("✅ Event replay validation successful!")
 	t.Logf("   - Metadata: %s v%s", replayedSpec.Metadata.Name, replayedSpec.Metadata.Version)
 	t.Logf("   - Requirements: %d", len(replayedSpec.Requirements))
-	t.Logf("   - Categories: %v", replayedSpec.Categories)
+	t.Logf("   - Categories: %v", replayedSpec.CategoryNames())
 	t.Logf("   - REQ-AUTH-001 acceptance criteria: %d", len(replayedSpec.Requirements[0].AcceptanceCriteria))
 }
 
@@ -236,7 +237,7 @@ func TestSnapshotPlusReplayEqualsFullReplay(t *testing.T) {
 			UpdatedAt:   now,
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	for i := 0; i < 50; i++ {
@@ -266,12 +267,12 @@ func TestSnapshotPlusReplayEqualsFullReplay(t *testing.T) {
 		})
 	}
 
-	if !containsString(initialSpec.Categories, "TEST") {
-		initialSpec.Categories = append(initialSpec.Categories, "TEST")
+	if !initialSpec.HasCategory("TEST") {
+		initialSpec.Categories = append(initialSpec.Categories, schema.Category{Name: "TEST"})
 	}
 
 	// Write and create snapshot (50 events)
-	if err := repo.WriteSpecificationAndChangelog(initialSpec, initialEvents); err != nil {
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), initialSpec, initialEvents); err != nil {
 		t.Fatalf("Failed to write initial spec: %v", err)
 	}
 
@@ -307,7 +308,7 @@ func TestSnapshotPlusReplayEqualsFullReplay(t *testing.T) {
 	initialSpec.Metadata.UpdatedAt = now.Add(110 * time.Second)
 
 	// Write additional events (triggers snapshot creation)
-	if err := repo.WriteSpecificationAndChangelog(initialSpec, moreEvents); err != nil {
+	if err := repo.WriteSpecificationAndChangelog(context.Background(), initialSpec, moreEvents); err != nil {
 		t.Fatalf("Failed to write additional events: %v", err)
 	}
 
@@ -326,7 +327,7 @@ func TestSnapshotPlusReplayEqualsFullReplay(t *testing.T) {
 	emptySpec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	allEvents := append(initialEvents, moreEvents...)
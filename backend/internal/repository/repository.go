@@ -1,14 +1,17 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 
+	"xdd/internal/telemetry"
 	"xdd/pkg/schema"
 
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,6 +19,8 @@ import (
 type Repository struct {
 	baseDir         string
 	snapshotManager *SnapshotManager
+	fields          []schema.FieldDefinition // Custom fields writes are checked against
+	checkFields     bool
 }
 
 // NewRepository creates a new repository.
@@ -26,6 +31,32 @@ func NewRepository(baseDir string) *Repository {
 	}
 }
 
+// SetFieldDefinitions makes every write check the custom fields of each
+// requirement against defs, the fields the project declares. Reads are
+// not checked, so a specification stays loadable after defs change.
+func (r *Repository) SetFieldDefinitions(defs []schema.FieldDefinition) {
+	r.fields = defs
+	r.checkFields = true
+}
+
+// validate checks spec before it is written.
+func (r *Repository) validate(spec *schema.Specification) error {
+	if !r.checkFields {
+		return nil
+	}
+	if err := schema.ValidateSpecificationFields(spec, r.fields); err != nil {
+		return fmt.Errorf("validate fields: %w", err)
+	}
+	return nil
+}
+
+// startSpan starts a span for a repository transaction as a child of any
+// span in ctx.
+func startSpan(ctx context.Context, name string, events int) trace.Span {
+	_, span := telemetry.Start(ctx, "repository."+name, telemetry.AttrEventCount.Int(events))
+	return span
+}
+
 // ReadSpecification reads the current specification from YAML
 // Uses snapshots for performance - loads most recent snapshot and replays events since.
 func (r *Repository) ReadSpecification() (*schema.Specification, error) {
@@ -61,7 +92,7 @@ func (r *Repository) ReadSpecification() (*schema.Specification, error) {
 					return &schema.Specification{
 						Metadata:     schema.ProjectMetadata{},
 						Requirements: []schema.Requirement{},
-						Categories:   []string{},
+						Categories:   []schema.Category{},
 					}, nil
 				}
 				return nil, fmt.Errorf("read specification: %w", specErr)
@@ -90,7 +121,7 @@ func (r *Repository) ReadSpecification() (*schema.Specification, error) {
 	emptySpec := &schema.Specification{
 		Metadata:     schema.ProjectMetadata{},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	// Replay all events from the beginning
@@ -103,7 +134,14 @@ func (r *Repository) ReadSpecification() (*schema.Specification, error) {
 }
 
 // WriteSpecification writes the specification to YAML using atomic transaction.
-func (r *Repository) WriteSpecification(spec *schema.Specification) error {
+func (r *Repository) WriteSpecification(ctx context.Context, spec *schema.Specification) (err error) {
+	span := startSpan(ctx, "WriteSpecification", 0)
+	defer func() { telemetry.End(span, err) }()
+
+	if err := r.validate(spec); err != nil {
+		return err
+	}
+
 	data, err := yaml.Marshal(spec)
 	if err != nil {
 		return fmt.Errorf("marshal specification: %w", err)
@@ -133,7 +171,10 @@ func (r *Repository) WriteSpecification(spec *schema.Specification) error {
 }
 
 // AppendChangelog appends events to the changelog using atomic transaction.
-func (r *Repository) AppendChangelog(events []schema.ChangelogEvent) error {
+func (r *Repository) AppendChangelog(ctx context.Context, events []schema.ChangelogEvent) (err error) {
+	span := startSpan(ctx, "AppendChangelog", len(events))
+	defer func() { telemetry.End(span, err) }()
+
 	// Start transaction
 	tx := NewCopyOnWriteTx(r.baseDir)
 	if err := tx.Begin(); err != nil {
@@ -167,42 +208,7 @@ func (r *Repository) AppendChangelog(events []schema.ChangelogEvent) error {
 
 	// Convert events to maps for YAML serialization
 	for _, event := range events {
-		eventMap := make(map[string]interface{})
-		eventMap["event_type"] = event.EventType()
-		eventMap["event_id"] = event.EventID()
-		eventMap["timestamp"] = event.Timestamp()
-
-		// Add type-specific fields
-		switch e := event.(type) {
-		case *schema.RequirementAdded:
-			eventMap["requirement"] = e.Requirement
-		case *schema.RequirementDeleted:
-			eventMap["requirement_id"] = e.RequirementID
-			eventMap["requirement"] = e.Requirement
-		case *schema.AcceptanceCriterionAdded:
-			eventMap["requirement_id"] = e.RequirementID
-			eventMap["criterion"] = e.Criterion
-		case *schema.AcceptanceCriterionDeleted:
-			eventMap["requirement_id"] = e.RequirementID
-			eventMap["criterion_id"] = e.CriterionID
-			eventMap["criterion"] = e.Criterion
-		case *schema.ProjectMetadataUpdated:
-			eventMap["old_metadata"] = e.OldMetadata
-			eventMap["new_metadata"] = e.NewMetadata
-		case *schema.VersionBumped:
-			eventMap["old_version"] = e.OldVersion
-			eventMap["new_version"] = e.NewVersion
-			eventMap["bump_type"] = e.BumpType
-			eventMap["reasoning"] = e.Reasoning
-		case *schema.CategoryAdded:
-			eventMap["name"] = e.Name
-		case *schema.CategoryDeleted:
-			eventMap["name"] = e.Name
-		case *schema.CategoryRenamed:
-			eventMap["old_name"] = e.OldName
-			eventMap["new_name"] = e.NewName
-		}
-
+		eventMap := eventToMap(event, nil)
 		changelog.Events = append(changelog.Events, eventMap)
 		changelog.EventsSinceSnapshot++
 	}
@@ -234,7 +240,25 @@ func (r *Repository) AppendChangelog(events []schema.ChangelogEvent) error {
 }
 
 // WriteSpecificationAndChangelog writes both specification and changelog atomically.
-func (r *Repository) WriteSpecificationAndChangelog(spec *schema.Specification, events []schema.ChangelogEvent) error {
+func (r *Repository) WriteSpecificationAndChangelog(ctx context.Context, spec *schema.Specification, events []schema.ChangelogEvent) error {
+	return r.WriteSpecificationAndChangelogWithEnvelope(ctx, spec, events, nil)
+}
+
+// WriteSpecificationAndChangelogWithEnvelope is WriteSpecificationAndChangelog
+// with provenance recorded on every event's envelope.
+func (r *Repository) WriteSpecificationAndChangelogWithEnvelope(
+	ctx context.Context,
+	spec *schema.Specification,
+	events []schema.ChangelogEvent,
+	envelope *EventEnvelope,
+) (err error) {
+	span := startSpan(ctx, "WriteSpecificationAndChangelog", len(events))
+	defer func() { telemetry.End(span, err) }()
+
+	if err := r.validate(spec); err != nil {
+		return err
+	}
+
 	// Start transaction
 	tx := NewCopyOnWriteTx(r.baseDir)
 	if err := tx.Begin(); err != nil {
@@ -285,42 +309,7 @@ func (r *Repository) WriteSpecificationAndChangelog(spec *schema.Specification,
 
 	// Append events
 	for _, event := range events {
-		eventMap := make(map[string]interface{})
-		eventMap["event_type"] = event.EventType()
-		eventMap["event_id"] = event.EventID()
-		eventMap["timestamp"] = event.Timestamp()
-
-		// Add type-specific fields
-		switch e := event.(type) {
-		case *schema.RequirementAdded:
-			eventMap["requirement"] = e.Requirement
-		case *schema.RequirementDeleted:
-			eventMap["requirement_id"] = e.RequirementID
-			eventMap["requirement"] = e.Requirement
-		case *schema.AcceptanceCriterionAdded:
-			eventMap["requirement_id"] = e.RequirementID
-			eventMap["criterion"] = e.Criterion
-		case *schema.AcceptanceCriterionDeleted:
-			eventMap["requirement_id"] = e.RequirementID
-			eventMap["criterion_id"] = e.CriterionID
-			eventMap["criterion"] = e.Criterion
-		case *schema.ProjectMetadataUpdated:
-			eventMap["old_metadata"] = e.OldMetadata
-			eventMap["new_metadata"] = e.NewMetadata
-		case *schema.VersionBumped:
-			eventMap["old_version"] = e.OldVersion
-			eventMap["new_version"] = e.NewVersion
-			eventMap["bump_type"] = e.BumpType
-			eventMap["reasoning"] = e.Reasoning
-		case *schema.CategoryAdded:
-			eventMap["name"] = e.Name
-		case *schema.CategoryDeleted:
-			eventMap["name"] = e.Name
-		case *schema.CategoryRenamed:
-			eventMap["old_name"] = e.OldName
-			eventMap["new_name"] = e.NewName
-		}
-
+		eventMap := eventToMap(event, envelope)
 		changelog.Events = append(changelog.Events, eventMap)
 		changelog.EventsSinceSnapshot++
 	}
@@ -407,3 +396,113 @@ func (r *Repository) WriteSpecificationAndChangelog(spec *schema.Specification,
 
 	return nil
 }
+
+// EventEnvelope is provenance written alongside each event of one commit.
+type EventEnvelope struct {
+	// PromptHashes maps prompt template names to the hash of the template that
+	// produced the change, so prompt edits can be traced in the changelog.
+	PromptHashes map[string]string
+}
+
+// eventToMap converts an event and its envelope to a map for YAML serialization.
+func eventToMap(event schema.ChangelogEvent, envelope *EventEnvelope) map[string]interface{} {
+	eventMap := make(map[string]interface{})
+	eventMap["event_type"] = event.EventType()
+	eventMap["event_id"] = event.EventID()
+	eventMap["timestamp"] = event.Timestamp()
+
+	// Add type-specific fields
+	switch e := event.(type) {
+	case *schema.RequirementAdded:
+		eventMap["requirement"] = e.Requirement
+	case *schema.RequirementDeleted:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["requirement"] = e.Requirement
+	case *schema.AcceptanceCriterionAdded:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["criterion"] = e.Criterion
+	case *schema.AcceptanceCriterionDeleted:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["criterion_id"] = e.CriterionID
+		eventMap["criterion"] = e.Criterion
+	case *schema.ProjectMetadataUpdated:
+		eventMap["old_metadata"] = e.OldMetadata
+		eventMap["new_metadata"] = e.NewMetadata
+	case *schema.VersionBumped:
+		eventMap["old_version"] = e.OldVersion
+		eventMap["new_version"] = e.NewVersion
+		eventMap["bump_type"] = e.BumpType
+		eventMap["reasoning"] = e.Reasoning
+	case *schema.CategoryAdded:
+		eventMap["name"] = e.Name
+		if e.Description != "" {
+			eventMap["description"] = e.Description
+		}
+		if e.Owner != "" {
+			eventMap["owner"] = e.Owner
+		}
+	case *schema.CategoryDeleted:
+		eventMap["name"] = e.Name
+	case *schema.CategoryRenamed:
+		eventMap["old_name"] = e.OldName
+		eventMap["new_name"] = e.NewName
+		if len(e.RequirementIDs) > 0 {
+			eventMap["requirement_ids"] = e.RequirementIDs
+		}
+	case *schema.CategoryMerged:
+		eventMap["source"] = e.Source
+		eventMap["target"] = e.Target
+		if len(e.RequirementIDs) > 0 {
+			eventMap["requirement_ids"] = e.RequirementIDs
+		}
+	case *schema.RequirementLinkAdded:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["link"] = e.Link
+	case *schema.RequirementLinkRemoved:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["link"] = e.Link
+	case *schema.RequirementStatusChanged:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["from"] = e.From
+		eventMap["to"] = e.To
+		eventMap["actor"] = e.Actor
+		if e.Comment != "" {
+			eventMap["comment"] = e.Comment
+		}
+	case *schema.RequirementTagsChanged:
+		eventMap["requirement_id"] = e.RequirementID
+		if len(e.Added) > 0 {
+			eventMap["added"] = e.Added
+		}
+		if len(e.Removed) > 0 {
+			eventMap["removed"] = e.Removed
+		}
+	case *schema.RequirementFieldSet:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["name"] = e.Name
+		if e.Value != "" {
+			eventMap["value"] = e.Value
+		}
+		if e.OldValue != "" {
+			eventMap["old_value"] = e.OldValue
+		}
+	case *schema.AcceptanceCriterionVerificationSet:
+		eventMap["requirement_id"] = e.RequirementID
+		eventMap["criterion_id"] = e.CriterionID
+		if e.Verification != (schema.Verification{}) {
+			eventMap["verification"] = e.Verification
+		}
+		if e.OldVerification != (schema.Verification{}) {
+			eventMap["old_verification"] = e.OldVerification
+		}
+	case *schema.ConflictOverridden:
+		eventMap["conflicts"] = e.Conflicts
+		eventMap["reason"] = e.Reason
+	}
+
+	if envelope != nil && len(envelope.PromptHashes) > 0 {
+		eventMap["prompt_hashes"] = envelope.PromptHashes
+	}
+
+	return eventMap
+}
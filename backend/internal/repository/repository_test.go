@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestRepository_ReadWriteSpecification(t *testing.T) {
@@ -53,11 +55,11 @@ func TestRepository_ReadWriteSpecification(t *testing.T) {
 				CreatedAt: time.Now(),
 			},
 		},
-		Categories: []string{"TEST"},
+		Categories: []schema.Category{{Name: "TEST"}},
 	}
 
 	// Write spec
-	err = repo.WriteSpecification(newSpec)
+	err = repo.WriteSpecification(context.Background(), newSpec)
 	require.NoError(t, err)
 
 	// Read it back
@@ -99,7 +101,7 @@ func TestRepository_AppendChangelog(t *testing.T) {
 	}
 
 	// Append events
-	err := repo.AppendChangelog(events)
+	err := repo.AppendChangelog(context.Background(), events)
 	require.NoError(t, err)
 
 	// Verify file exists
@@ -123,10 +125,10 @@ func TestRepository_WriteSpecification_AtomicityGuarantee(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
-	err := repo.WriteSpecification(spec)
+	err := repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
 	// Verify no temporary directories left behind in tempDir
@@ -169,7 +171,7 @@ func TestRepository_WriteSpecificationAndChangelog_AtomicityGuarantee(t *testing
 				CreatedAt:   time.Now(),
 			},
 		},
-		Categories: []string{"AUTH"},
+		Categories: []schema.Category{{Name: "AUTH"}},
 	}
 
 	events := []schema.ChangelogEvent{
@@ -180,7 +182,7 @@ func TestRepository_WriteSpecificationAndChangelog_AtomicityGuarantee(t *testing
 		},
 	}
 
-	err := repo.WriteSpecificationAndChangelog(spec, events)
+	err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events)
 	require.NoError(t, err)
 
 	// Verify both files exist
@@ -224,10 +226,10 @@ func TestRepository_WriteSpecification_IsolationDuringTransaction(t *testing.T)
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
-	err := repo.WriteSpecification(initialSpec)
+	err := repo.WriteSpecification(context.Background(), initialSpec)
 	require.NoError(t, err)
 
 	// Read initial content
@@ -249,10 +251,10 @@ func TestRepository_WriteSpecification_IsolationDuringTransaction(t *testing.T)
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
-	err = repo.WriteSpecification(updatedSpec)
+	err = repo.WriteSpecification(context.Background(), updatedSpec)
 	require.NoError(t, err)
 
 	// Verify the file was updated
@@ -292,10 +294,10 @@ func TestRepository_SequentialWrites(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
-	err := repo.WriteSpecification(spec1)
+	err := repo.WriteSpecification(context.Background(), spec1)
 	require.NoError(t, err)
 
 	// Write version 2
@@ -308,10 +310,10 @@ func TestRepository_SequentialWrites(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
-	err = repo.WriteSpecification(spec2)
+	err = repo.WriteSpecification(context.Background(), spec2)
 	require.NoError(t, err)
 
 	// Verify final state
@@ -321,3 +323,488 @@ func TestRepository_SequentialWrites(t *testing.T) {
 	assert.Equal(t, "0.2.0", finalSpec.Metadata.Version)
 	assert.Equal(t, "Version 2", finalSpec.Metadata.Description)
 }
+
+func TestRepository_WriteSpecificationAndChangelogWithEnvelope(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	spec := &schema.Specification{
+		Metadata:   schema.ProjectMetadata{Name: "Envelope", Version: "0.1.0"},
+		Categories: []schema.Category{{Name: "AUTH"}},
+	}
+	events := []schema.ChangelogEvent{
+		&schema.CategoryAdded{EventID_: "EVT-1", Name: "AUTH", Timestamp_: time.Now()},
+	}
+	envelope := &EventEnvelope{PromptHashes: map[string]string{"delta": "0123456789abcdef"}}
+
+	err := repo.WriteSpecificationAndChangelogWithEnvelope(context.Background(), spec, events, envelope)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "01-specs", "changelog.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "prompt_hashes:")
+	assert.Contains(t, string(data), "delta: 0123456789abcdef")
+
+	// Envelope fields do not affect replay
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AUTH"}, replayed.CategoryNames())
+}
+
+func TestRepository_ConflictOverriddenRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	spec := &schema.Specification{
+		Metadata:   schema.ProjectMetadata{Name: "Overrides", Version: "0.1.0"},
+		Categories: []schema.Category{{Name: "AUTH"}},
+	}
+	events := []schema.ChangelogEvent{
+		&schema.CategoryAdded{EventID_: "EVT-1", Name: "AUTH", Timestamp_: time.Now()},
+		&schema.ConflictOverridden{
+			EventID_: "EVT-2",
+			Conflicts: []schema.ConflictRecord{{
+				RequirementA: "REQ-AUTH-new",
+				RequirementB: "REQ-AUTH-old",
+				Severity:     "critical",
+				Explanation:  "15 minute expiry vs 24 hour sessions",
+			}},
+			Reason:     "Old requirement is removed next sprint",
+			Timestamp_: time.Now(),
+		},
+	}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	// The override is recorded but does not change the replayed spec
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AUTH"}, replayed.CategoryNames())
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "01-specs", "changelog.yaml"))
+	require.NoError(t, err)
+	var changelog struct {
+		Events []map[string]interface{} `yaml:"events"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &changelog))
+	require.Len(t, changelog.Events, 2)
+
+	event, err := mapToEvent(changelog.Events[1])
+	require.NoError(t, err)
+	override, ok := event.(*schema.ConflictOverridden)
+	require.True(t, ok, "expected ConflictOverridden, got %T", event)
+	assert.Equal(t, "Old requirement is removed next sprint", override.Reason)
+	assert.Equal(t, events[1].(*schema.ConflictOverridden).Conflicts, override.Conflicts)
+}
+
+func TestRepository_CategoryMovesRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	now := time.Now()
+	requirement := func(id, category string) schema.Requirement {
+		return schema.Requirement{
+			ID:          id,
+			Type:        schema.EARSUbiquitous,
+			Category:    category,
+			Description: "The system shall support " + id,
+			Rationale:   "Needed",
+			Priority:    schema.PriorityMedium,
+			CreatedAt:   now,
+		}
+	}
+	events := []schema.ChangelogEvent{
+		&schema.CategoryAdded{EventID_: "EVT-1", Name: "LOGIN", Timestamp_: now},
+		&schema.CategoryAdded{EventID_: "EVT-2", Name: "SESSION", Timestamp_: now.Add(time.Millisecond)},
+		&schema.RequirementAdded{EventID_: "EVT-3", Requirement: requirement("REQ-LOGIN-aaa111", "LOGIN"), Timestamp_: now.Add(2 * time.Millisecond)},
+		&schema.RequirementAdded{EventID_: "EVT-4", Requirement: requirement("REQ-SESSION-bbb222", "SESSION"), Timestamp_: now.Add(3 * time.Millisecond)},
+		&schema.CategoryRenamed{
+			EventID_:       "EVT-5",
+			OldName:        "LOGIN",
+			NewName:        "AUTH",
+			RequirementIDs: map[string]string{"REQ-LOGIN-aaa111": "REQ-AUTH-aaa111"},
+			Timestamp_:     now.Add(4 * time.Millisecond),
+		},
+		&schema.CategoryMerged{
+			EventID_:       "EVT-6",
+			Source:         "SESSION",
+			Target:         "AUTH",
+			RequirementIDs: map[string]string{"REQ-SESSION-bbb222": "REQ-AUTH-bbb222"},
+			Timestamp_:     now.Add(5 * time.Millisecond),
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Moves", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AUTH"}, replayed.CategoryNames())
+	require.Len(t, replayed.Requirements, 2)
+	assert.Equal(t, "REQ-AUTH-aaa111", replayed.Requirements[0].ID)
+	assert.Equal(t, "REQ-AUTH-bbb222", replayed.Requirements[1].ID)
+	for _, req := range replayed.Requirements {
+		assert.Equal(t, "AUTH", req.Category)
+	}
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "01-specs", "changelog.yaml"))
+	require.NoError(t, err)
+	var changelog struct {
+		Events []map[string]interface{} `yaml:"events"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &changelog))
+	require.Len(t, changelog.Events, 6)
+
+	event, err := mapToEvent(changelog.Events[5])
+	require.NoError(t, err)
+	merged, ok := event.(*schema.CategoryMerged)
+	require.True(t, ok, "expected CategoryMerged, got %T", event)
+	assert.Equal(t, "SESSION", merged.Source)
+	assert.Equal(t, "AUTH", merged.Target)
+	assert.Equal(t, map[string]string{"REQ-SESSION-bbb222": "REQ-AUTH-bbb222"}, merged.RequirementIDs)
+}
+
+func TestRepository_CategoryDescriptionRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	added := &schema.CategoryAdded{
+		EventID_:    "EVT-1",
+		Name:        "AUTH",
+		Description: "Login, sessions and access control",
+		Owner:       "identity-team",
+		Timestamp_:  time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Categories", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, []schema.ChangelogEvent{added}))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	require.Len(t, replayed.Categories, 1)
+	got := replayed.Categories[0]
+	assert.Equal(t, "AUTH", got.Name)
+	assert.Equal(t, added.Description, got.Description)
+	assert.Equal(t, "identity-team", got.Owner)
+	assert.True(t, got.CreatedAt.Equal(added.Timestamp_), "created_at should be the event timestamp, got %v", got.CreatedAt)
+}
+
+func TestRepository_RequirementLinksRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	now := time.Now()
+	requirement := func(id string, links ...schema.RequirementLink) schema.Requirement {
+		return schema.Requirement{
+			ID:          id,
+			Type:        schema.EARSUbiquitous,
+			Category:    "AUTH",
+			Description: "The system shall support " + id,
+			Rationale:   "Needed",
+			Priority:    schema.PriorityMedium,
+			Links:       links,
+			CreatedAt:   now,
+		}
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: requirement("REQ-AUTH-aaa111"), Timestamp_: now},
+		&schema.RequirementAdded{
+			EventID_:    "EVT-2",
+			Requirement: requirement("REQ-AUTH-bbb222", schema.RequirementLink{Type: schema.LinkRefines, Target: "REQ-AUTH-aaa111"}),
+			Timestamp_:  now.Add(time.Millisecond),
+		},
+		&schema.RequirementLinkAdded{
+			EventID_:      "EVT-3",
+			RequirementID: "REQ-AUTH-bbb222",
+			Link:          schema.RequirementLink{Type: schema.LinkDependsOn, Target: "REQ-AUTH-aaa111"},
+			Timestamp_:    now.Add(2 * time.Millisecond),
+		},
+		&schema.RequirementLinkRemoved{
+			EventID_:      "EVT-4",
+			RequirementID: "REQ-AUTH-bbb222",
+			Link:          schema.RequirementLink{Type: schema.LinkRefines, Target: "REQ-AUTH-aaa111"},
+			Timestamp_:    now.Add(3 * time.Millisecond),
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Links", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	require.Len(t, replayed.Requirements, 2)
+	assert.Empty(t, replayed.Requirements[0].Links)
+	assert.Equal(t, []schema.RequirementLink{{Type: schema.LinkDependsOn, Target: "REQ-AUTH-aaa111"}}, replayed.Requirements[1].Links)
+}
+
+func TestRepository_RequirementStatusRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	now := time.Now()
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{
+			EventID_:    "EVT-1",
+			Requirement: schema.Requirement{ID: "REQ-AUTH-aaa111", Category: "AUTH", Description: "Users log in", CreatedAt: now},
+			Timestamp_:  now,
+		},
+		&schema.RequirementStatusChanged{
+			EventID_:      "EVT-2",
+			RequirementID: "REQ-AUTH-aaa111",
+			From:          schema.StatusDraft,
+			To:            schema.StatusApproved,
+			Actor:         "alex",
+			Comment:       "Signed off in review",
+			Timestamp_:    now.Add(time.Millisecond),
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Status", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	require.Len(t, replayed.Requirements, 1)
+	assert.Equal(t, schema.StatusApproved, replayed.Requirements[0].Status)
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "01-specs", "changelog.yaml"))
+	require.NoError(t, err)
+	var changelog struct {
+		Events []map[string]interface{} `yaml:"events"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &changelog))
+	require.Len(t, changelog.Events, 2)
+
+	event, err := mapToEvent(changelog.Events[1])
+	require.NoError(t, err)
+	changed, ok := event.(*schema.RequirementStatusChanged)
+	require.True(t, ok, "expected RequirementStatusChanged, got %T", event)
+	assert.Equal(t, "alex", changed.Actor)
+	assert.Equal(t, "Signed off in review", changed.Comment)
+	assert.Equal(t, schema.StatusDraft, changed.From)
+
+	// Replay rejects a change whose recorded starting status no longer holds
+	stale := *changed
+	stale.EventID_ = "EVT-3"
+	stale.Timestamp_ = now.Add(2 * time.Millisecond)
+	_, err = ReplayEvents(&schema.Specification{}, append(events, &stale))
+	assert.ErrorContains(t, err, "requirement REQ-AUTH-aaa111 is approved, not draft")
+}
+
+func TestRepository_TagsAndFieldsRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	now := time.Now()
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{
+			EventID_: "EVT-1",
+			Requirement: schema.Requirement{
+				ID: "REQ-AUTH-aaa111", Category: "AUTH", Description: "Users log in",
+				Tags: []string{"SOC2"}, Fields: map[string]string{"component": "gateway"}, CreatedAt: now,
+			},
+			Timestamp_: now,
+		},
+		&schema.RequirementTagsChanged{
+			EventID_:      "EVT-2",
+			RequirementID: "REQ-AUTH-aaa111",
+			Added:         []string{"HIPAA"},
+			Removed:       []string{"SOC2"},
+			Timestamp_:    now.Add(time.Millisecond),
+		},
+		&schema.RequirementFieldSet{
+			EventID_:      "EVT-3",
+			RequirementID: "REQ-AUTH-aaa111",
+			Name:          "due",
+			Value:         "2025-12-31",
+			Timestamp_:    now.Add(2 * time.Millisecond),
+		},
+		&schema.RequirementFieldSet{
+			EventID_:      "EVT-4",
+			RequirementID: "REQ-AUTH-aaa111",
+			Name:          "component",
+			OldValue:      "gateway",
+			Timestamp_:    now.Add(3 * time.Millisecond),
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Annotations", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	require.Len(t, replayed.Requirements, 1)
+	assert.Equal(t, []string{"HIPAA"}, replayed.Requirements[0].Tags)
+	assert.Equal(t, map[string]string{"due": "2025-12-31"}, replayed.Requirements[0].Fields)
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "01-specs", "changelog.yaml"))
+	require.NoError(t, err)
+	var changelog struct {
+		Events []map[string]interface{} `yaml:"events"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &changelog))
+	require.Len(t, changelog.Events, 4)
+
+	event, err := mapToEvent(changelog.Events[3])
+	require.NoError(t, err)
+	cleared, ok := event.(*schema.RequirementFieldSet)
+	require.True(t, ok, "expected RequirementFieldSet, got %T", event)
+	assert.Empty(t, cleared.Value)
+	assert.Equal(t, "gateway", cleared.OldValue)
+}
+
+func TestRepository_SetFieldDefinitions(t *testing.T) {
+	repo := NewRepository(filepath.Join(t.TempDir(), ".xdd"))
+	now := time.Now()
+	added := func(fields map[string]string) []schema.ChangelogEvent {
+		evtID, _ := schema.NewEventID()
+		reqID, _ := schema.NewRequirementID("AUTH")
+		return []schema.ChangelogEvent{&schema.RequirementAdded{
+			EventID_:    evtID,
+			Requirement: schema.Requirement{ID: reqID, Category: "AUTH", Description: "Users log in", Fields: fields, CreatedAt: now},
+			Timestamp_:  now,
+		}}
+	}
+	write := func(fields map[string]string) error {
+		spec, err := repo.ReadSpecification()
+		require.NoError(t, err)
+		events := added(fields)
+		_, err = ReplayEvents(spec, events)
+		require.NoError(t, err)
+		return repo.WriteSpecificationAndChangelog(context.Background(), spec, events)
+	}
+
+	// Unchecked until the project's fields are known
+	require.NoError(t, write(map[string]string{"team": "identity"}))
+
+	repo.SetFieldDefinitions([]schema.FieldDefinition{{Name: "points", Type: schema.FieldInt}})
+	assert.ErrorContains(t, write(map[string]string{"points": "eight"}), "field team is not declared")
+
+	repo.SetFieldDefinitions([]schema.FieldDefinition{{Name: "team", Type: schema.FieldString}, {Name: "points", Type: schema.FieldInt}})
+	assert.ErrorContains(t, write(map[string]string{"points": "eight"}), "validate fields")
+	require.NoError(t, write(map[string]string{"points": "8"}))
+
+	// Reads stay unchecked, so a project can repair its fields
+	repo.SetFieldDefinitions(nil)
+	spec, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	assert.Len(t, spec.Requirements, 2)
+}
+
+func TestRepository_VerificationSetRoundTrip(t *testing.T) {
+	repo := NewRepository(filepath.Join(t.TempDir(), ".xdd"))
+	now := time.Now()
+	v := schema.Verification{Method: schema.VerificationInspection, Ref: "checklists/auth.md"}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{EventID_: "EVT-1", Requirement: schema.Requirement{
+			ID: "REQ-AUTH-aaa111", Category: "AUTH", Description: "Users log in",
+			AcceptanceCriteria: []schema.AcceptanceCriterion{
+				&schema.AssertionCriterion{ID: "AC-1", Type: "assertion", Statement: "Passwords are hashed", CreatedAt: now},
+			},
+			CreatedAt: now,
+		}, Timestamp_: now},
+		&schema.AcceptanceCriterionVerificationSet{
+			EventID_:      "EVT-2",
+			RequirementID: "REQ-AUTH-aaa111",
+			CriterionID:   "AC-1",
+			Verification:  v,
+			Timestamp_:    now.Add(time.Millisecond),
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Verified", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	assert.Equal(t, v, replayed.Requirements[0].AcceptanceCriteria[0].GetVerification())
+
+	eventMap := eventToMap(events[1], nil)
+	assert.NotContains(t, eventMap, "old_verification", "an empty snapshot is left out")
+	event, err := mapToEvent(map[string]interface{}{
+		"event_id": "EVT-3", "event_type": "AcceptanceCriterionVerificationSet", "timestamp": now,
+		"requirement_id": "REQ-AUTH-aaa111", "criterion_id": "AC-1",
+		"old_verification": map[string]interface{}{"verification_method": "inspection", "verification_ref": "checklists/auth.md"},
+	})
+	require.NoError(t, err)
+	cleared, ok := event.(*schema.AcceptanceCriterionVerificationSet)
+	require.True(t, ok, "expected AcceptanceCriterionVerificationSet, got %T", event)
+	assert.Empty(t, cleared.Verification)
+	assert.Equal(t, v, cleared.OldVerification)
+}
+
+func TestRepository_MetricCriteriaRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	latency := &schema.MetricCriterion{
+		ID: "AC-lat", Type: "metric", Measure: "p95 latency", Comparator: schema.ComparatorLess,
+		Threshold: 200, Unit: "ms", Conditions: "under 1k rps", CreatedAt: now,
+	}
+	availability := &schema.MetricCriterion{
+		ID: "AC-avail", Type: "metric", Measure: "monthly availability", Comparator: schema.ComparatorGreaterEqual,
+		Threshold: 99.95, Unit: "%", CreatedAt: now,
+		Verification: schema.Verification{Method: schema.VerificationAnalysis, Ref: "uptime report"},
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{
+			EventID_: "EVT-1",
+			Requirement: schema.Requirement{
+				ID: "REQ-SEARCH-aaa111", Category: "SEARCH", Description: "Search returns results",
+				AcceptanceCriteria: []schema.AcceptanceCriterion{latency}, CreatedAt: now,
+			},
+			Timestamp_: now,
+		},
+		&schema.AcceptanceCriterionAdded{
+			EventID_:      "EVT-2",
+			RequirementID: "REQ-SEARCH-aaa111",
+			Criterion:     availability,
+			Timestamp_:    now.Add(time.Millisecond),
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Metrics", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	require.Len(t, replayed.Requirements, 1)
+	assert.Equal(t, []schema.AcceptanceCriterion{latency, availability}, replayed.Requirements[0].AcceptanceCriteria)
+
+	// A threshold that is not a number is rejected rather than read as zero
+	_, err = mapToAcceptanceCriterion(map[string]interface{}{"id": "AC-bad", "type": "metric", "threshold": "fast"})
+	assert.ErrorContains(t, err, "metric criterion AC-bad: threshold is not a number")
+}
+
+func TestRepository_ScenarioOutlineRoundTrip(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), ".xdd")
+	repo := NewRepository(baseDir)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	outline := &schema.ScenarioOutlineCriterion{
+		ID: "AC-out", Type: "scenario_outline", CreatedAt: now,
+		Given: "a user with role <role>", When: "they open /admin", Then: "the response is <status>",
+		Examples: schema.ExamplesTable{Columns: []string{"role", "status"}, Rows: [][]string{{"admin", "200"}, {"guest", "403"}}},
+	}
+	events := []schema.ChangelogEvent{
+		&schema.RequirementAdded{
+			EventID_: "EVT-1",
+			Requirement: schema.Requirement{
+				ID: "REQ-AUTH-aaa111", Category: "AUTH", Description: "Admins manage users",
+				AcceptanceCriteria: []schema.AcceptanceCriterion{outline}, CreatedAt: now,
+			},
+			Timestamp_: now,
+		},
+	}
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Outlines", Version: "0.1.0"}}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(context.Background(), spec, events))
+
+	replayed, err := repo.ReadSpecification()
+	require.NoError(t, err)
+	require.Len(t, replayed.Requirements, 1)
+	assert.Equal(t, []schema.AcceptanceCriterion{outline}, replayed.Requirements[0].AcceptanceCriteria)
+
+	// Hand-written tables may leave numbers unquoted
+	examples, err := mapToExamples(map[string]interface{}{
+		"columns": []interface{}{"role", "status"},
+		"rows":    []interface{}{[]interface{}{"admin", 200}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"admin", "200"}}, examples.Rows)
+
+	_, err = mapToAcceptanceCriterion(map[string]interface{}{"id": "AC-bad", "type": "scenario_outline", "examples": "role"})
+	assert.ErrorContains(t, err, "scenario outline AC-bad: examples: not a map")
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -24,7 +25,7 @@ func BenchmarkSnapshotCreation(b *testing.B) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: make([]schema.Requirement, 100),
-		Categories:   []string{"TEST"},
+		Categories:   []schema.Category{{Name: "TEST"}},
 	}
 
 	for i := 0; i < 100; i++ {
@@ -64,7 +65,7 @@ func BenchmarkSnapshotLoad(b *testing.B) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: make([]schema.Requirement, 100),
-		Categories:   []string{"TEST"},
+		Categories:   []schema.Category{{Name: "TEST"}},
 	}
 
 	for i := 0; i < 100; i++ {
@@ -109,7 +110,7 @@ func BenchmarkRepositoryWriteWithSnapshot(b *testing.B) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	// Create 101 events to trigger snapshot
@@ -129,7 +130,7 @@ func BenchmarkRepositoryWriteWithSnapshot(b *testing.B) {
 		os.RemoveAll(baseDir)
 
 		spec.Metadata.UpdatedAt = time.Now()
-		err := repo.WriteSpecificationAndChangelog(spec, events)
+		err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events)
 		if err != nil {
 			b.Fatal(err)
 		}
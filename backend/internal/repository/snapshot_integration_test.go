@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -29,7 +30,7 @@ func TestSnapshotIntegration_AutomaticSnapshotCreation(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	// Create 101 events to trigger snapshot (threshold is 100)
@@ -58,7 +59,7 @@ func TestSnapshotIntegration_AutomaticSnapshotCreation(t *testing.T) {
 	}
 
 	// Write spec and changelog (should create snapshot)
-	err := repo.WriteSpecificationAndChangelog(spec, events)
+	err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events)
 	require.NoError(t, err)
 
 	// Verify snapshot was created
@@ -96,7 +97,7 @@ func TestSnapshotIntegration_LoadPerformance(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{"TEST"},
+		Categories:   []schema.Category{{Name: "TEST"}},
 	}
 
 	// Add 50 requirements
@@ -119,7 +120,7 @@ func TestSnapshotIntegration_LoadPerformance(t *testing.T) {
 	require.NoError(t, err)
 
 	// Also write specification normally
-	err = repo.WriteSpecification(spec)
+	err = repo.WriteSpecification(context.Background(), spec)
 	require.NoError(t, err)
 
 	// Measure load time with snapshot
@@ -150,7 +151,7 @@ func TestSnapshotIntegration_SnapshotEvery100Events(t *testing.T) {
 			UpdatedAt:   time.Now(),
 		},
 		Requirements: []schema.Requirement{},
-		Categories:   []string{},
+		Categories:   []schema.Category{},
 	}
 
 	// Write 99 events (should not create snapshot)
@@ -164,7 +165,7 @@ func TestSnapshotIntegration_SnapshotEvery100Events(t *testing.T) {
 		}
 	}
 
-	err := repo.WriteSpecificationAndChangelog(spec, events1)
+	err := repo.WriteSpecificationAndChangelog(context.Background(), spec, events1)
 	require.NoError(t, err)
 
 	// Verify no snapshot created yet
@@ -182,7 +183,7 @@ func TestSnapshotIntegration_SnapshotEvery100Events(t *testing.T) {
 	}
 
 	spec.Metadata.UpdatedAt = time.Now()
-	err = repo.WriteSpecificationAndChangelog(spec, events2)
+	err = repo.WriteSpecificationAndChangelog(context.Background(), spec, events2)
 	require.NoError(t, err)
 
 	// Verify snapshot was created
@@ -46,7 +46,7 @@ func TestSnapshotManager_CreateSnapshot(t *testing.T) {
 				CreatedAt:   time.Now(),
 			},
 		},
-		Categories: []string{"TEST"},
+		Categories: []schema.Category{{Name: "TEST"}},
 	}
 
 	// Create snapshot
@@ -126,7 +126,7 @@ func TestSnapshotManager_LoadFromSnapshot_WithSnapshot(t *testing.T) {
 				CreatedAt:   time.Now(),
 			},
 		},
-		Categories: []string{"LOAD"},
+		Categories: []schema.Category{{Name: "LOAD"}},
 	}
 
 	// Create snapshot
@@ -178,7 +178,7 @@ func TestSnapshotManager_LoadFromSnapshot_WithEventsAfter(t *testing.T) {
 				CreatedAt:   now,
 			},
 		},
-		Categories: []string{"OLD"},
+		Categories: []schema.Category{{Name: "OLD"}},
 	}
 
 	// Create snapshot
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"xdd/internal/telemetry"
+	"xdd/internal/telemetry/telemetrytest"
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_TracesTransactions(t *testing.T) {
+	exporter, restore := telemetrytest.UseInMemoryExporter()
+	defer restore()
+
+	ctx, parent := telemetry.Start(context.Background(), "commit")
+	repo := NewRepository(filepath.Join(t.TempDir(), ".xdd"))
+
+	spec := &schema.Specification{Metadata: schema.ProjectMetadata{Name: "Traced", Version: "0.1.0", UpdatedAt: time.Now()}}
+	events := []schema.ChangelogEvent{
+		&schema.CategoryAdded{EventID_: "EVT-1", Name: "AUTH", Timestamp_: time.Now()},
+		&schema.CategoryAdded{EventID_: "EVT-2", Name: "TASKS", Timestamp_: time.Now()},
+	}
+	require.NoError(t, repo.WriteSpecificationAndChangelog(ctx, spec, events))
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	tx := spans[0]
+	assert.Equal(t, "repository.WriteSpecificationAndChangelog", tx.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), tx.Parent.SpanID())
+
+	var count int64
+	for _, kv := range tx.Attributes {
+		if kv.Key == telemetry.AttrEventCount {
+			count = kv.Value.AsInt64()
+		}
+	}
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,205 @@
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultThreshold is the cosine similarity above which two requirements
+// are treated as likely duplicates.
+const DefaultThreshold = 0.6
+
+// stopwords carry no meaning for duplicate detection; they appear in nearly
+// every EARS requirement.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "be": true,
+	"by": true, "for": true, "from": true, "if": true, "in": true, "is": true,
+	"it": true, "its": true, "of": true, "on": true, "or": true, "shall": true,
+	"should": true, "system": true, "that": true, "the": true, "their": true,
+	"them": true, "then": true, "this": true, "to": true, "when": true,
+	"while": true, "where": true, "will": true, "with": true, "must": true,
+	"can": true, "able": true,
+}
+
+// Document is a piece of text to compare, identified by ID.
+type Document struct {
+	ID   string
+	Text string
+}
+
+// Match is a document similar to a query.
+type Match struct {
+	ID    string
+	Score float64 // Cosine similarity in [0, 1]
+}
+
+// Index holds TF-IDF vectors for a corpus of documents.
+type Index struct {
+	docs    []Document
+	vectors []vector
+	docFreq map[string]int
+}
+
+// vector is a sparse, L2-normalized TF-IDF vector.
+type vector map[string]float64
+
+// NewIndex builds an index over docs. Term weights are computed from the
+// whole corpus, so terms common to every document count for little.
+func NewIndex(docs []Document) *Index {
+	idx := &Index{
+		docs:    docs,
+		vectors: make([]vector, len(docs)),
+		docFreq: make(map[string]int),
+	}
+
+	terms := make([][]string, len(docs))
+	for i, doc := range docs {
+		terms[i] = Terms(doc.Text)
+		seen := make(map[string]bool)
+		for _, term := range terms[i] {
+			if !seen[term] {
+				seen[term] = true
+				idx.docFreq[term]++
+			}
+		}
+	}
+
+	for i := range docs {
+		idx.vectors[i] = idx.vectorize(terms[i])
+	}
+	return idx
+}
+
+// Query returns the documents whose similarity to text is at least
+// threshold, best first.
+func (idx *Index) Query(text string, threshold float64) []Match {
+	q := idx.vectorize(Terms(text))
+
+	var matches []Match
+	for i, v := range idx.vectors {
+		if score := cosine(q, v); score >= threshold {
+			matches = append(matches, Match{ID: idx.docs[i].ID, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Score > matches[b].Score
+	})
+	return matches
+}
+
+// Clusters groups documents connected by similarity of at least threshold.
+// Only groups of two or more are returned, each in corpus order; groups are
+// ordered by their first member.
+func (idx *Index) Clusters(threshold float64) [][]Document {
+	parent := make([]int, len(idx.docs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range idx.vectors {
+		for j := i + 1; j < len(idx.vectors); j++ {
+			if cosine(idx.vectors[i], idx.vectors[j]) >= threshold {
+				if ri, rj := find(i), find(j); ri != rj {
+					parent[rj] = ri
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]Document)
+	var roots []int
+	for i, doc := range idx.docs {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], doc)
+	}
+
+	var clusters [][]Document
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			clusters = append(clusters, groups[root])
+		}
+	}
+	return clusters
+}
+
+// vectorize weights terms with smoothed IDF from the corpus. Terms the
+// corpus has never seen get the highest weight.
+func (idx *Index) vectorize(terms []string) vector {
+	tf := make(map[string]int)
+	for _, term := range terms {
+		tf[term]++
+	}
+
+	n := float64(len(idx.docs))
+	v := make(vector, len(tf))
+	var norm float64
+	for term, count := range tf {
+		idf := math.Log((1+n)/(1+float64(idx.docFreq[term]))) + 1
+		w := float64(count) * idf
+		v[term] = w
+		norm += w * w
+	}
+
+	norm = math.Sqrt(norm)
+	for term := range v {
+		v[term] /= norm
+	}
+	return v
+}
+
+// cosine returns the dot product of two normalized vectors.
+func cosine(a, b vector) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return math.Min(dot, 1) // Guard against rounding just above 1
+}
+
+// Terms lowercases text, splits it into words, drops stopwords and reduces
+// each word to a crude stem so "logs in" and "logging in" compare equal.
+func Terms(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if len(f) < 2 || stopwords[f] {
+			continue
+		}
+		terms = append(terms, stem(f))
+	}
+	return terms
+}
+
+// stem strips common English inflections. It is deliberately simple: the
+// goal is matching word forms within one spec, not linguistic accuracy.
+func stem(word string) string {
+	for _, suffix := range []string{"ing", "ed", "es", "s"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+	// "logging" -> "logg" -> "log"
+	if n := len(word); n >= 4 && word[n-1] == word[n-2] && !strings.ContainsRune("aeiousl", rune(word[n-1])) {
+		word = word[:n-1]
+	}
+	return word
+}
@@ -0,0 +1,71 @@
+package similarity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func corpus() []Document {
+	return []Document{
+		{ID: "REQ-AUTH-1", Text: "When a user submits valid credentials, the system shall log the user in"},
+		{ID: "REQ-AUTH-2", Text: "When a user enters an incorrect password three times, the system shall lock the account"},
+		{ID: "REQ-TASK-1", Text: "The system shall allow users to create tasks with a title and due date"},
+		{ID: "REQ-TASK-2", Text: "When a task due date passes, the system shall send a reminder email"},
+		{ID: "REQ-AUTH-3", Text: "When the user submits valid credentials the system shall log them in"},
+	}
+}
+
+func TestTerms(t *testing.T) {
+	got := Terms("When the user is logging in, the system shall log requests")
+	want := []string{"user", "log", "log", "request"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestIndex_Query(t *testing.T) {
+	idx := NewIndex(corpus())
+
+	matches := idx.Query("Users shall be logged in after submitting valid credentials", DefaultThreshold)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %v", matches)
+	}
+	for _, m := range matches {
+		if m.ID != "REQ-AUTH-1" && m.ID != "REQ-AUTH-3" {
+			t.Errorf("unexpected match %s", m.ID)
+		}
+	}
+	if matches[0].Score < matches[1].Score {
+		t.Errorf("expected matches ordered best first, got %v", matches)
+	}
+
+	if matches := idx.Query("Export reports as PDF", DefaultThreshold); len(matches) != 0 {
+		t.Errorf("expected no matches for unrelated text, got %v", matches)
+	}
+
+	// Identical text scores 1
+	matches = idx.Query(corpus()[2].Text, 0.99)
+	if len(matches) != 1 || matches[0].ID != "REQ-TASK-1" {
+		t.Errorf("expected exact match, got %v", matches)
+	}
+}
+
+func TestIndex_Clusters(t *testing.T) {
+	idx := NewIndex(corpus())
+
+	clusters := idx.Clusters(DefaultThreshold)
+	if len(clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %v", clusters)
+	}
+	var ids []string
+	for _, doc := range clusters[0] {
+		ids = append(ids, doc.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"REQ-AUTH-1", "REQ-AUTH-3"}) {
+		t.Errorf("expected login requirements clustered, got %v", ids)
+	}
+
+	if clusters := NewIndex(nil).Clusters(DefaultThreshold); len(clusters) != 0 {
+		t.Errorf("expected no clusters for empty corpus, got %v", clusters)
+	}
+}
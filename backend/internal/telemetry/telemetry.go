@@ -0,0 +1,113 @@
+// Package telemetry configures OpenTelemetry tracing for xdd.
+//
+// Tracing is off unless OTEL_TRACES_EXPORTER selects an exporter:
+//
+//	OTEL_TRACES_EXPORTER=console  # Pretty-printed spans on stdout
+//	OTEL_TRACES_EXPORTER=otlp     # OTLP/HTTP, see OTEL_EXPORTER_OTLP_ENDPOINT
+//
+// The OTLP exporter honours the standard OTEL_EXPORTER_OTLP_* variables
+// (endpoint, headers, timeout).
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies xdd's tracer.
+const InstrumentationName = "xdd"
+
+// ExporterEnv selects the span exporter.
+const ExporterEnv = "OTEL_TRACES_EXPORTER"
+
+// Exporter names accepted in ExporterEnv.
+const (
+	ExporterNone    = "none"
+	ExporterConsole = "console"
+	ExporterStdout  = "stdout" // Alias for console
+	ExporterOTLP    = "otlp"
+)
+
+// Span attribute keys. Model and token keys follow the OpenTelemetry
+// GenAI semantic conventions.
+const (
+	AttrTask                = attribute.Key("xdd.task")
+	AttrModel               = attribute.Key("gen_ai.request.model")
+	AttrInputTokens         = attribute.Key("gen_ai.usage.input_tokens")
+	AttrOutputTokens        = attribute.Key("gen_ai.usage.output_tokens")
+	AttrAttempt             = attribute.Key("xdd.llm.attempt")
+	AttrRetryReason         = attribute.Key("xdd.llm.retry_reason")
+	AttrCacheHit            = attribute.Key("xdd.llm.cache_hit")
+	AttrFallbackModels      = attribute.Key("xdd.llm.fallback_models")
+	AttrHTTPStatusCode      = attribute.Key("http.response.status_code")
+	AttrEventCount          = attribute.Key("xdd.events.count")
+	AttrRequirementsAdded   = attribute.Key("xdd.requirements.added")
+	AttrRequirementsRemoved = attribute.Key("xdd.requirements.removed")
+	AttrConflicts           = attribute.Key("xdd.conflicts.count")
+	AttrGenerationFailures  = attribute.Key("xdd.generation.failures")
+)
+
+// Tracer returns xdd's tracer from the global provider. Until Setup (or
+// telemetrytest.UseInMemoryExporter) installs one, spans are no-ops.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Start starts a span as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup installs a global tracer provider with the exporter named by
+// ExporterEnv. The returned function flushes pending spans and must be
+// called before exit. With no exporter configured, tracing stays off.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch name := os.Getenv(ExporterEnv); name {
+	case "", ExporterNone:
+		return noop, nil
+	case ExporterConsole, ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return noop, fmt.Errorf("%s: unknown exporter %q, must be %s|%s|%s",
+			ExporterEnv, name, ExporterNone, ExporterConsole, ExporterOTLP)
+	}
+	if err != nil {
+		return noop, fmt.Errorf("create trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource()),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func serviceResource() *resource.Resource {
+	return resource.NewSchemaless(attribute.String("service.name", InstrumentationName))
+}
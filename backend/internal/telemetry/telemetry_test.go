@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  string
+	}{
+		{name: "unset leaves tracing off", exporter: ""},
+		{name: "none", exporter: ExporterNone},
+		{name: "console", exporter: ExporterConsole},
+		{name: "stdout alias", exporter: ExporterStdout},
+		{name: "otlp", exporter: ExporterOTLP},
+		{name: "unknown", exporter: "zipkin", wantErr: `unknown exporter "zipkin"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := otel.GetTracerProvider()
+			t.Cleanup(func() { otel.SetTracerProvider(previous) })
+			t.Setenv(ExporterEnv, tt.exporter)
+			// Keep the OTLP exporter from reaching a real collector
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:1")
+
+			shutdown, err := Setup(context.Background())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			enabled := tt.exporter != "" && tt.exporter != ExporterNone
+			assert.Equal(t, enabled, otel.GetTracerProvider() != previous)
+
+			// Nothing was traced, so shutdown has nothing to send
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
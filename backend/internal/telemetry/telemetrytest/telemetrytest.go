@@ -0,0 +1,36 @@
+// Package telemetrytest records xdd's spans in memory for tests, keeping
+// the OpenTelemetry test exporter out of the xdd binary.
+package telemetrytest
+
+import (
+	"context"
+	"errors"
+
+	"xdd/internal/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// UseInMemoryExporter installs a provider that records spans synchronously
+// in memory. The returned function restores the previous provider.
+func UseInMemoryExporter() (*tracetest.InMemoryExporter, func()) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", telemetry.InstrumentationName))),
+	)
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	return exporter, func() {
+		// Shutting down a syncer only stops further exports
+		if err := provider.Shutdown(context.Background()); err != nil && !errors.Is(err, context.Canceled) {
+			otel.Handle(err)
+		}
+		otel.SetTracerProvider(previous)
+	}
+}
@@ -0,0 +1,38 @@
+package telemetrytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"xdd/internal/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseInMemoryExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	exporter, restore := UseInMemoryExporter()
+
+	ctx, parent := telemetry.Start(context.Background(), "parent", telemetry.AttrTask.String("metadata"))
+	_, child := telemetry.Start(ctx, "child")
+	telemetry.End(child, errors.New("boom"))
+	telemetry.End(parent, nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "boom", spans[0].Status.Description)
+	require.Len(t, spans[0].Events, 1, "error recorded as a span event")
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	assert.Contains(t, spans[1].Attributes, telemetry.AttrTask.String("metadata"))
+
+	restore()
+	assert.Equal(t, previous, otel.GetTracerProvider())
+}
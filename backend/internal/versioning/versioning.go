@@ -0,0 +1,170 @@
+// Package versioning computes semantic version bumps from changelog events.
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"xdd/pkg/schema"
+)
+
+// BumpType is a semantic version increment.
+type BumpType string
+
+const (
+	BumpMajor BumpType = "major"
+	BumpMinor BumpType = "minor"
+	BumpPatch BumpType = "patch"
+)
+
+// rank orders bump types so the largest one in a change set wins.
+var rank = map[BumpType]int{
+	BumpPatch: 1,
+	BumpMinor: 2,
+	BumpMajor: 3,
+}
+
+// InitialVersion is the version a specification starts from before its first bump.
+const InitialVersion = "0.0.0"
+
+var semverRegex = regexp.MustCompile(`^([0-9]+)\.([0-9]+)\.([0-9]+)$`)
+
+// Policy maps each kind of change to the bump it requires.
+type Policy struct {
+	Removal  BumpType `yaml:"removal,omitempty"`  // Requirement deleted (default: major)
+	Addition BumpType `yaml:"addition,omitempty"` // Requirement added (default: minor)
+	Metadata BumpType `yaml:"metadata,omitempty"` // Project name or description changed (default: patch)
+	Category BumpType `yaml:"category,omitempty"` // Category added, deleted, renamed or merged (default: patch)
+}
+
+// DefaultPolicy returns the standard policy: removals are breaking,
+// additions are features, everything else is a patch.
+func DefaultPolicy() Policy {
+	return Policy{
+		Removal:  BumpMajor,
+		Addition: BumpMinor,
+		Metadata: BumpPatch,
+		Category: BumpPatch,
+	}
+}
+
+// SetDefaults fills in default values for unset fields.
+func (p *Policy) SetDefaults() {
+	defaults := DefaultPolicy()
+	if p.Removal == "" {
+		p.Removal = defaults.Removal
+	}
+	if p.Addition == "" {
+		p.Addition = defaults.Addition
+	}
+	if p.Metadata == "" {
+		p.Metadata = defaults.Metadata
+	}
+	if p.Category == "" {
+		p.Category = defaults.Category
+	}
+}
+
+// Validate checks every set field names a known bump type.
+func (p Policy) Validate() error {
+	fields := []struct {
+		name string
+		bump BumpType
+	}{
+		{"removal", p.Removal},
+		{"addition", p.Addition},
+		{"metadata", p.Metadata},
+		{"category", p.Category},
+	}
+	for _, f := range fields {
+		if _, ok := rank[f.bump]; f.bump != "" && !ok {
+			return fmt.Errorf("%s must be %s|%s|%s, got %q", f.name, BumpMajor, BumpMinor, BumpPatch, f.bump)
+		}
+	}
+	return nil
+}
+
+// Bump is the version change required by a set of events.
+type Bump struct {
+	Type       BumpType
+	OldVersion string
+	NewVersion string
+	Rules      []string // Policy rules that applied, e.g. "2 requirement(s) removed: major"
+}
+
+// Compute applies policy to events and returns the bump from current.
+// The largest bump any event requires wins; a change set that matches no
+// rule is a patch. An empty current version counts as InitialVersion.
+func Compute(policy Policy, current string, events []schema.ChangelogEvent) (*Bump, error) {
+	policy.SetDefaults()
+
+	var removed, added, metadata, categories int
+	for _, event := range events {
+		switch event.(type) {
+		case *schema.RequirementDeleted:
+			removed++
+		case *schema.RequirementAdded:
+			added++
+		case *schema.ProjectMetadataUpdated:
+			metadata++
+		case *schema.CategoryAdded, *schema.CategoryDeleted, *schema.CategoryRenamed, *schema.CategoryMerged:
+			categories++
+		}
+	}
+
+	bump := &Bump{Type: BumpPatch, OldVersion: current}
+	apply := func(count int, what string, t BumpType) {
+		if count == 0 {
+			return
+		}
+		bump.Rules = append(bump.Rules, fmt.Sprintf("%d %s: %s", count, what, t))
+		if rank[t] > rank[bump.Type] {
+			bump.Type = t
+		}
+	}
+	apply(removed, "requirement(s) removed", policy.Removal)
+	apply(added, "requirement(s) added", policy.Addition)
+	apply(metadata, "metadata change(s)", policy.Metadata)
+	apply(categories, "category change(s)", policy.Category)
+
+	next, err := Increment(current, bump.Type)
+	if err != nil {
+		return nil, err
+	}
+	bump.NewVersion = next
+	return bump, nil
+}
+
+// Increment returns version bumped by t, resetting lower components.
+func Increment(version string, t BumpType) (string, error) {
+	if version == "" {
+		version = InitialVersion
+	}
+	m := semverRegex.FindStringSubmatch(version)
+	if m == nil {
+		return "", fmt.Errorf("version must be valid semver (X.Y.Z), got %q", version)
+	}
+
+	// The regex guarantees digits; only overflow can fail
+	parts := make([]int, 3)
+	for i := range parts {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return "", fmt.Errorf("version %q: %w", version, err)
+		}
+		parts[i] = n
+	}
+
+	switch t {
+	case BumpMajor:
+		parts = []int{parts[0] + 1, 0, 0}
+	case BumpMinor:
+		parts = []int{parts[0], parts[1] + 1, 0}
+	case BumpPatch:
+		parts[2]++
+	default:
+		return "", fmt.Errorf("unknown bump type %q", t)
+	}
+	return fmt.Sprintf("%d.%d.%d", parts[0], parts[1], parts[2]), nil
+}
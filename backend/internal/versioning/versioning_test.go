@@ -0,0 +1,125 @@
+package versioning
+
+import (
+	"testing"
+
+	"xdd/pkg/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		current string
+		events  []schema.ChangelogEvent
+		want    BumpType
+		version string
+	}{
+		{
+			name:    "removal is major",
+			current: "1.4.2",
+			events:  []schema.ChangelogEvent{&schema.RequirementDeleted{}, &schema.RequirementAdded{}},
+			want:    BumpMajor,
+			version: "2.0.0",
+		},
+		{
+			name:    "addition is minor",
+			current: "1.4.2",
+			events:  []schema.ChangelogEvent{&schema.RequirementAdded{}, &schema.CategoryAdded{}},
+			want:    BumpMinor,
+			version: "1.5.0",
+		},
+		{
+			name:    "metadata only is patch",
+			current: "1.4.2",
+			events:  []schema.ChangelogEvent{&schema.ProjectMetadataUpdated{}},
+			want:    BumpPatch,
+			version: "1.4.3",
+		},
+		{
+			name:    "category moves follow the category policy",
+			policy:  Policy{Category: BumpMinor},
+			current: "1.4.2",
+			events:  []schema.ChangelogEvent{&schema.CategoryRenamed{}, &schema.CategoryMerged{}},
+			want:    BumpMinor,
+			version: "1.5.0",
+		},
+		{
+			name:    "no rule matched is patch",
+			current: "1.4.2",
+			events:  []schema.ChangelogEvent{&schema.ConflictOverridden{}},
+			want:    BumpPatch,
+			version: "1.4.3",
+		},
+		{
+			name:    "new project starts from 0.0.0",
+			events:  []schema.ChangelogEvent{&schema.RequirementAdded{}},
+			want:    BumpMinor,
+			version: "0.1.0",
+		},
+		{
+			name:    "custom policy",
+			policy:  Policy{Removal: BumpMinor, Metadata: BumpMinor},
+			current: "0.3.1",
+			events:  []schema.ChangelogEvent{&schema.RequirementDeleted{}, &schema.ProjectMetadataUpdated{}},
+			want:    BumpMinor,
+			version: "0.4.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bump, err := Compute(tt.policy, tt.current, tt.events)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, bump.Type)
+			assert.Equal(t, tt.version, bump.NewVersion)
+			assert.Equal(t, tt.current, bump.OldVersion)
+		})
+	}
+}
+
+func TestCompute_Rules(t *testing.T) {
+	bump, err := Compute(DefaultPolicy(), "0.1.0", []schema.ChangelogEvent{
+		&schema.RequirementDeleted{},
+		&schema.RequirementDeleted{},
+		&schema.RequirementAdded{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2 requirement(s) removed: major", "1 requirement(s) added: minor"}, bump.Rules)
+}
+
+func TestCompute_InvalidVersion(t *testing.T) {
+	_, err := Compute(DefaultPolicy(), "v1.0", nil)
+	assert.ErrorContains(t, err, "valid semver")
+}
+
+func TestIncrement(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    BumpType
+		want    string
+	}{
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"0.9.9", BumpMinor, "0.10.0"},
+		{"", BumpPatch, "0.0.1"},
+	}
+	for _, tt := range tests {
+		got, err := Increment(tt.version, tt.bump)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s + %s", tt.version, tt.bump)
+	}
+
+	_, err := Increment("1.2.3", "breaking")
+	assert.Error(t, err)
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	assert.NoError(t, Policy{}.Validate())
+	assert.NoError(t, DefaultPolicy().Validate())
+	assert.ErrorContains(t, Policy{Addition: "huge"}.Validate(), "addition")
+}
@@ -1,22 +1,28 @@
 package schema
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // AcceptanceCriterion is the interface for all acceptance criteria types.
 type AcceptanceCriterion interface {
 	GetID() string
 	GetType() string
 	GetCreatedAt() time.Time
+	GetVerification() Verification
 }
 
 // BehavioralCriterion represents a Given/When/Then acceptance criterion.
 type BehavioralCriterion struct {
-	ID        string    `json:"id" yaml:"id"`
-	Type      string    `json:"type" yaml:"type"` // "behavioral"
-	Given     string    `json:"given" yaml:"given" jsonschema:"maxLength=200"`
-	When      string    `json:"when" yaml:"when" jsonschema:"maxLength=200"`
-	Then      string    `json:"then" yaml:"then" jsonschema:"maxLength=200"`
-	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	ID           string `json:"id" yaml:"id"`
+	Type         string `json:"type" yaml:"type"` // "behavioral"
+	Given        string `json:"given" yaml:"given" jsonschema:"maxLength=200"`
+	When         string `json:"when" yaml:"when" jsonschema:"maxLength=200"`
+	Then         string `json:"then" yaml:"then" jsonschema:"maxLength=200"`
+	Verification `yaml:",inline"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
 }
 
 // Implement AcceptanceCriterion interface.
@@ -26,13 +32,58 @@ func (b *BehavioralCriterion) GetCreatedAt() time.Time { return b.CreatedAt }
 
 // AssertionCriterion represents a single testable assertion.
 type AssertionCriterion struct {
-	ID        string    `json:"id" yaml:"id"`
-	Type      string    `json:"type" yaml:"type"` // "assertion"
-	Statement string    `json:"statement" yaml:"statement" jsonschema:"maxLength=200"`
-	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	ID           string `json:"id" yaml:"id"`
+	Type         string `json:"type" yaml:"type"` // "assertion"
+	Statement    string `json:"statement" yaml:"statement" jsonschema:"maxLength=200"`
+	Verification `yaml:",inline"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
 }
 
 // Implement AcceptanceCriterion interface.
 func (a *AssertionCriterion) GetID() string           { return a.ID }
 func (a *AssertionCriterion) GetType() string         { return a.Type }
 func (a *AssertionCriterion) GetCreatedAt() time.Time { return a.CreatedAt }
+
+// Metric comparators.
+const (
+	ComparatorLess         = "<"
+	ComparatorLessEqual    = "<="
+	ComparatorGreater      = ">"
+	ComparatorGreaterEqual = ">="
+	ComparatorEqual        = "=="
+)
+
+// Comparators lists the comparators a metric criterion may use.
+var Comparators = []string{ComparatorLess, ComparatorLessEqual, ComparatorGreater, ComparatorGreaterEqual, ComparatorEqual}
+
+// MetricCriterion represents a measurable target, e.g. p95 latency < 200 ms
+// under 1k rps.
+type MetricCriterion struct {
+	ID           string  `json:"id" yaml:"id"`
+	Type         string  `json:"type" yaml:"type"`                                  // "metric"
+	Measure      string  `json:"measure" yaml:"measure" jsonschema:"maxLength=100"` // e.g. "p95 latency"
+	Comparator   string  `json:"comparator" yaml:"comparator" jsonschema:"enum=<,enum=<=,enum=>,enum=>=,enum=="`
+	Threshold    float64 `json:"threshold" yaml:"threshold"`
+	Unit         string  `json:"unit,omitempty" yaml:"unit,omitempty" jsonschema:"maxLength=20"`              // e.g. "ms"
+	Conditions   string  `json:"conditions,omitempty" yaml:"conditions,omitempty" jsonschema:"maxLength=200"` // e.g. "under 1k rps"
+	Verification `yaml:",inline"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// Implement AcceptanceCriterion interface.
+func (m *MetricCriterion) GetID() string           { return m.ID }
+func (m *MetricCriterion) GetType() string         { return m.Type }
+func (m *MetricCriterion) GetCreatedAt() time.Time { return m.CreatedAt }
+
+// Expression renders the target as one line, e.g.
+// "p95 latency < 200 ms under 1k rps".
+func (m *MetricCriterion) Expression() string {
+	parts := []string{m.Measure, m.Comparator, strconv.FormatFloat(m.Threshold, 'f', -1, 64)}
+	if m.Unit != "" {
+		parts = append(parts, m.Unit)
+	}
+	if m.Conditions != "" {
+		parts = append(parts, m.Conditions)
+	}
+	return strings.Join(parts, " ")
+}
@@ -20,6 +20,9 @@ const (
 	PriorityLow      Priority = "low"      // Nice to have
 )
 
+// Priorities lists the priority levels, most urgent first.
+var Priorities = []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow}
+
 // ValidationLimits defines the constraints for various fields.
 const (
 	RequirementDescriptionMin = 10
@@ -30,10 +33,21 @@ const (
 	MetadataNameMax           = 100
 	MetadataDescriptionMin    = 10
 	MetadataDescriptionMax    = 1000
-	CategoryNameMin           = 1
-	CategoryNameMax           = 20
+	CategoryNameMin           = 1  // Per path segment
+	CategoryNameMax           = 20 // Per path segment
+	CategoryDepthMax          = 4
+	CategoryPathMax           = CategoryDepthMax*(CategoryNameMax+1) - 1 // Whole path, separators included
 	AcceptanceCriterionMin    = 1
 	AcceptanceCriterionMax    = 10
 	GivenWhenThenMax          = 200
 	AssertionStatementMax     = 200
+	MetricMeasureMax          = 100
+	MetricUnitMax             = 20
+	MetricConditionsMax       = 200
+	ExamplesColumnsMax        = 10
+	ExamplesRowsMax           = 20
+	ExampleValueMax           = 100
+	VerificationRefMax        = 200
+	TagMax                    = 40 // Also bounds custom field names
+	FieldValueMax             = 100
 )
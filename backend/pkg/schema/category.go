@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Category groups related requirements. Description records what the
+// category covers so it keeps the same meaning across sessions.
+type Category struct {
+	Name        string    `json:"name" yaml:"name" jsonschema:"minLength=1,maxLength=83,pattern=^[A-Z0-9_]+(/[A-Z0-9_]+)*$"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string    `json:"owner,omitempty" yaml:"owner,omitempty"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// UnmarshalYAML accepts either a category mapping or a bare name, the form
+// older specifications stored categories in.
+func (c *Category) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*c = Category{}
+		return node.Decode(&c.Name)
+	}
+
+	type categoryAlias Category
+	var temp categoryAlias
+	if err := node.Decode(&temp); err != nil {
+		return err
+	}
+	*c = Category(temp)
+	return nil
+}
+
+// CategorySeparator joins the segments of a nested category path, e.g. AUTH/SSO.
+const CategorySeparator = "/"
+
+// ValidateCategoryPath checks a category path has 1-CategoryDepthMax
+// segments of CategoryNameMin-CategoryNameMax characters each. Segments are
+// limited to upper-case letters, digits and underscores, so no two paths
+// share the ID segment and alias key they map to (AUTH/SSO and AUTH.SSO
+// would both give AUTH.SSO).
+func ValidateCategoryPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("category is required")
+	}
+	segments := strings.Split(path, CategorySeparator)
+	if len(segments) > CategoryDepthMax {
+		return fmt.Errorf("category %s is nested %d levels deep, at most %d allowed", path, len(segments), CategoryDepthMax)
+	}
+	for _, seg := range segments {
+		if len(seg) < CategoryNameMin || len(seg) > CategoryNameMax {
+			return fmt.Errorf("category %s: each segment must be %d-%d characters, got %q", path, CategoryNameMin, CategoryNameMax, seg)
+		}
+		if strings.IndexFunc(seg, func(r rune) bool { return !isCategoryChar(r) }) >= 0 {
+			return fmt.Errorf("category %s: segment %q may only contain A-Z, 0-9 and _", path, seg)
+		}
+	}
+	return nil
+}
+
+// isCategoryChar reports whether r may appear in a category path segment.
+func isCategoryChar(r rune) bool {
+	return (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
+
+// CategoryParent returns the parent of a category path, or "" for a
+// top-level category.
+func CategoryParent(path string) string {
+	i := strings.LastIndex(path, CategorySeparator)
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+// CategoryAncestors returns the ancestors of a category path, outermost
+// first: AUTH/SSO/SAML yields AUTH and AUTH/SSO.
+func CategoryAncestors(path string) []string {
+	var ancestors []string
+	for i, r := range path {
+		if string(r) == CategorySeparator {
+			ancestors = append(ancestors, path[:i])
+		}
+	}
+	return ancestors
+}
+
+// CategoryDepth returns the number of segments in a category path.
+func CategoryDepth(path string) int {
+	return strings.Count(path, CategorySeparator) + 1
+}
+
+// CategoryWithin reports whether path is ancestor or one of its descendants.
+func CategoryWithin(path, ancestor string) bool {
+	return path == ancestor || strings.HasPrefix(path, ancestor+CategorySeparator)
+}
+
+// RebaseCategory moves path from under from to under to: with from AUTH
+// and to SECURITY, AUTH/SSO becomes SECURITY/SSO. Paths outside from are
+// returned unchanged with ok false.
+func RebaseCategory(path, from, to string) (string, bool) {
+	if !CategoryWithin(path, from) {
+		return path, false
+	}
+	return to + strings.TrimPrefix(path, from), true
+}
@@ -57,11 +57,19 @@ func (e *AcceptanceCriterionDeleted) EventType() string    { return "AcceptanceC
 func (e *AcceptanceCriterionDeleted) EventID() string      { return e.EventID_ }
 func (e *AcceptanceCriterionDeleted) Timestamp() time.Time { return e.Timestamp_ }
 
-// CategoryAdded represents a category addition event.
+// CategoryAdded represents a category addition event. The category's
+// CreatedAt is the event timestamp.
 type CategoryAdded struct {
-	EventID_   string    `json:"event_id" yaml:"event_id"`
-	Name       string    `json:"name" yaml:"name"`
-	Timestamp_ time.Time `json:"timestamp" yaml:"timestamp"`
+	EventID_    string    `json:"event_id" yaml:"event_id"`
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string    `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Timestamp_  time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+// Category returns the category this event declares.
+func (e *CategoryAdded) Category() Category {
+	return Category{Name: e.Name, Description: e.Description, Owner: e.Owner, CreatedAt: e.Timestamp_}
 }
 
 func (e *CategoryAdded) EventType() string    { return "CategoryAdded" }
@@ -79,18 +87,120 @@ func (e *CategoryDeleted) EventType() string    { return "CategoryDeleted" }
 func (e *CategoryDeleted) EventID() string      { return e.EventID_ }
 func (e *CategoryDeleted) Timestamp() time.Time { return e.Timestamp_ }
 
-// CategoryRenamed represents a category rename event.
+// CategoryRenamed represents a category rename event. RequirementIDs maps
+// old to new IDs for requirements whose ID carries the category name.
 type CategoryRenamed struct {
-	EventID_   string    `json:"event_id" yaml:"event_id"`
-	OldName    string    `json:"old_name" yaml:"old_name"`
-	NewName    string    `json:"new_name" yaml:"new_name"`
-	Timestamp_ time.Time `json:"timestamp" yaml:"timestamp"`
+	EventID_       string            `json:"event_id" yaml:"event_id"`
+	OldName        string            `json:"old_name" yaml:"old_name"`
+	NewName        string            `json:"new_name" yaml:"new_name"`
+	RequirementIDs map[string]string `json:"requirement_ids,omitempty" yaml:"requirement_ids,omitempty"`
+	Timestamp_     time.Time         `json:"timestamp" yaml:"timestamp"`
 }
 
 func (e *CategoryRenamed) EventType() string    { return "CategoryRenamed" }
 func (e *CategoryRenamed) EventID() string      { return e.EventID_ }
 func (e *CategoryRenamed) Timestamp() time.Time { return e.Timestamp_ }
 
+// CategoryMerged represents moving every requirement of Source into the
+// existing Target category and dropping Source.
+type CategoryMerged struct {
+	EventID_       string            `json:"event_id" yaml:"event_id"`
+	Source         string            `json:"source" yaml:"source"`
+	Target         string            `json:"target" yaml:"target"`
+	RequirementIDs map[string]string `json:"requirement_ids,omitempty" yaml:"requirement_ids,omitempty"`
+	Timestamp_     time.Time         `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *CategoryMerged) EventType() string    { return "CategoryMerged" }
+func (e *CategoryMerged) EventID() string      { return e.EventID_ }
+func (e *CategoryMerged) Timestamp() time.Time { return e.Timestamp_ }
+
+// RequirementLinkAdded links RequirementID to another requirement.
+type RequirementLinkAdded struct {
+	EventID_      string          `json:"event_id" yaml:"event_id"`
+	RequirementID string          `json:"requirement_id" yaml:"requirement_id"`
+	Link          RequirementLink `json:"link" yaml:"link"`
+	Timestamp_    time.Time       `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *RequirementLinkAdded) EventType() string    { return "RequirementLinkAdded" }
+func (e *RequirementLinkAdded) EventID() string      { return e.EventID_ }
+func (e *RequirementLinkAdded) Timestamp() time.Time { return e.Timestamp_ }
+
+// RequirementLinkRemoved drops a link held by RequirementID.
+type RequirementLinkRemoved struct {
+	EventID_      string          `json:"event_id" yaml:"event_id"`
+	RequirementID string          `json:"requirement_id" yaml:"requirement_id"`
+	Link          RequirementLink `json:"link" yaml:"link"`
+	Timestamp_    time.Time       `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *RequirementLinkRemoved) EventType() string    { return "RequirementLinkRemoved" }
+func (e *RequirementLinkRemoved) EventID() string      { return e.EventID_ }
+func (e *RequirementLinkRemoved) Timestamp() time.Time { return e.Timestamp_ }
+
+// RequirementStatusChanged moves a requirement along its lifecycle. Actor
+// is who made the change and Comment why.
+type RequirementStatusChanged struct {
+	EventID_      string    `json:"event_id" yaml:"event_id"`
+	RequirementID string    `json:"requirement_id" yaml:"requirement_id"`
+	From          Status    `json:"from" yaml:"from"`
+	To            Status    `json:"to" yaml:"to"`
+	Actor         string    `json:"actor" yaml:"actor"`
+	Comment       string    `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Timestamp_    time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *RequirementStatusChanged) EventType() string    { return "RequirementStatusChanged" }
+func (e *RequirementStatusChanged) EventID() string      { return e.EventID_ }
+func (e *RequirementStatusChanged) Timestamp() time.Time { return e.Timestamp_ }
+
+// RequirementTagsChanged adds and removes tags on a requirement.
+type RequirementTagsChanged struct {
+	EventID_      string    `json:"event_id" yaml:"event_id"`
+	RequirementID string    `json:"requirement_id" yaml:"requirement_id"`
+	Added         []string  `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed       []string  `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Timestamp_    time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *RequirementTagsChanged) EventType() string    { return "RequirementTagsChanged" }
+func (e *RequirementTagsChanged) EventID() string      { return e.EventID_ }
+func (e *RequirementTagsChanged) Timestamp() time.Time { return e.Timestamp_ }
+
+// RequirementFieldSet sets a custom field on a requirement; an empty Value
+// clears it. OldValue is a snapshot of the previous value.
+type RequirementFieldSet struct {
+	EventID_      string    `json:"event_id" yaml:"event_id"`
+	RequirementID string    `json:"requirement_id" yaml:"requirement_id"`
+	Name          string    `json:"name" yaml:"name"`
+	Value         string    `json:"value,omitempty" yaml:"value,omitempty"`
+	OldValue      string    `json:"old_value,omitempty" yaml:"old_value,omitempty"`
+	Timestamp_    time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *RequirementFieldSet) EventType() string    { return "RequirementFieldSet" }
+func (e *RequirementFieldSet) EventID() string      { return e.EventID_ }
+func (e *RequirementFieldSet) Timestamp() time.Time { return e.Timestamp_ }
+
+// AcceptanceCriterionVerificationSet records how an acceptance criterion is
+// verified; an empty Verification clears it. OldVerification is a snapshot
+// of the previous one.
+type AcceptanceCriterionVerificationSet struct {
+	EventID_        string       `json:"event_id" yaml:"event_id"`
+	RequirementID   string       `json:"requirement_id" yaml:"requirement_id"`
+	CriterionID     string       `json:"criterion_id" yaml:"criterion_id"`
+	Verification    Verification `json:"verification" yaml:"verification"`
+	OldVerification Verification `json:"old_verification" yaml:"old_verification"`
+	Timestamp_      time.Time    `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *AcceptanceCriterionVerificationSet) EventType() string {
+	return "AcceptanceCriterionVerificationSet"
+}
+func (e *AcceptanceCriterionVerificationSet) EventID() string      { return e.EventID_ }
+func (e *AcceptanceCriterionVerificationSet) Timestamp() time.Time { return e.Timestamp_ }
+
 // ProjectMetadataUpdated represents a metadata update event.
 type ProjectMetadataUpdated struct {
 	EventID_    string          `json:"event_id" yaml:"event_id"`
@@ -117,6 +227,27 @@ func (e *VersionBumped) EventType() string    { return "VersionBumped" }
 func (e *VersionBumped) EventID() string      { return e.EventID_ }
 func (e *VersionBumped) Timestamp() time.Time { return e.Timestamp_ }
 
+// ConflictOverridden records that a change was committed despite critical
+// conflicts. It does not alter the specification on replay.
+type ConflictOverridden struct {
+	EventID_   string           `json:"event_id" yaml:"event_id"`
+	Conflicts  []ConflictRecord `json:"conflicts" yaml:"conflicts"`
+	Reason     string           `json:"reason" yaml:"reason"`
+	Timestamp_ time.Time        `json:"timestamp" yaml:"timestamp"`
+}
+
+func (e *ConflictOverridden) EventType() string    { return "ConflictOverridden" }
+func (e *ConflictOverridden) EventID() string      { return e.EventID_ }
+func (e *ConflictOverridden) Timestamp() time.Time { return e.Timestamp_ }
+
+// ConflictRecord is a pair of contradicting requirements.
+type ConflictRecord struct {
+	RequirementA string `json:"requirement_a" yaml:"requirement_a"`
+	RequirementB string `json:"requirement_b" yaml:"requirement_b"`
+	Severity     string `json:"severity" yaml:"severity"`
+	Explanation  string `json:"explanation" yaml:"explanation"`
+}
+
 // Changelog represents the event log document.
 type Changelog struct {
 	Version             string           `json:"version" yaml:"version"`
@@ -0,0 +1,212 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the type of a project-declared custom field.
+type FieldType string
+
+const (
+	FieldString FieldType = "string"
+	FieldEnum   FieldType = "enum" // One of FieldDefinition.Values
+	FieldInt    FieldType = "int"
+	FieldDate   FieldType = "date" // YYYY-MM-DD
+)
+
+// FieldDateLayout is the format date field values are stored in.
+const FieldDateLayout = "2006-01-02"
+
+// FieldDefinition declares a custom field requirements may carry, e.g. a
+// compliance regime or target release.
+type FieldDefinition struct {
+	Name        string    `json:"name" yaml:"name"`
+	Type        FieldType `json:"type" yaml:"type"`
+	Values      []string  `json:"values,omitempty" yaml:"values,omitempty"` // Allowed values of an enum
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Validate checks the definition itself.
+func (d FieldDefinition) Validate() error {
+	if err := validateAnnotation("field name", d.Name); err != nil {
+		return err
+	}
+	switch d.Type {
+	case FieldString, FieldInt, FieldDate:
+		if len(d.Values) > 0 {
+			return fmt.Errorf("field %s: values are only allowed for enum fields", d.Name)
+		}
+	case FieldEnum:
+		if len(d.Values) == 0 {
+			return fmt.Errorf("field %s: enum fields need values", d.Name)
+		}
+	default:
+		return fmt.Errorf("field %s: unknown type %q, must be string, enum, int or date", d.Name, d.Type)
+	}
+	return nil
+}
+
+// Check validates value against the field's type.
+func (d FieldDefinition) Check(value string) error {
+	if value == "" {
+		return fmt.Errorf("field %s: value is required", d.Name)
+	}
+	if len(value) > FieldValueMax {
+		return fmt.Errorf("field %s: value must be at most %d characters", d.Name, FieldValueMax)
+	}
+	switch d.Type {
+	case FieldEnum:
+		if !slices.Contains(d.Values, value) {
+			return fmt.Errorf("field %s: %q is not one of %s", d.Name, value, strings.Join(d.Values, ", "))
+		}
+	case FieldInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("field %s: %q is not an integer", d.Name, value)
+		}
+	case FieldDate:
+		if _, err := time.Parse(FieldDateLayout, value); err != nil {
+			return fmt.Errorf("field %s: %q is not a date (YYYY-MM-DD)", d.Name, value)
+		}
+	}
+	return nil
+}
+
+// FindField returns the definition called name, or nil.
+func FindField(defs []FieldDefinition, name string) *FieldDefinition {
+	for i := range defs {
+		if defs[i].Name == name {
+			return &defs[i]
+		}
+	}
+	return nil
+}
+
+// ValidateTag checks a tag is TagMax characters at most, with no spaces,
+// commas or '=' so it survives command-line filters.
+func ValidateTag(tag string) error {
+	return validateAnnotation("tag", tag)
+}
+
+func validateAnnotation(what, s string) error {
+	if s == "" {
+		return fmt.Errorf("%s is required", what)
+	}
+	if len(s) > TagMax {
+		return fmt.Errorf("%s %q must be at most %d characters", what, s, TagMax)
+	}
+	if strings.ContainsAny(s, " \t\n,=") {
+		return fmt.Errorf("%s %q must not contain spaces, commas or '='", what, s)
+	}
+	return nil
+}
+
+// HasTag reports whether the requirement carries tag.
+func (r *Requirement) HasTag(tag string) bool {
+	return slices.Contains(r.Tags, tag)
+}
+
+// ChangeTags adds and removes tags on requirement id. Added tags must be
+// new, and given once, and removed ones present.
+func (s *Specification) ChangeTags(id string, added, removed []string) error {
+	i := s.requirementIndex(id)
+	if i < 0 {
+		return fmt.Errorf("requirement %s not found", id)
+	}
+	req := &s.Requirements[i]
+	for _, tag := range removed {
+		if !req.HasTag(tag) {
+			return fmt.Errorf("requirement %s is not tagged %s", id, tag)
+		}
+	}
+	for i, tag := range added {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+		if slices.Contains(added[:i], tag) {
+			return fmt.Errorf("tag %s given twice", tag)
+		}
+		if req.HasTag(tag) && !slices.Contains(removed, tag) {
+			return fmt.Errorf("requirement %s is already tagged %s", id, tag)
+		}
+	}
+
+	tags := slices.DeleteFunc(slices.Clone(req.Tags), func(t string) bool { return slices.Contains(removed, t) })
+	req.Tags = append(tags, added...)
+	if len(req.Tags) == 0 {
+		req.Tags = nil
+	}
+	return nil
+}
+
+// SetField sets custom field name on requirement id, clearing it when
+// value is empty, and returns the previous value.
+func (s *Specification) SetField(id, name, value string) (string, error) {
+	if err := validateAnnotation("field name", name); err != nil {
+		return "", err
+	}
+	i := s.requirementIndex(id)
+	if i < 0 {
+		return "", fmt.Errorf("requirement %s not found", id)
+	}
+	req := &s.Requirements[i]
+	old, ok := req.Fields[name]
+	req.Fields = maps.Clone(req.Fields) // May be shared with the event that added the requirement
+	if value == "" {
+		if !ok {
+			return "", fmt.Errorf("requirement %s has no field %s", id, name)
+		}
+		delete(req.Fields, name)
+		if len(req.Fields) == 0 {
+			req.Fields = nil
+		}
+		return old, nil
+	}
+	if req.Fields == nil {
+		req.Fields = make(map[string]string)
+	}
+	req.Fields[name] = value
+	return old, nil
+}
+
+// RequirementFilter selects requirements by status, tags and custom
+// fields. Empty criteria match everything; a requirement must match all
+// the criteria given.
+type RequirementFilter struct {
+	Statuses []Status          // Any of these
+	Tags     []string          // All of these
+	Fields   map[string]string // Exactly these values
+}
+
+// Match reports whether req passes the filter.
+func (f RequirementFilter) Match(req *Requirement) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, req.CurrentStatus()) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !req.HasTag(tag) {
+			return false
+		}
+	}
+	for name, value := range f.Fields {
+		if req.Fields[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the requirements matching f.
+func (s *Specification) Filter(f RequirementFilter) []Requirement {
+	var matched []Requirement
+	for i := range s.Requirements {
+		if f.Match(&s.Requirements[i]) {
+			matched = append(matched, s.Requirements[i])
+		}
+	}
+	return matched
+}
@@ -8,12 +8,35 @@ import (
 )
 
 // NewRequirementID generates a new requirement ID in format REQ-{CATEGORY}-{nanoid(10)}.
+// Nested category segments are joined with dots: AUTH/SSO gives REQ-AUTH.SSO-{nanoid(10)}.
 func NewRequirementID(category string) (string, error) {
 	id, err := gonanoid.New(10)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("REQ-%s-%s", strings.ToUpper(category), id), nil
+	return fmt.Sprintf("REQ-%s-%s", categoryIDSegment(category), id), nil
+}
+
+// categoryIDSegment is the form a category path takes inside a requirement ID.
+func categoryIDSegment(category string) string {
+	return strings.ToUpper(strings.ReplaceAll(category, CategorySeparator, "."))
+}
+
+// RecategorizeRequirementID moves an ID in REQ-{CATEGORY}-{nanoid} format
+// from category from to category to. IDs that don't carry from are
+// returned unchanged with ok false.
+func RecategorizeRequirementID(id, from, to string) (newID string, ok bool) {
+	prefix := "REQ-" + categoryIDSegment(from) + "-"
+	if !strings.HasPrefix(id, prefix) {
+		return id, false
+	}
+	return "REQ-" + categoryIDSegment(to) + "-" + strings.TrimPrefix(id, prefix), true
+}
+
+// RequirementAlias formats the seq-th alias of a category, e.g. AUTH-014.
+// Nested segments are joined with dots like in IDs: AUTH.SSO-003.
+func RequirementAlias(category string, seq int) string {
+	return fmt.Sprintf("%s-%03d", categoryIDSegment(category), seq)
 }
 
 // NewAcceptanceCriterionID generates a new acceptance criterion ID in format AC-{nanoid(10)}.
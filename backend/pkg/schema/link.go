@@ -0,0 +1,136 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LinkType is the kind of relationship a requirement has to another.
+type LinkType string
+
+const (
+	LinkDependsOn     LinkType = "depends_on"     // Cannot be delivered before its target
+	LinkRefines       LinkType = "refines"        // Narrows or details its target
+	LinkConflictsWith LinkType = "conflicts_with" // Cannot hold together with its target
+	LinkSupersedes    LinkType = "supersedes"     // Replaces its target
+)
+
+// LinkTypes lists every link type in display order.
+var LinkTypes = []LinkType{LinkDependsOn, LinkRefines, LinkConflictsWith, LinkSupersedes}
+
+// Valid reports whether t is a known link type.
+func (t LinkType) Valid() bool {
+	return slices.Contains(LinkTypes, t)
+}
+
+// RequirementLink points from the requirement holding it to Target.
+type RequirementLink struct {
+	Type   LinkType `json:"type" yaml:"type" jsonschema:"enum=depends_on,enum=refines,enum=conflicts_with,enum=supersedes"`
+	Target string   `json:"target" yaml:"target"`
+}
+
+// LinkEdge is a link together with the requirement holding it.
+type LinkEdge struct {
+	Source string
+	Type   LinkType
+	Target string
+}
+
+// Links returns every link in the specification, in requirement order.
+func (s *Specification) Links() []LinkEdge {
+	var edges []LinkEdge
+	for _, req := range s.Requirements {
+		for _, link := range req.Links {
+			edges = append(edges, LinkEdge{Source: req.ID, Type: link.Type, Target: link.Target})
+		}
+	}
+	return edges
+}
+
+// LinksTo returns the links other requirements hold to id.
+func (s *Specification) LinksTo(id string) []LinkEdge {
+	var edges []LinkEdge
+	for _, edge := range s.Links() {
+		if edge.Target == id && edge.Source != id {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// AddLink links source to link.Target. Both requirements must exist, the
+// link must be new, and depends_on links may not form a cycle.
+func (s *Specification) AddLink(source string, link RequirementLink) error {
+	if !link.Type.Valid() {
+		return fmt.Errorf("invalid link type: %s", link.Type)
+	}
+	i := s.requirementIndex(source)
+	if i < 0 {
+		return fmt.Errorf("requirement %s not found", source)
+	}
+	if s.requirementIndex(link.Target) < 0 {
+		return fmt.Errorf("link target %s not found", link.Target)
+	}
+	if source == link.Target {
+		return fmt.Errorf("requirement %s cannot link to itself", source)
+	}
+	if slices.Contains(s.Requirements[i].Links, link) {
+		return fmt.Errorf("requirement %s already %s %s", source, link.Type, link.Target)
+	}
+	if link.Type == LinkDependsOn {
+		if path := s.dependencyPath(link.Target, source); path != nil {
+			return fmt.Errorf("depends_on cycle: %s -> %s", source, strings.Join(path, " -> "))
+		}
+	}
+	s.Requirements[i].Links = append(s.Requirements[i].Links, link)
+	return nil
+}
+
+// RemoveLink drops a link held by source.
+func (s *Specification) RemoveLink(source string, link RequirementLink) error {
+	i := s.requirementIndex(source)
+	if i < 0 {
+		return fmt.Errorf("requirement %s not found", source)
+	}
+	j := slices.Index(s.Requirements[i].Links, link)
+	if j < 0 {
+		return fmt.Errorf("requirement %s has no %s link to %s", source, link.Type, link.Target)
+	}
+	s.Requirements[i].Links = slices.Delete(s.Requirements[i].Links, j, j+1)
+	return nil
+}
+
+// dependencyPath returns the depends_on chain from from to to, both
+// included, or nil when to is not reachable.
+func (s *Specification) dependencyPath(from, to string) []string {
+	visited := make(map[string]bool)
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		i := s.requirementIndex(id)
+		if i < 0 {
+			return nil
+		}
+		for _, link := range s.Requirements[i].Links {
+			if link.Type != LinkDependsOn {
+				continue
+			}
+			if rest := walk(link.Target); rest != nil {
+				return append([]string{id}, rest...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+func (s *Specification) requirementIndex(id string) int {
+	return slices.IndexFunc(s.Requirements, func(r Requirement) bool { return r.ID == id })
+}
@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// placeholderPattern matches <name> in outline steps. Names start with a
+// letter so comparisons such as "< 200 ms" are left alone.
+var placeholderPattern = regexp.MustCompile(`<([A-Za-z][A-Za-z0-9_-]*)>`)
+
+// ExamplesTable holds the values an outline is run over, one row per
+// scenario.
+type ExamplesTable struct {
+	Columns []string   `json:"columns" yaml:"columns" jsonschema:"minItems=1,maxItems=10"`
+	Rows    [][]string `json:"rows" yaml:"rows" jsonschema:"minItems=1,maxItems=20"`
+}
+
+// String renders the table on one line, e.g. "role | code: admin | 200; guest | 403".
+func (e ExamplesTable) String() string {
+	rows := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		rows[i] = strings.Join(row, " | ")
+	}
+	return strings.Join(e.Columns, " | ") + ": " + strings.Join(rows, "; ")
+}
+
+// ScenarioOutlineCriterion is a Given/When/Then template run over an
+// examples table, like Gherkin's Scenario Outline. Steps refer to columns
+// as <name>.
+type ScenarioOutlineCriterion struct {
+	ID           string        `json:"id" yaml:"id"`
+	Type         string        `json:"type" yaml:"type"` // "scenario_outline"
+	Given        string        `json:"given" yaml:"given" jsonschema:"maxLength=200"`
+	When         string        `json:"when" yaml:"when" jsonschema:"maxLength=200"`
+	Then         string        `json:"then" yaml:"then" jsonschema:"maxLength=200"`
+	Examples     ExamplesTable `json:"examples" yaml:"examples"`
+	Verification `yaml:",inline"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// Implement AcceptanceCriterion interface.
+func (o *ScenarioOutlineCriterion) GetID() string           { return o.ID }
+func (o *ScenarioOutlineCriterion) GetType() string         { return o.Type }
+func (o *ScenarioOutlineCriterion) GetCreatedAt() time.Time { return o.CreatedAt }
+
+// Placeholders returns the names used in the steps, in order of first use.
+func (o *ScenarioOutlineCriterion) Placeholders() []string {
+	var names []string
+	for _, step := range []string{o.Given, o.When, o.Then} {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(step, -1) {
+			if !slices.Contains(names, m[1]) {
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// Expand returns one behavioral criterion per example row, with each
+// placeholder replaced by the row's value. Expanded criteria are numbered
+// after the outline, e.g. AC-abc.1, AC-abc.2, and share its verification.
+func (o *ScenarioOutlineCriterion) Expand() []BehavioralCriterion {
+	expanded := make([]BehavioralCriterion, 0, len(o.Examples.Rows))
+	for i, row := range o.Examples.Rows {
+		fill := func(step string) string {
+			return placeholderPattern.ReplaceAllStringFunc(step, func(m string) string {
+				col := slices.Index(o.Examples.Columns, m[1:len(m)-1])
+				if col < 0 || col >= len(row) {
+					return m
+				}
+				return row[col]
+			})
+		}
+		expanded = append(expanded, BehavioralCriterion{
+			ID:           o.ID + "." + strconv.Itoa(i+1),
+			Type:         "behavioral",
+			Given:        fill(o.Given),
+			When:         fill(o.When),
+			Then:         fill(o.Then),
+			Verification: o.Verification,
+			CreatedAt:    o.CreatedAt,
+		})
+	}
+	return expanded
+}
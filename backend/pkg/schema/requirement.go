@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -9,12 +10,17 @@ import (
 // Requirement represents a single requirement in the specification.
 type Requirement struct {
 	ID                 string                `json:"id" yaml:"id"`
+	Alias              string                `json:"alias,omitempty" yaml:"alias,omitempty"` // e.g. AUTH-014, assigned at replay
 	Type               EARSType              `json:"type" yaml:"type" jsonschema:"enum=ubiquitous,enum=event,enum=state,enum=optional"`
-	Category           string                `json:"category" yaml:"category" jsonschema:"minLength=1,maxLength=20"`
+	Category           string                `json:"category" yaml:"category" jsonschema:"minLength=1,maxLength=83,pattern=^[A-Z0-9_]+(/[A-Z0-9_]+)*$"`
 	Description        string                `json:"description" yaml:"description" jsonschema:"minLength=10,maxLength=500"`
 	Rationale          string                `json:"rationale" yaml:"rationale" jsonschema:"minLength=10,maxLength=500"`
 	AcceptanceCriteria []AcceptanceCriterion `json:"acceptance_criteria" yaml:"acceptance_criteria" jsonschema:"minItems=1,maxItems=10"`
 	Priority           Priority              `json:"priority" yaml:"priority" jsonschema:"enum=critical,enum=high,enum=medium,enum=low"`
+	Status             Status                `json:"status,omitempty" yaml:"status,omitempty" jsonschema:"enum=draft,enum=approved,enum=implemented,enum=verified,enum=deprecated"`
+	Links              []RequirementLink     `json:"links,omitempty" yaml:"links,omitempty"`
+	Tags               []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Fields             map[string]string     `json:"fields,omitempty" yaml:"fields,omitempty"` // Project-declared custom fields
 	CreatedAt          time.Time             `json:"created_at" yaml:"created_at"`
 }
 
@@ -22,14 +28,19 @@ type Requirement struct {
 func (r *Requirement) UnmarshalYAML(node *yaml.Node) error {
 	// Create a temporary struct with the same fields but AcceptanceCriteria as yaml.Node
 	type requirementAlias struct {
-		ID                 string      `yaml:"id"`
-		Type               EARSType    `yaml:"type"`
-		Category           string      `yaml:"category"`
-		Description        string      `yaml:"description"`
-		Rationale          string      `yaml:"rationale"`
-		AcceptanceCriteria []yaml.Node `yaml:"acceptance_criteria"`
-		Priority           Priority    `yaml:"priority"`
-		CreatedAt          time.Time   `yaml:"created_at"`
+		ID                 string            `yaml:"id"`
+		Alias              string            `yaml:"alias"`
+		Type               EARSType          `yaml:"type"`
+		Category           string            `yaml:"category"`
+		Description        string            `yaml:"description"`
+		Rationale          string            `yaml:"rationale"`
+		AcceptanceCriteria []yaml.Node       `yaml:"acceptance_criteria"`
+		Priority           Priority          `yaml:"priority"`
+		Status             Status            `yaml:"status"`
+		Links              []RequirementLink `yaml:"links"`
+		Tags               []string          `yaml:"tags"`
+		Fields             map[string]string `yaml:"fields"`
+		CreatedAt          time.Time         `yaml:"created_at"`
 	}
 
 	var temp requirementAlias
@@ -39,11 +50,16 @@ func (r *Requirement) UnmarshalYAML(node *yaml.Node) error {
 
 	// Copy simple fields
 	r.ID = temp.ID
+	r.Alias = temp.Alias
 	r.Type = temp.Type
 	r.Category = temp.Category
 	r.Description = temp.Description
 	r.Rationale = temp.Rationale
 	r.Priority = temp.Priority
+	r.Status = temp.Status
+	r.Links = temp.Links
+	r.Tags = temp.Tags
+	r.Fields = temp.Fields
 	r.CreatedAt = temp.CreatedAt
 
 	// Convert acceptance criteria nodes to typed objects
@@ -69,8 +85,61 @@ func (r *Requirement) UnmarshalYAML(node *yaml.Node) error {
 			if err := acNode.Decode(&ac); err == nil {
 				r.AcceptanceCriteria = append(r.AcceptanceCriteria, &ac)
 			}
+
+		case "metric":
+			var mc MetricCriterion
+			if err := acNode.Decode(&mc); err == nil {
+				r.AcceptanceCriteria = append(r.AcceptanceCriteria, &mc)
+			}
+
+		case "scenario_outline":
+			var oc ScenarioOutlineCriterion
+			if err := acNode.Decode(&oc); err == nil {
+				r.AcceptanceCriteria = append(r.AcceptanceCriteria, &oc)
+			}
 		}
 	}
 
 	return nil
 }
+
+// MentionedIn reports whether text names the requirement by ID or alias
+// (case-insensitive). An alias must stand alone, so AUTH-001 is not
+// mentioned by AUTH-0012 or AUTH-001.5, but is by "remove AUTH-001.".
+func (r *Requirement) MentionedIn(text string) bool {
+	upper := strings.ToUpper(text)
+	if r.ID != "" && strings.Contains(upper, strings.ToUpper(r.ID)) {
+		return true
+	}
+	if r.Alias == "" {
+		return false
+	}
+	alias := strings.ToUpper(r.Alias)
+	for start := 0; ; {
+		i := strings.Index(upper[start:], alias)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(alias)
+		if (i == 0 || !extendsAlias(upper, i-1, -1)) && (end == len(upper) || !extendsAlias(upper, end, 1)) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+// extendsAlias reports whether s[i], next to a match, would make it part of
+// a longer alias. A '.' only does when a segment character follows it in
+// direction dir, so sentence punctuation still ends an alias.
+func extendsAlias(s string, i, dir int) bool {
+	if s[i] != '.' {
+		return isSegmentChar(s[i]) || s[i] == '-'
+	}
+	next := i + dir
+	return next >= 0 && next < len(s) && isSegmentChar(s[next])
+}
+
+func isSegmentChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
@@ -2,6 +2,9 @@ package schema
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -184,7 +187,7 @@ func TestSpecificationWithMultipleRequirements(t *testing.T) {
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		},
-		Categories: []string{"AUTH", "TASKS"},
+		Categories: []Category{{Name: "AUTH"}, {Name: "TASKS"}},
 		Requirements: []Requirement{
 			{
 				ID:          "REQ-AUTH-1",
@@ -415,6 +418,40 @@ func TestValidation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid metric criterion",
+			validate: func() error {
+				return ValidateMetricCriterion(&MetricCriterion{
+					Type:       "metric",
+					Measure:    "p95 latency",
+					Comparator: ComparatorLess,
+					Threshold:  200,
+					Unit:       "ms",
+					Conditions: "under 1k rps",
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "metric criterion with unknown comparator",
+			validate: func() error {
+				return ValidateMetricCriterion(&MetricCriterion{
+					Type:       "metric",
+					Measure:    "availability",
+					Comparator: "~",
+					Threshold:  99.9,
+					Unit:       "%",
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "metric criterion without measure",
+			validate: func() error {
+				return ValidateMetricCriterion(&MetricCriterion{Type: "metric", Comparator: ComparatorLess, Threshold: 1})
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -426,3 +463,733 @@ func TestValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestRecategorizeRequirementID(t *testing.T) {
+	newID, ok := RecategorizeRequirementID("REQ-LOGIN-abc123", "login", "AUTH")
+	if !ok || newID != "REQ-AUTH-abc123" {
+		t.Errorf("Expected REQ-AUTH-abc123, got %q (ok=%v)", newID, ok)
+	}
+
+	if _, ok := RecategorizeRequirementID("REQ-AUTH-abc123", "LOGIN", "AUTH"); ok {
+		t.Error("ID without the old category prefix should not be rewritten")
+	}
+}
+
+func TestSpecificationRenameCategory(t *testing.T) {
+	spec := &Specification{
+		Categories: []Category{{Name: "LOGIN"}, {Name: "API"}},
+		Requirements: []Requirement{
+			{ID: "REQ-LOGIN-abc123", Category: "LOGIN"},
+			{ID: "REQ-API-def456", Category: "API"},
+		},
+	}
+
+	err := spec.RenameCategory("LOGIN", "AUTH", map[string]string{"REQ-LOGIN-abc123": "REQ-AUTH-abc123"})
+	if err != nil {
+		t.Fatalf("RenameCategory failed: %v", err)
+	}
+	if spec.Categories[0].Name != "AUTH" {
+		t.Errorf("Expected AUTH, got %s", spec.Categories[0].Name)
+	}
+	if spec.Requirements[0].ID != "REQ-AUTH-abc123" || spec.Requirements[0].Category != "AUTH" {
+		t.Errorf("Requirement not moved: %+v", spec.Requirements[0])
+	}
+
+	if err := spec.RenameCategory("AUTH", "API", nil); err == nil {
+		t.Error("Renaming onto an existing category should fail")
+	}
+	if err := spec.RenameCategory("MISSING", "OTHER", nil); err == nil {
+		t.Error("Renaming an unknown category should fail")
+	}
+}
+
+func TestSpecificationMergeCategory(t *testing.T) {
+	spec := &Specification{
+		Categories: []Category{{Name: "LOGIN"}, {Name: "AUTH"}},
+		Requirements: []Requirement{
+			{ID: "REQ-LOGIN-abc123", Category: "LOGIN"},
+			{ID: "REQ-AUTH-def456", Category: "AUTH"},
+		},
+	}
+
+	if err := spec.MergeCategory("LOGIN", "AUTH", map[string]string{"REQ-LOGIN-abc123": "REQ-AUTH-def456"}); err == nil {
+		t.Error("Merge should reject an ID that collides with an existing requirement")
+	}
+	if spec.Requirements[0].ID != "REQ-LOGIN-abc123" || len(spec.Categories) != 2 {
+		t.Error("Failed merge should leave the specification untouched")
+	}
+
+	err := spec.MergeCategory("LOGIN", "AUTH", map[string]string{"REQ-LOGIN-abc123": "REQ-AUTH-abc123"})
+	if err != nil {
+		t.Fatalf("MergeCategory failed: %v", err)
+	}
+	if len(spec.Categories) != 1 || spec.Categories[0].Name != "AUTH" {
+		t.Errorf("Expected only AUTH, got %v", spec.CategoryNames())
+	}
+	if spec.Requirements[0].ID != "REQ-AUTH-abc123" || spec.Requirements[0].Category != "AUTH" {
+		t.Errorf("Requirement not moved: %+v", spec.Requirements[0])
+	}
+
+	if err := spec.MergeCategory("AUTH", "MISSING", nil); err == nil {
+		t.Error("Merging into an unknown category should fail")
+	}
+}
+
+func TestCategoryYAML(t *testing.T) {
+	t.Run("legacy string list", func(t *testing.T) {
+		var spec Specification
+		if err := yaml.Unmarshal([]byte("categories:\n  - AUTH\n  - API\n"), &spec); err != nil {
+			t.Fatalf("Failed to unmarshal legacy categories: %v", err)
+		}
+		if len(spec.Categories) != 2 || spec.Categories[0].Name != "AUTH" || spec.Categories[1].Name != "API" {
+			t.Errorf("Expected AUTH and API, got %+v", spec.Categories)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		created := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+		spec := Specification{Categories: []Category{{
+			Name:        "AUTH",
+			Description: "Login, sessions and access control",
+			Owner:       "identity-team",
+			CreatedAt:   created,
+		}}}
+
+		data, err := yaml.Marshal(spec)
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		var decoded Specification
+		if err := yaml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal: %v", err)
+		}
+		if len(decoded.Categories) != 1 {
+			t.Fatalf("Expected 1 category, got %d", len(decoded.Categories))
+		}
+		got := decoded.Categories[0]
+		if got.Name != "AUTH" || got.Description != spec.Categories[0].Description || got.Owner != "identity-team" || !got.CreatedAt.Equal(created) {
+			t.Errorf("Category changed in round trip: %+v", got)
+		}
+	})
+}
+
+func TestCategoryPaths(t *testing.T) {
+	valid := []string{"AUTH", "AUTH/SSO", "A/B/C/D", "USER_MGMT/OAUTH2", strings.Repeat("X", CategoryNameMax)}
+	for _, path := range valid {
+		if err := ValidateCategoryPath(path); err != nil {
+			t.Errorf("ValidateCategoryPath(%q) = %v, want nil", path, err)
+		}
+	}
+	invalid := []string{"", "AUTH/", "/AUTH", "AUTH//SSO", "A/B/C/D/E", "AUTH/" + strings.Repeat("X", CategoryNameMax+1),
+		"AUTH.SSO", "auth/SSO", "AUTH-SSO", "AUTH SSO", "AUTH\\SSO", "ÉTAT"}
+	for _, path := range invalid {
+		if err := ValidateCategoryPath(path); err == nil {
+			t.Errorf("ValidateCategoryPath(%q) should fail", path)
+		}
+	}
+
+	// Only one of the paths that map to the ID segment AUTH.SSO is allowed
+	spec := &Specification{}
+	for _, path := range []string{"AUTH/SSO", "AUTH.SSO", "auth/sso"} {
+		_ = spec.AddCategory(Category{Name: path})
+	}
+	if len(spec.Categories) != 2 || !spec.HasCategory("AUTH/SSO") {
+		t.Errorf("categories = %+v, want only AUTH and AUTH/SSO", spec.Categories)
+	}
+
+	deepest := strings.Repeat(strings.Repeat("X", CategoryNameMax)+CategorySeparator, CategoryDepthMax-1) + strings.Repeat("X", CategoryNameMax)
+	if err := ValidateCategoryPath(deepest); err != nil || len(deepest) != CategoryPathMax {
+		t.Errorf("longest valid path has %d characters (err %v), want %d", len(deepest), err, CategoryPathMax)
+	}
+	maxLength := fmt.Sprintf("maxLength=%d", CategoryPathMax)
+	nameField, _ := reflect.TypeFor[Category]().FieldByName("Name")
+	categoryField, _ := reflect.TypeFor[Requirement]().FieldByName("Category")
+	for _, field := range []reflect.StructField{nameField, categoryField} {
+		if !strings.Contains(field.Tag.Get("jsonschema"), maxLength) {
+			t.Errorf("%s jsonschema tag %q should allow a full path (%s)", field.Name, field.Tag.Get("jsonschema"), maxLength)
+		}
+	}
+
+	if got := CategoryParent("AUTH/SSO/SAML"); got != "AUTH/SSO" {
+		t.Errorf("CategoryParent = %q, want AUTH/SSO", got)
+	}
+	if got := CategoryParent("AUTH"); got != "" {
+		t.Errorf("CategoryParent of a top-level category = %q, want empty", got)
+	}
+	if got := CategoryAncestors("AUTH/SSO/SAML"); len(got) != 2 || got[0] != "AUTH" || got[1] != "AUTH/SSO" {
+		t.Errorf("CategoryAncestors = %v, want [AUTH AUTH/SSO]", got)
+	}
+	if !CategoryWithin("AUTH/SSO", "AUTH") || !CategoryWithin("AUTH", "AUTH") || CategoryWithin("AUTHZ", "AUTH") {
+		t.Error("CategoryWithin should match the category and its descendants only")
+	}
+	if got, ok := RebaseCategory("AUTH/SSO", "AUTH", "SECURITY"); !ok || got != "SECURITY/SSO" {
+		t.Errorf("RebaseCategory = %q (ok=%v), want SECURITY/SSO", got, ok)
+	}
+}
+
+func TestNestedRequirementID(t *testing.T) {
+	reqID, err := NewRequirementID("auth/sso")
+	if err != nil {
+		t.Fatalf("Failed to generate requirement ID: %v", err)
+	}
+	if !strings.HasPrefix(reqID, "REQ-AUTH.SSO-") || len(reqID) != len("REQ-AUTH.SSO-")+10 {
+		t.Errorf("Nested requirement ID should be REQ-AUTH.SSO-{nanoid}, got %s", reqID)
+	}
+
+	newID, ok := RecategorizeRequirementID("REQ-AUTH.SSO-abc123", "AUTH/SSO", "IDENTITY/SSO")
+	if !ok || newID != "REQ-IDENTITY.SSO-abc123" {
+		t.Errorf("Expected REQ-IDENTITY.SSO-abc123, got %q (ok=%v)", newID, ok)
+	}
+}
+
+func TestSpecificationNestedCategories(t *testing.T) {
+	created := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	spec := &Specification{}
+
+	if err := spec.AddCategory(Category{Name: "AUTH/SSO", Description: "Single sign-on", CreatedAt: created}); err != nil {
+		t.Fatalf("AddCategory failed: %v", err)
+	}
+	if got := spec.CategoryNames(); len(got) != 2 || got[0] != "AUTH" || got[1] != "AUTH/SSO" {
+		t.Errorf("AddCategory should declare the parent first, got %v", got)
+	}
+	if err := spec.AddCategory(Category{Name: "AUTH"}); err == nil {
+		t.Error("AddCategory should reject an existing category")
+	}
+	if err := spec.AddCategory(Category{Name: "A/B/C/D/E"}); err == nil {
+		t.Error("AddCategory should reject an invalid path")
+	}
+	if got := spec.Subcategories("AUTH"); len(got) != 1 || got[0] != "AUTH/SSO" {
+		t.Errorf("Subcategories = %v, want [AUTH/SSO]", got)
+	}
+
+	spec.Requirements = []Requirement{{ID: "REQ-AUTH.SSO-abc123", Category: "AUTH/SSO"}}
+	if !spec.CategoryInUse("AUTH") {
+		t.Error("A parent is in use through its subcategories")
+	}
+
+	spec.Requirements = nil
+	spec.PruneCategory("AUTH/SSO")
+	if len(spec.Categories) != 0 {
+		t.Errorf("PruneCategory should drop empty parents too, got %v", spec.CategoryNames())
+	}
+}
+
+func TestSpecificationRenameCategorySubtree(t *testing.T) {
+	spec := &Specification{
+		Categories: []Category{{Name: "AUTH"}, {Name: "AUTH/SSO"}, {Name: "API"}},
+		Requirements: []Requirement{
+			{ID: "REQ-AUTH-abc123", Category: "AUTH"},
+			{ID: "REQ-AUTH.SSO-def456", Category: "AUTH/SSO"},
+		},
+	}
+
+	if err := spec.RenameCategory("AUTH", "AUTH/CORE", nil); err == nil {
+		t.Error("Renaming a category inside itself should fail")
+	}
+
+	ids := map[string]string{
+		"REQ-AUTH-abc123":     "REQ-SECURITY.AUTH-abc123",
+		"REQ-AUTH.SSO-def456": "REQ-SECURITY.AUTH.SSO-def456",
+	}
+	if err := spec.RenameCategory("AUTH", "SECURITY/AUTH", ids); err != nil {
+		t.Fatalf("RenameCategory failed: %v", err)
+	}
+	want := []string{"SECURITY/AUTH", "SECURITY/AUTH/SSO", "API", "SECURITY"}
+	if got := spec.CategoryNames(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Categories = %v, want %v", got, want)
+	}
+	if spec.Requirements[1].Category != "SECURITY/AUTH/SSO" || spec.Requirements[1].ID != "REQ-SECURITY.AUTH.SSO-def456" {
+		t.Errorf("Subcategory requirement not moved: %+v", spec.Requirements[1])
+	}
+}
+
+func TestSpecificationMergeCategorySubtree(t *testing.T) {
+	spec := &Specification{
+		Categories: []Category{{Name: "LOGIN"}, {Name: "LOGIN/SSO"}, {Name: "LOGIN/MFA"}, {Name: "AUTH"}, {Name: "AUTH/SSO"}},
+		Requirements: []Requirement{
+			{ID: "REQ-LOGIN.SSO-abc123", Category: "LOGIN/SSO"},
+			{ID: "REQ-LOGIN.MFA-def456", Category: "LOGIN/MFA"},
+		},
+	}
+
+	if err := spec.MergeCategory("AUTH", "AUTH/SSO", nil); err == nil {
+		t.Error("Merging a category into its own subcategory should fail")
+	}
+	if err := spec.MergeCategory("LOGIN", "AUTH", nil); err != nil {
+		t.Fatalf("MergeCategory failed: %v", err)
+	}
+	want := []string{"AUTH/MFA", "AUTH", "AUTH/SSO"}
+	if got := spec.CategoryNames(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Categories = %v, want %v", got, want)
+	}
+	if spec.Requirements[0].Category != "AUTH/SSO" || spec.Requirements[1].Category != "AUTH/MFA" {
+		t.Errorf("Requirements not moved: %+v", spec.Requirements)
+	}
+}
+
+func TestRequirementAliases(t *testing.T) {
+	spec := &Specification{}
+	var reqs []Requirement
+	for _, category := range []string{"AUTH", "AUTH", "API", "AUTH/SSO", "AUTH"} {
+		req := Requirement{ID: "REQ-" + category, Category: category}
+		spec.AssignAlias(&req)
+		reqs = append(reqs, req)
+	}
+
+	want := []string{"AUTH-001", "AUTH-002", "API-001", "AUTH.SSO-001", "AUTH-003"}
+	for i, req := range reqs {
+		if req.Alias != want[i] {
+			t.Errorf("alias %d = %s, want %s", i, req.Alias, want[i])
+		}
+	}
+	if RequirementAlias("auth", 1234) != "AUTH-1234" {
+		t.Errorf("RequirementAlias should widen past three digits, got %s", RequirementAlias("auth", 1234))
+	}
+
+	spec.Requirements = reqs[:2]
+	if req := spec.FindRequirement("auth-002"); req == nil || req.ID != reqs[1].ID {
+		t.Errorf("FindRequirement by alias = %+v, want %s", req, reqs[1].ID)
+	}
+	if id, ok := spec.ResolveRequirementID("AUTH-009"); ok || id != "AUTH-009" {
+		t.Errorf("ResolveRequirementID of an unknown alias = %q (ok=%v)", id, ok)
+	}
+}
+
+func TestRequirementMentionedIn(t *testing.T) {
+	req := &Requirement{ID: "REQ-AUTH-abc123", Alias: "AUTH-001"}
+	mentioned := []string{
+		"remove REQ-AUTH-abc123", "Remove auth-001 please", "AUTH-001", "drop (AUTH-001).",
+		"remove AUTH-001.", "Is AUTH-001... still needed?", "drop AUTH-001.\nThen add SSO", "...AUTH-001 again",
+	}
+	for _, text := range mentioned {
+		if !req.MentionedIn(text) {
+			t.Errorf("%q should mention the requirement", text)
+		}
+	}
+	notMentioned := []string{"remove AUTH-0012", "remove XAUTH-001", "remove AUTH-001.5", "remove AUTH-001.B", "remove V2.AUTH-001", "nothing here"}
+	for _, text := range notMentioned {
+		if req.MentionedIn(text) {
+			t.Errorf("%q should not mention the requirement", text)
+		}
+	}
+}
+
+func TestSpecificationLinks(t *testing.T) {
+	spec := &Specification{Requirements: []Requirement{
+		{ID: "REQ-LOGIN-aaa", Category: "LOGIN"},
+		{ID: "REQ-AUTH-bbb", Category: "AUTH"},
+	}}
+	dependsOn := RequirementLink{Type: LinkDependsOn, Target: "REQ-LOGIN-aaa"}
+
+	if err := spec.AddLink("REQ-AUTH-bbb", dependsOn); err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+	invalid := []struct {
+		source string
+		link   RequirementLink
+	}{
+		{"REQ-AUTH-bbb", dependsOn}, // Duplicate
+		{"REQ-AUTH-bbb", RequirementLink{Type: "blocks", Target: "REQ-LOGIN-aaa"}},
+		{"REQ-AUTH-bbb", RequirementLink{Type: LinkRefines, Target: "REQ-AUTH-bbb"}},
+		{"REQ-LOGIN-aaa", RequirementLink{Type: LinkDependsOn, Target: "REQ-AUTH-bbb"}}, // Cycle
+	}
+	for _, tt := range invalid {
+		if err := spec.AddLink(tt.source, tt.link); err == nil {
+			t.Errorf("AddLink(%s, %+v) should fail", tt.source, tt.link)
+		}
+	}
+
+	// Renaming a category retargets links to its requirements
+	spec.Categories = []Category{{Name: "LOGIN"}, {Name: "AUTH"}}
+	if err := spec.RenameCategory("LOGIN", "SIGNIN", map[string]string{"REQ-LOGIN-aaa": "REQ-SIGNIN-aaa"}); err != nil {
+		t.Fatalf("RenameCategory failed: %v", err)
+	}
+	if got := spec.Links(); len(got) != 1 || got[0].Target != "REQ-SIGNIN-aaa" {
+		t.Errorf("Links after rename = %+v, want target REQ-SIGNIN-aaa", got)
+	}
+
+	data, err := yaml.Marshal(spec.Requirements[1])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded Requirement
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(decoded.Links) != 1 || decoded.Links[0] != (RequirementLink{Type: LinkDependsOn, Target: "REQ-SIGNIN-aaa"}) {
+		t.Errorf("decoded links = %+v", decoded.Links)
+	}
+
+	if err := spec.RemoveLink("REQ-AUTH-bbb", RequirementLink{Type: LinkDependsOn, Target: "REQ-SIGNIN-aaa"}); err != nil {
+		t.Fatalf("RemoveLink failed: %v", err)
+	}
+	if err := spec.RemoveLink("REQ-AUTH-bbb", RequirementLink{Type: LinkDependsOn, Target: "REQ-SIGNIN-aaa"}); err == nil {
+		t.Error("removing a missing link should fail")
+	}
+}
+
+func TestRequirementStatus(t *testing.T) {
+	spec := &Specification{Requirements: []Requirement{{ID: "REQ-AUTH-aaa"}}}
+	if got := spec.Requirements[0].CurrentStatus(); got != StatusDraft {
+		t.Errorf("requirements without a status should be drafts, got %s", got)
+	}
+
+	for _, to := range []Status{StatusApproved, StatusImplemented, StatusVerified, StatusImplemented, StatusDeprecated, StatusDraft} {
+		if _, err := spec.ChangeStatus("REQ-AUTH-aaa", to); err != nil {
+			t.Fatalf("ChangeStatus to %s failed: %v", to, err)
+		}
+	}
+	if from, err := spec.ChangeStatus("REQ-AUTH-aaa", StatusVerified); err == nil || from != StatusDraft {
+		t.Errorf("draft -> verified should be rejected, got from=%s err=%v", from, err)
+	}
+	if _, err := spec.ChangeStatus("REQ-AUTH-aaa", "shipped"); err == nil {
+		t.Error("unknown statuses should be rejected")
+	}
+	if _, err := spec.ChangeStatus("REQ-AUTH-zzz", StatusApproved); err == nil {
+		t.Error("unknown requirements should be rejected")
+	}
+
+	spec.Requirements = append(spec.Requirements, Requirement{ID: "REQ-AUTH-bbb", Status: StatusVerified})
+	if got := spec.RequirementsWithStatus(StatusVerified, StatusApproved); len(got) != 1 || got[0].ID != "REQ-AUTH-bbb" {
+		t.Errorf("RequirementsWithStatus = %+v", got)
+	}
+	if got := spec.RequirementsWithStatus(); len(got) != 2 {
+		t.Errorf("RequirementsWithStatus() should return everything, got %d", len(got))
+	}
+}
+
+func TestCustomFields(t *testing.T) {
+	defs := []FieldDefinition{
+		{Name: "compliance", Type: FieldEnum, Values: []string{"SOC2", "HIPAA"}},
+		{Name: "target_release", Type: FieldString},
+		{Name: "story_points", Type: FieldInt},
+		{Name: "due", Type: FieldDate},
+	}
+	for _, d := range defs {
+		if err := d.Validate(); err != nil {
+			t.Errorf("%s: unexpected error %v", d.Name, err)
+		}
+	}
+	badDefs := []FieldDefinition{
+		{Name: "compliance", Type: FieldEnum},
+		{Name: "owner", Type: "person"},
+		{Name: "due date", Type: FieldDate},
+		{Name: "points", Type: FieldInt, Values: []string{"1"}},
+	}
+	for _, d := range badDefs {
+		if err := d.Validate(); err == nil {
+			t.Errorf("%+v: expected error", d)
+		}
+	}
+
+	checks := []struct {
+		field, value string
+		ok           bool
+	}{
+		{"compliance", "HIPAA", true},
+		{"compliance", "PCI", false},
+		{"story_points", "8", true},
+		{"story_points", "eight", false},
+		{"due", "2025-12-31", true},
+		{"due", "31/12/2025", false},
+		{"target_release", "", false},
+	}
+	for _, c := range checks {
+		err := FindField(defs, c.field).Check(c.value)
+		if (err == nil) != c.ok {
+			t.Errorf("Check(%s=%q) error = %v, want ok=%v", c.field, c.value, err, c.ok)
+		}
+	}
+
+	req := &Requirement{ID: "REQ-AUTH-aaa", Fields: map[string]string{"compliance": "SOC2", "team": "identity"}}
+	if err := ValidateRequirementFields(req, defs); err == nil || !strings.Contains(err.Error(), "field team is not declared") {
+		t.Errorf("expected undeclared field error, got %v", err)
+	}
+
+	spec := &Specification{Requirements: []Requirement{
+		{ID: "REQ-AUTH-aaa", Fields: map[string]string{"compliance": "SOC2"}},
+		{ID: "REQ-AUTH-bbb", Fields: map[string]string{"story_points": "eight"}},
+	}}
+	if err := ValidateSpecificationFields(spec, defs); err == nil || !strings.Contains(err.Error(), "REQ-AUTH-bbb") {
+		t.Errorf("expected invalid field error on REQ-AUTH-bbb, got %v", err)
+	}
+}
+
+func TestRequirementTagsAndFields(t *testing.T) {
+	shared := map[string]string{"compliance": "SOC2"}
+	spec := &Specification{Requirements: []Requirement{
+		{ID: "REQ-AUTH-aaa", Fields: shared},
+		{ID: "REQ-AUTH-bbb", Status: StatusApproved, Tags: []string{"SOC2"}},
+	}}
+
+	if err := spec.ChangeTags("REQ-AUTH-aaa", []string{"SOC2", "customer-acme"}, nil); err != nil {
+		t.Fatalf("ChangeTags failed: %v", err)
+	}
+	if err := spec.ChangeTags("REQ-AUTH-aaa", []string{"SOC2"}, nil); err == nil {
+		t.Error("adding a tag twice should fail")
+	}
+	if err := spec.ChangeTags("REQ-AUTH-aaa", []string{"PCI", "PCI"}, nil); err == nil || spec.Requirements[0].HasTag("PCI") {
+		t.Errorf("adding the same tag twice in one change should fail, got %v", err)
+	}
+	if err := spec.ChangeTags("REQ-AUTH-aaa", nil, []string{"HIPAA"}); err == nil {
+		t.Error("removing a missing tag should fail")
+	}
+	if err := spec.ChangeTags("REQ-AUTH-aaa", []string{"two words"}, nil); err == nil {
+		t.Error("tags with spaces should be rejected")
+	}
+	if err := spec.ChangeTags("REQ-AUTH-bbb", nil, []string{"SOC2"}); err != nil || spec.Requirements[1].Tags != nil {
+		t.Errorf("removing the last tag should leave none, got %v (err %v)", spec.Requirements[1].Tags, err)
+	}
+
+	old, err := spec.SetField("REQ-AUTH-aaa", "compliance", "HIPAA")
+	if err != nil || old != "SOC2" {
+		t.Fatalf("SetField = %q, %v", old, err)
+	}
+	if shared["compliance"] != "SOC2" {
+		t.Error("SetField must not modify maps shared with events")
+	}
+	if _, err := spec.SetField("REQ-AUTH-bbb", "compliance", ""); err == nil {
+		t.Error("clearing an unset field should fail")
+	}
+
+	filter := RequirementFilter{Tags: []string{"SOC2"}, Fields: map[string]string{"compliance": "HIPAA"}}
+	if got := spec.Filter(filter); len(got) != 1 || got[0].ID != "REQ-AUTH-aaa" {
+		t.Errorf("Filter(%+v) = %+v", filter, got)
+	}
+	if got := spec.Filter(RequirementFilter{Statuses: []Status{StatusApproved}, Tags: []string{"SOC2"}}); len(got) != 0 {
+		t.Errorf("criteria should all apply, got %+v", got)
+	}
+}
+
+func TestMetricCriterion(t *testing.T) {
+	metric := &MetricCriterion{
+		ID:         "AC-metric1",
+		Type:       "metric",
+		Measure:    "p95 latency",
+		Comparator: ComparatorLess,
+		Threshold:  200,
+		Unit:       "ms",
+		Conditions: "under 1k rps",
+	}
+	if got := metric.Expression(); got != "p95 latency < 200 ms under 1k rps" {
+		t.Errorf("Expression() = %q", got)
+	}
+	if got := (&MetricCriterion{Measure: "availability", Comparator: ">=", Threshold: 99.95, Unit: "%"}).Expression(); got != "availability >= 99.95 %" {
+		t.Errorf("Expression() = %q", got)
+	}
+
+	req := Requirement{
+		ID:                 "REQ-SEARCH-abc123",
+		Category:           "SEARCH",
+		AcceptanceCriteria: []AcceptanceCriterion{metric},
+	}
+	data, err := yaml.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded Requirement
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(decoded.AcceptanceCriteria) != 1 {
+		t.Fatalf("expected 1 criterion, got %d", len(decoded.AcceptanceCriteria))
+	}
+	got, ok := decoded.AcceptanceCriteria[0].(*MetricCriterion)
+	if !ok {
+		t.Fatalf("expected *MetricCriterion, got %T", decoded.AcceptanceCriteria[0])
+	}
+	if *got != *metric {
+		t.Errorf("round trip = %+v, want %+v", got, metric)
+	}
+}
+
+func TestScenarioOutlineCriterion(t *testing.T) {
+	outline := &ScenarioOutlineCriterion{
+		ID:    "AC-out",
+		Type:  "scenario_outline",
+		Given: "a user with role <role>",
+		When:  "the user opens <page>",
+		Then:  "the response is <status> within < 2 s",
+		Examples: ExamplesTable{
+			Columns: []string{"role", "page", "status"},
+			Rows:    [][]string{{"admin", "/admin", "200"}, {"guest", "/admin", "403"}},
+		},
+	}
+	if got := outline.Placeholders(); !slices.Equal(got, []string{"role", "page", "status"}) {
+		t.Errorf("Placeholders() = %v", got)
+	}
+	if err := ValidateScenarioOutlineCriterion(outline); err != nil {
+		t.Errorf("expected valid outline, got %v", err)
+	}
+
+	expanded := outline.Expand()
+	if len(expanded) != 2 {
+		t.Fatalf("expected 2 expanded criteria, got %d", len(expanded))
+	}
+	want := BehavioralCriterion{
+		ID: "AC-out.2", Type: "behavioral",
+		Given: "a user with role guest", When: "the user opens /admin", Then: "the response is 403 within < 2 s",
+	}
+	if expanded[1] != want {
+		t.Errorf("Expand()[1] = %+v, want %+v", expanded[1], want)
+	}
+
+	invalid := []struct {
+		name   string
+		mutate func(*ScenarioOutlineCriterion)
+		err    string
+	}{
+		{"placeholder without column", func(o *ScenarioOutlineCriterion) { o.Then = "the response is <code>" }, "no examples column for <code>"},
+		{"no placeholders", func(o *ScenarioOutlineCriterion) { o.Given, o.When, o.Then = "a user", "they log in", "it works" }, "at least one <placeholder>"},
+		{"short row", func(o *ScenarioOutlineCriterion) { o.Examples.Rows[1] = []string{"guest", "/admin"} }, "examples row 2 has 2 values, want 3"},
+		{"duplicate column", func(o *ScenarioOutlineCriterion) { o.Examples.Columns[2] = "role" }, "column role appears twice"},
+		{"no rows", func(o *ScenarioOutlineCriterion) { o.Examples.Rows = nil }, "examples must have 1-20 rows"},
+		{"bad column name", func(o *ScenarioOutlineCriterion) { o.Examples.Columns[0] = "user role" }, `column "user role" must start with a letter`},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			o := *outline
+			o.Examples.Columns = slices.Clone(outline.Examples.Columns)
+			o.Examples.Rows = slices.Clone(outline.Examples.Rows)
+			tt.mutate(&o)
+			err := ValidateScenarioOutlineCriterion(&o)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	// Decodes through Requirement.UnmarshalYAML, bare numbers included
+	var req Requirement
+	data := `
+id: REQ-AUTH-abc123
+acceptance_criteria:
+  - id: AC-out
+    type: scenario_outline
+    given: a user with role <role>
+    when: the user opens /admin
+    then: the response is <status>
+    examples:
+      columns: [role, status]
+      rows:
+        - [admin, 200]
+        - [guest, 403]
+`
+	if err := yaml.Unmarshal([]byte(data), &req); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	decoded, ok := req.AcceptanceCriteria[0].(*ScenarioOutlineCriterion)
+	if !ok {
+		t.Fatalf("expected *ScenarioOutlineCriterion, got %T", req.AcceptanceCriteria[0])
+	}
+	if decoded.Examples.String() != "role | status: admin | 200; guest | 403" {
+		t.Errorf("Examples = %q", decoded.Examples.String())
+	}
+}
+
+func TestCriterionVerification(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Verification
+		err  string
+	}{
+		{name: "none"},
+		{name: "method only", v: Verification{Method: VerificationInspection}},
+		{name: "method and ref", v: Verification{Method: VerificationTest, Ref: "tests/auth/login_test.go"}},
+		{name: "unknown method", v: Verification{Method: "review"}, err: "invalid verification_method: review"},
+		{name: "ref without method", v: Verification{Ref: "report.pdf"}, err: "verification_ref needs a verification_method"},
+		{name: "ref too long", v: Verification{Method: VerificationAnalysis, Ref: strings.Repeat("r", VerificationRefMax+1)}, err: "at most 200 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAssertionCriterion(&AssertionCriterion{Type: "assertion", Statement: "Holds", Verification: tt.v})
+			if tt.err == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	v := Verification{Method: VerificationTest, Ref: "tests/auth/login_test.go"}
+	if v.String() != "test: tests/auth/login_test.go" || !v.Verified() {
+		t.Errorf("String() = %q, Verified() = %v", v.String(), v.Verified())
+	}
+	if (Verification{Method: VerificationTest}).Verified() {
+		t.Error("a method without an artefact should not count as verified")
+	}
+
+	// Fields sit beside the criterion's own in YAML and JSON
+	criterion := &BehavioralCriterion{ID: "AC-1", Type: "behavioral", Given: "g", When: "w", Then: "t", Verification: v}
+	data, err := yaml.Marshal(Requirement{ID: "REQ-AUTH-abc123", AcceptanceCriteria: []AcceptanceCriterion{criterion}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), "verification_method: test") {
+		t.Errorf("expected inline verification_method in YAML:\n%s", data)
+	}
+	var decoded Requirement
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := decoded.AcceptanceCriteria[0].GetVerification(); got != v {
+		t.Errorf("decoded verification = %+v, want %+v", got, v)
+	}
+	jsonData, err := json.Marshal(criterion)
+	if err != nil {
+		t.Fatalf("marshal JSON: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"verification_ref":"tests/auth/login_test.go"`) {
+		t.Errorf("expected verification_ref in JSON: %s", jsonData)
+	}
+
+	outline := &ScenarioOutlineCriterion{
+		ID: "AC-out", Type: "scenario_outline", Given: "role <role>", When: "w", Then: "t",
+		Examples:     ExamplesTable{Columns: []string{"role"}, Rows: [][]string{{"admin"}}},
+		Verification: v,
+	}
+	if got := outline.Expand()[0].Verification; got != v {
+		t.Errorf("expanded criterion verification = %+v, want %+v", got, v)
+	}
+}
+
+func TestSpecificationSetVerification(t *testing.T) {
+	shared := &MetricCriterion{ID: "AC-p95", Type: "metric", Measure: "p95 latency", Comparator: "<", Threshold: 200}
+	spec := &Specification{Requirements: []Requirement{
+		{ID: "REQ-API-aaa", AcceptanceCriteria: []AcceptanceCriterion{shared}},
+	}}
+
+	v := Verification{Method: VerificationAnalysis, Ref: "load-test-2025-06.pdf"}
+	old, err := spec.SetVerification("REQ-API-aaa", "AC-p95", v)
+	if err != nil || old != (Verification{}) {
+		t.Fatalf("SetVerification = %+v, %v", old, err)
+	}
+	if got := spec.Requirements[0].AcceptanceCriteria[0]; got.GetVerification() != v || got.GetType() != "metric" {
+		t.Errorf("criterion after SetVerification = %+v", got)
+	}
+	if shared.Verification != (Verification{}) {
+		t.Error("SetVerification should not modify a criterion shared with an event")
+	}
+
+	invalid := []struct {
+		req, criterion string
+		v              Verification
+	}{
+		{"REQ-API-missing", "AC-p95", v},
+		{"REQ-API-aaa", "AC-missing", v},
+		{"REQ-API-aaa", "AC-p95", Verification{Ref: "report.pdf"}},
+	}
+	for _, tt := range invalid {
+		if _, err := spec.SetVerification(tt.req, tt.criterion, tt.v); err == nil {
+			t.Errorf("SetVerification(%s, %s, %+v) should fail", tt.req, tt.criterion, tt.v)
+		}
+	}
+
+	if old, err := spec.SetVerification("REQ-API-aaa", "AC-p95", Verification{}); err != nil || old != v {
+		t.Errorf("clearing = %+v, %v", old, err)
+	}
+	if _, err := spec.SetVerification("REQ-API-aaa", "AC-p95", Verification{}); err == nil {
+		t.Error("clearing a criterion with no verification should fail")
+	}
+}
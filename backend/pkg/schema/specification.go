@@ -1,8 +1,250 @@
 package schema
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
 // Specification represents the root document containing all requirements.
 type Specification struct {
 	Metadata     ProjectMetadata `json:"metadata" yaml:"metadata"`
 	Requirements []Requirement   `json:"requirements" yaml:"requirements"`
-	Categories   []string        `json:"categories" yaml:"categories"`
+	Categories   []Category      `json:"categories" yaml:"categories"`
+
+	// AliasSequences holds the last alias number issued per category (keyed
+	// like the alias prefix). Numbers only grow, so aliases are never reused.
+	AliasSequences map[string]int `json:"alias_sequences,omitempty" yaml:"alias_sequences,omitempty"`
+}
+
+// AssignAlias gives req the next alias in its category's sequence.
+func (s *Specification) AssignAlias(req *Requirement) {
+	if s.AliasSequences == nil {
+		s.AliasSequences = make(map[string]int)
+	}
+	key := categoryIDSegment(req.Category)
+	s.AliasSequences[key]++
+	req.Alias = RequirementAlias(req.Category, s.AliasSequences[key])
+}
+
+// FindRequirement returns the requirement whose ID or alias is ref
+// (case-insensitive), or nil.
+func (s *Specification) FindRequirement(ref string) *Requirement {
+	for i, req := range s.Requirements {
+		if strings.EqualFold(req.ID, ref) || (req.Alias != "" && strings.EqualFold(req.Alias, ref)) {
+			return &s.Requirements[i]
+		}
+	}
+	return nil
+}
+
+// ResolveRequirementID maps an ID or alias to the requirement's ID.
+// Unknown references are returned unchanged with ok false.
+func (s *Specification) ResolveRequirementID(ref string) (id string, ok bool) {
+	if req := s.FindRequirement(ref); req != nil {
+		return req.ID, true
+	}
+	return ref, false
+}
+
+// HasCategory reports whether name is a declared category.
+func (s *Specification) HasCategory(name string) bool {
+	return s.FindCategory(name) != nil
+}
+
+// FindCategory returns the declared category called name, or nil.
+func (s *Specification) FindCategory(name string) *Category {
+	if i := s.categoryIndex(name); i >= 0 {
+		return &s.Categories[i]
+	}
+	return nil
+}
+
+// CategoryNames returns the declared category names in order.
+func (s *Specification) CategoryNames() []string {
+	names := make([]string, len(s.Categories))
+	for i, c := range s.Categories {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// RemoveCategory drops the declared category called name, if any.
+func (s *Specification) RemoveCategory(name string) {
+	s.Categories = slices.DeleteFunc(s.Categories, func(c Category) bool { return c.Name == name })
+}
+
+func (s *Specification) categoryIndex(name string) int {
+	return slices.IndexFunc(s.Categories, func(c Category) bool { return c.Name == name })
+}
+
+// AddCategory declares c, first declaring any missing ancestors with the
+// same creation time.
+func (s *Specification) AddCategory(c Category) error {
+	if err := ValidateCategoryPath(c.Name); err != nil {
+		return err
+	}
+	if s.HasCategory(c.Name) {
+		return fmt.Errorf("category %s already exists", c.Name)
+	}
+	s.ensureAncestors(c.Name, c.CreatedAt)
+	s.Categories = append(s.Categories, c)
+	return nil
+}
+
+// EnsureCategory declares name and its ancestors where they are missing.
+func (s *Specification) EnsureCategory(name string, createdAt time.Time) {
+	s.ensureAncestors(name, createdAt)
+	if !s.HasCategory(name) {
+		s.Categories = append(s.Categories, Category{Name: name, CreatedAt: createdAt})
+	}
+}
+
+func (s *Specification) ensureAncestors(name string, createdAt time.Time) {
+	for _, ancestor := range CategoryAncestors(name) {
+		if !s.HasCategory(ancestor) {
+			s.Categories = append(s.Categories, Category{Name: ancestor, CreatedAt: createdAt})
+		}
+	}
+}
+
+// Subcategories returns the declared categories directly below name.
+func (s *Specification) Subcategories(name string) []string {
+	var children []string
+	for _, c := range s.Categories {
+		if CategoryParent(c.Name) == name {
+			children = append(children, c.Name)
+		}
+	}
+	return children
+}
+
+// CategoryInUse reports whether any requirement belongs to the category
+// or one of its subcategories.
+func (s *Specification) CategoryInUse(name string) bool {
+	for _, req := range s.Requirements {
+		if CategoryWithin(req.Category, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// PruneCategory drops name, then each of its ancestors in turn, for as
+// long as the category has neither requirements nor subcategories.
+func (s *Specification) PruneCategory(name string) {
+	for name != "" && !s.CategoryInUse(name) && len(s.Subcategories(name)) == 0 {
+		s.RemoveCategory(name)
+		name = CategoryParent(name)
+	}
+}
+
+// RenameCategory renames a category and its subcategories in place, moving
+// their requirements and re-identifying them per ids (old ID to new ID).
+// Missing ancestors of the new name are declared.
+func (s *Specification) RenameCategory(oldName, newName string, ids map[string]string) error {
+	i := s.categoryIndex(oldName)
+	if i < 0 {
+		return fmt.Errorf("category %s not found", oldName)
+	}
+	if oldName == newName {
+		return s.renameRequirementIDs(ids)
+	}
+	if CategoryWithin(newName, oldName) {
+		return fmt.Errorf("cannot move category %s inside itself", oldName)
+	}
+	if err := ValidateCategoryPath(newName); err != nil {
+		return err
+	}
+	for _, c := range s.Categories {
+		if renamed, ok := RebaseCategory(c.Name, oldName, newName); ok && s.HasCategory(renamed) {
+			return fmt.Errorf("category %s already exists", renamed)
+		}
+	}
+	if err := s.renameRequirementIDs(ids); err != nil {
+		return err
+	}
+
+	createdAt := s.Categories[i].CreatedAt
+	for j := range s.Categories {
+		s.Categories[j].Name, _ = RebaseCategory(s.Categories[j].Name, oldName, newName)
+	}
+	s.ensureAncestors(newName, createdAt)
+	s.moveRequirements(oldName, newName)
+	return nil
+}
+
+// MergeCategory moves every requirement of source into target, which must
+// already exist, re-identifying them per ids, and drops source.
+// Subcategories of source move under target, merging with any of the same
+// name already there.
+func (s *Specification) MergeCategory(source, target string, ids map[string]string) error {
+	if !s.HasCategory(source) {
+		return fmt.Errorf("category %s not found", source)
+	}
+	if !s.HasCategory(target) {
+		return fmt.Errorf("merge target category %s not found", target)
+	}
+	if source == target {
+		return fmt.Errorf("cannot merge category %s into itself", source)
+	}
+	if CategoryWithin(target, source) {
+		return fmt.Errorf("cannot merge category %s into its own subcategory %s", source, target)
+	}
+	if err := s.renameRequirementIDs(ids); err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool, len(s.Categories))
+	for _, c := range s.Categories {
+		declared[c.Name] = true
+	}
+	merged := make([]Category, 0, len(s.Categories))
+	for _, c := range s.Categories {
+		if moved, ok := RebaseCategory(c.Name, source, target); ok {
+			if declared[moved] {
+				continue
+			}
+			c.Name = moved
+		}
+		merged = append(merged, c)
+	}
+	s.Categories = merged
+	s.moveRequirements(source, target)
+	return nil
+}
+
+// moveRequirements rebases requirements in from or below it onto to.
+func (s *Specification) moveRequirements(from, to string) {
+	for i := range s.Requirements {
+		s.Requirements[i].Category, _ = RebaseCategory(s.Requirements[i].Category, from, to)
+	}
+}
+
+// renameRequirementIDs applies every rename or none, retargeting links.
+func (s *Specification) renameRequirementIDs(ids map[string]string) error {
+	index := make(map[string]int, len(s.Requirements))
+	for i, req := range s.Requirements {
+		index[req.ID] = i
+	}
+	for oldID, newID := range ids {
+		if _, ok := index[oldID]; !ok {
+			return fmt.Errorf("requirement %s not found", oldID)
+		}
+		if _, taken := index[newID]; taken && ids[newID] == "" {
+			return fmt.Errorf("requirement %s already exists", newID)
+		}
+	}
+	for oldID, newID := range ids {
+		s.Requirements[index[oldID]].ID = newID
+	}
+	for i := range s.Requirements {
+		for j, link := range s.Requirements[i].Links {
+			if newID, ok := ids[link.Target]; ok {
+				s.Requirements[i].Links[j].Target = newID
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Status is where a requirement is in its lifecycle.
+type Status string
+
+const (
+	StatusDraft       Status = "draft"       // Proposed, not yet agreed
+	StatusApproved    Status = "approved"    // Agreed, ready to build
+	StatusImplemented Status = "implemented" // Built, awaiting verification
+	StatusVerified    Status = "verified"    // Acceptance criteria confirmed
+	StatusDeprecated  Status = "deprecated"  // No longer in force
+)
+
+// Statuses lists every status in lifecycle order.
+var Statuses = []Status{StatusDraft, StatusApproved, StatusImplemented, StatusVerified, StatusDeprecated}
+
+// statusTransitions lists the statuses each status may move to. Work can
+// step back one stage (e.g. a verified requirement regressing), and any
+// active requirement can be deprecated; a deprecated one can only be
+// revived as a draft.
+var statusTransitions = map[Status][]Status{
+	StatusDraft:       {StatusApproved, StatusDeprecated},
+	StatusApproved:    {StatusImplemented, StatusDraft, StatusDeprecated},
+	StatusImplemented: {StatusVerified, StatusApproved, StatusDeprecated},
+	StatusVerified:    {StatusImplemented, StatusDeprecated},
+	StatusDeprecated:  {StatusDraft},
+}
+
+// Valid reports whether s is a known status.
+func (s Status) Valid() bool {
+	return slices.Contains(Statuses, s)
+}
+
+// CanTransition reports whether a requirement may move from s to to.
+func (s Status) CanTransition(to Status) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+// Transitions returns the statuses s may move to.
+func (s Status) Transitions() []Status {
+	return slices.Clone(statusTransitions[s])
+}
+
+// CurrentStatus returns the requirement's status; requirements recorded
+// before statuses existed count as drafts.
+func (r *Requirement) CurrentStatus() Status {
+	if r.Status == "" {
+		return StatusDraft
+	}
+	return r.Status
+}
+
+// ChangeStatus moves requirement id to status to along an allowed
+// transition and returns the status it left.
+func (s *Specification) ChangeStatus(id string, to Status) (Status, error) {
+	if !to.Valid() {
+		return "", fmt.Errorf("invalid status: %s", to)
+	}
+	i := s.requirementIndex(id)
+	if i < 0 {
+		return "", fmt.Errorf("requirement %s not found", id)
+	}
+	from := s.Requirements[i].CurrentStatus()
+	if !from.CanTransition(to) {
+		return from, fmt.Errorf("requirement %s cannot move from %s to %s (allowed: %v)", id, from, to, from.Transitions())
+	}
+	s.Requirements[i].Status = to
+	return from, nil
+}
+
+// RequirementsWithStatus returns the requirements in any of statuses, or
+// every requirement when none are given.
+func (s *Specification) RequirementsWithStatus(statuses ...Status) []Requirement {
+	return s.Filter(RequirementFilter{Statuses: statuses})
+}
@@ -2,7 +2,10 @@ package schema
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"slices"
+	"strings"
 )
 
 var semverPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
@@ -39,9 +42,14 @@ func ValidateRequirement(r *Requirement) error {
 		return fmt.Errorf("invalid priority: %s", r.Priority)
 	}
 
+	// Validate status (empty means draft)
+	if r.Status != "" && !r.Status.Valid() {
+		return fmt.Errorf("invalid status: %s", r.Status)
+	}
+
 	// Validate category
-	if len(r.Category) < CategoryNameMin || len(r.Category) > CategoryNameMax {
-		return fmt.Errorf("category must be %d-%d characters", CategoryNameMin, CategoryNameMax)
+	if err := ValidateCategoryPath(r.Category); err != nil {
+		return err
 	}
 
 	// Validate description
@@ -54,6 +62,13 @@ func ValidateRequirement(r *Requirement) error {
 		return fmt.Errorf("rationale must be %d-%d characters", RequirementRationaleMin, RequirementRationaleMax)
 	}
 
+	// Validate tags
+	for _, tag := range r.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
 	// Validate acceptance criteria count
 	if len(r.AcceptanceCriteria) < AcceptanceCriterionMin || len(r.AcceptanceCriteria) > AcceptanceCriterionMax {
 		return fmt.Errorf("must have %d-%d acceptance criteria", AcceptanceCriterionMin, AcceptanceCriterionMax)
@@ -62,6 +77,32 @@ func ValidateRequirement(r *Requirement) error {
 	return nil
 }
 
+// ValidateRequirementFields checks every custom field on a requirement is
+// declared in defs and holds a value of the declared type.
+func ValidateRequirementFields(r *Requirement, defs []FieldDefinition) error {
+	for name, value := range r.Fields {
+		def := FindField(defs, name)
+		if def == nil {
+			return fmt.Errorf("requirement %s: field %s is not declared", r.ID, name)
+		}
+		if err := def.Check(value); err != nil {
+			return fmt.Errorf("requirement %s: %w", r.ID, err)
+		}
+	}
+	return nil
+}
+
+// ValidateSpecificationFields checks the custom fields of every
+// requirement in s against defs.
+func ValidateSpecificationFields(s *Specification, defs []FieldDefinition) error {
+	for i := range s.Requirements {
+		if err := ValidateRequirementFields(&s.Requirements[i], defs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ValidateBehavioralCriterion validates a behavioral acceptance criterion.
 func ValidateBehavioralCriterion(b *BehavioralCriterion) error {
 	if b.Type != "behavioral" {
@@ -76,7 +117,7 @@ func ValidateBehavioralCriterion(b *BehavioralCriterion) error {
 	if len(b.Then) > GivenWhenThenMax {
 		return fmt.Errorf("then must be at most %d characters", GivenWhenThenMax)
 	}
-	return nil
+	return ValidateVerification(b.Verification)
 }
 
 // ValidateAssertionCriterion validates an assertion acceptance criterion.
@@ -87,5 +128,100 @@ func ValidateAssertionCriterion(a *AssertionCriterion) error {
 	if len(a.Statement) > AssertionStatementMax {
 		return fmt.Errorf("statement must be at most %d characters", AssertionStatementMax)
 	}
+	return ValidateVerification(a.Verification)
+}
+
+// ValidateMetricCriterion validates a metric acceptance criterion.
+func ValidateMetricCriterion(m *MetricCriterion) error {
+	if m.Type != "metric" {
+		return fmt.Errorf("type must be 'metric'")
+	}
+	if m.Measure == "" || len(m.Measure) > MetricMeasureMax {
+		return fmt.Errorf("measure must be 1-%d characters", MetricMeasureMax)
+	}
+	if !slices.Contains(Comparators, m.Comparator) {
+		return fmt.Errorf("comparator must be one of %s", strings.Join(Comparators, " "))
+	}
+	if math.IsNaN(m.Threshold) || math.IsInf(m.Threshold, 0) {
+		return fmt.Errorf("threshold must be a finite number")
+	}
+	if len(m.Unit) > MetricUnitMax {
+		return fmt.Errorf("unit must be at most %d characters", MetricUnitMax)
+	}
+	if len(m.Conditions) > MetricConditionsMax {
+		return fmt.Errorf("conditions must be at most %d characters", MetricConditionsMax)
+	}
+	return ValidateVerification(m.Verification)
+}
+
+// ValidateScenarioOutlineCriterion validates a scenario outline: the steps
+// must use at least one placeholder, every placeholder needs a column, and
+// every row needs a value per column.
+func ValidateScenarioOutlineCriterion(o *ScenarioOutlineCriterion) error {
+	if o.Type != "scenario_outline" {
+		return fmt.Errorf("type must be 'scenario_outline'")
+	}
+	if o.Given == "" || o.When == "" || o.Then == "" {
+		return fmt.Errorf("given, when and then are required")
+	}
+	if len(o.Given) > GivenWhenThenMax || len(o.When) > GivenWhenThenMax || len(o.Then) > GivenWhenThenMax {
+		return fmt.Errorf("given, when and then must be at most %d characters", GivenWhenThenMax)
+	}
+
+	cols := o.Examples.Columns
+	if len(cols) < 1 || len(cols) > ExamplesColumnsMax {
+		return fmt.Errorf("examples must have 1-%d columns", ExamplesColumnsMax)
+	}
+	for i, col := range cols {
+		if !placeholderPattern.MatchString("<" + col + ">") {
+			return fmt.Errorf("column %q must start with a letter and contain only letters, digits, '_' or '-'", col)
+		}
+		if slices.Contains(cols[:i], col) {
+			return fmt.Errorf("column %s appears twice", col)
+		}
+	}
+
+	placeholders := o.Placeholders()
+	if len(placeholders) == 0 {
+		return fmt.Errorf("steps must use at least one <placeholder>")
+	}
+	var missing []string
+	for _, name := range placeholders {
+		if !slices.Contains(cols, name) {
+			missing = append(missing, "<"+name+">")
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no examples column for %s", strings.Join(missing, ", "))
+	}
+
+	if len(o.Examples.Rows) < 1 || len(o.Examples.Rows) > ExamplesRowsMax {
+		return fmt.Errorf("examples must have 1-%d rows", ExamplesRowsMax)
+	}
+	for i, row := range o.Examples.Rows {
+		if len(row) != len(cols) {
+			return fmt.Errorf("examples row %d has %d values, want %d", i+1, len(row), len(cols))
+		}
+		for _, value := range row {
+			if len(value) > ExampleValueMax {
+				return fmt.Errorf("examples row %d: values must be at most %d characters", i+1, ExampleValueMax)
+			}
+		}
+	}
+	return ValidateVerification(o.Verification)
+}
+
+// ValidateVerification checks a criterion's verification method and
+// artefact. Both may be empty, but an artefact needs a method.
+func ValidateVerification(v Verification) error {
+	if v.Method != "" && !v.Method.Valid() {
+		return fmt.Errorf("invalid verification_method: %s, must be test, inspection, analysis or demonstration", v.Method)
+	}
+	if v.Ref != "" && v.Method == "" {
+		return fmt.Errorf("verification_ref needs a verification_method")
+	}
+	if len(v.Ref) > VerificationRefMax {
+		return fmt.Errorf("verification_ref must be at most %d characters", VerificationRefMax)
+	}
 	return nil
 }
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+)
+
+// VerificationMethod is how an acceptance criterion is shown to hold, as
+// in the IADT methods of systems engineering.
+type VerificationMethod string
+
+const (
+	VerificationTest          VerificationMethod = "test"
+	VerificationInspection    VerificationMethod = "inspection"
+	VerificationAnalysis      VerificationMethod = "analysis"
+	VerificationDemonstration VerificationMethod = "demonstration"
+)
+
+// VerificationMethods lists the verification methods.
+var VerificationMethods = []VerificationMethod{VerificationTest, VerificationInspection, VerificationAnalysis, VerificationDemonstration}
+
+// Valid reports whether m is a known verification method.
+func (m VerificationMethod) Valid() bool {
+	return slices.Contains(VerificationMethods, m)
+}
+
+// Verification records how a criterion is verified and by what artefact,
+// e.g. a test file, inspection checklist or analysis report. Both parts
+// are optional; every criterion type embeds it.
+type Verification struct {
+	Method VerificationMethod `json:"verification_method,omitempty" yaml:"verification_method,omitempty" jsonschema:"enum=test,enum=inspection,enum=analysis,enum=demonstration"`
+	Ref    string             `json:"verification_ref,omitempty" yaml:"verification_ref,omitempty" jsonschema:"maxLength=200"`
+}
+
+// GetVerification implements AcceptanceCriterion for the embedding types.
+func (v Verification) GetVerification() Verification { return v }
+
+// Verified reports whether both the method and its artefact are recorded.
+func (v Verification) Verified() bool {
+	return v.Method != "" && v.Ref != ""
+}
+
+// String renders the verification as "method" or "method: ref".
+func (v Verification) String() string {
+	if v.Ref == "" {
+		return string(v.Method)
+	}
+	return fmt.Sprintf("%s: %s", v.Method, v.Ref)
+}
+
+// SetVerification records how criterion criterionID of requirement id is
+// verified, clearing it when v is empty, and returns the previous
+// verification.
+func (s *Specification) SetVerification(id, criterionID string, v Verification) (Verification, error) {
+	if err := ValidateVerification(v); err != nil {
+		return Verification{}, err
+	}
+	i := s.requirementIndex(id)
+	if i < 0 {
+		return Verification{}, fmt.Errorf("requirement %s not found", id)
+	}
+	req := &s.Requirements[i]
+	j := slices.IndexFunc(req.AcceptanceCriteria, func(ac AcceptanceCriterion) bool { return ac.GetID() == criterionID })
+	if j < 0 {
+		return Verification{}, fmt.Errorf("requirement %s has no acceptance criterion %s", id, criterionID)
+	}
+	old := req.AcceptanceCriteria[j].GetVerification()
+	if v == (Verification{}) && old == v {
+		return old, fmt.Errorf("acceptance criterion %s has no verification", criterionID)
+	}
+
+	var updated AcceptanceCriterion
+	switch c := req.AcceptanceCriteria[j].(type) {
+	case *BehavioralCriterion:
+		clone := *c
+		clone.Verification = v
+		updated = &clone
+	case *AssertionCriterion:
+		clone := *c
+		clone.Verification = v
+		updated = &clone
+	case *MetricCriterion:
+		clone := *c
+		clone.Verification = v
+		updated = &clone
+	case *ScenarioOutlineCriterion:
+		clone := *c
+		clone.Verification = v
+		updated = &clone
+	default:
+		return Verification{}, fmt.Errorf("acceptance criterion %s: unsupported type %s", criterionID, c.GetType())
+	}
+	// Criteria may be shared with the event that added the requirement
+	req.AcceptanceCriteria = slices.Clone(req.AcceptanceCriteria)
+	req.AcceptanceCriteria[j] = updated
+	return old, nil
+}